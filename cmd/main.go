@@ -1,16 +1,44 @@
 package main
 
 import (
+	"context"
+
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
 	"go.uber.org/zap"
 
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/repository"
+	apiKeyRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/apikey"
+	attemptRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/attempt"
+	exportRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/export"
+	identityRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/identity"
+	impersonationRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/impersonation"
+	oauthRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/oauth"
+	passkeyRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/passkey"
+	roleRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/role"
+	tokenRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/token"
+	twoFactorRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/twofactor"
 	userRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/user"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/access"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/apikey"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/auth"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/export"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/hasher"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/impersonation"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/ldap"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/oauth"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/oidc"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/passkey"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/policy"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/user"
+	apiKeyV1 "github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/handlers/apikey/v1"
 	authV1 "github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/handlers/auth/v1"
+	exportV1 "github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/handlers/export/v1"
+	oauthV1 "github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/handlers/oauth/v1"
+	oidcV1 "github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/handlers/oidc/v1"
+	passkeyV1 "github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/handlers/passkey/v1"
 	userV1 "github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/handlers/user/v1"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/middlewares"
 )
@@ -43,17 +71,89 @@ func main() {
 			// REST API
 			infra.NewEcho,
 			middlewares.NewLogger,
+			middlewares.NewAuth,
+			middlewares.NewCookies,
 			authV1.NewAuth,
 			userV1.NewUser,
+			passkeyV1.NewPasskey,
+			apiKeyV1.NewAPIKey,
+			oidcV1.NewOIDC,
+			oauthV1.NewOAuth,
+			exportV1.NewExport,
 
 			// services and infra
 			infra.NewPostgresConnection,
+			infra.NewScheduler,
+			infra.NewMailer,
 			fx.Annotate(
 				userRepo.New,
 				fx.As(new(repository.UserRepository)),
 			),
+			fx.Annotate(
+				tokenRepo.New,
+				fx.As(new(repository.TokenRepository)),
+			),
+			fx.Annotate(
+				roleRepo.New,
+				fx.As(new(repository.RoleRepository)),
+			),
+			fx.Annotate(
+				twoFactorRepo.New,
+				fx.As(new(repository.TwoFactorRepository)),
+			),
+			fx.Annotate(
+				passkeyRepo.New,
+				fx.As(new(repository.PasskeyRepository)),
+			),
+			fx.Annotate(
+				attemptRepo.New,
+				fx.As(new(repository.AttemptRepository)),
+			),
+			fx.Annotate(
+				apiKeyRepo.New,
+				fx.As(new(repository.APIKeyRepository)),
+			),
+			fx.Annotate(
+				identityRepo.New,
+				fx.As(new(repository.IdentityRepository)),
+			),
+			fx.Annotate(
+				oauthRepo.New,
+				fx.As(new(repository.OAuthRepository)),
+			),
+			fx.Annotate(
+				impersonationRepo.New,
+				fx.As(new(repository.ImpersonationRepository)),
+			),
+			fx.Annotate(
+				exportRepo.New,
+				fx.As(new(repository.ExportRepository)),
+			),
+			policy.NewService,
+			hasher.NewService,
+			ldap.NewService,
 			user.NewService,
 			auth.NewService,
+			access.NewService,
+			passkey.NewService,
+			apikey.NewService,
+			oidc.NewService,
+			oauth.NewService,
+			impersonation.NewService,
+			fx.Annotate(
+				export.NewService,
+				fx.ParamTags(``, ``, ``, ``, ``, ``, `group:"exporters"`),
+			),
+
+			// sections of the personal data export, a module with its own tables adds its exporter here
+			asExporter(user.NewExporter),
+			asExporter(access.NewExporter),
+			asExporter(auth.NewExporter),
+			asExporter(passkey.NewExporter),
+			asExporter(apikey.NewExporter),
+			asExporter(oidc.NewExporter),
+			asExporter(oauth.NewExporter),
+			asExporter(impersonation.NewExporter),
 		),
 
 		fx.WithLogger(func(log *zap.Logger) fxevent.Logger {
@@ -69,6 +169,37 @@ func main() {
 
 		// need each of controllers, to register them
 		// no need to call infra, apis and services, they're deps, started automatically
-		fx.Invoke(func(auth *authV1.Auth) {}, func(user *userV1.User) {}),
+		fx.Invoke(func(auth *authV1.Auth) {}, func(user *userV1.User) {}, func(passkey *passkeyV1.Passkey) {}, func(apiKey *apiKeyV1.APIKey) {}, func(oidc *oidcV1.OIDC) {}, func(oauth *oauthV1.OAuth) {}, func(export *exportV1.Export) {}),
+
+		// first admin is assigned after migrations are applied
+		fx.Invoke(func(lc fx.Lifecycle, accessService *access.Service) {
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					return accessService.BootstrapAdmin(ctx, cfg.BootstrapAdminEmail)
+				},
+			})
+		}),
+
+		// background jobs, started together with the app
+		fx.Invoke(func(scheduler *infra.Scheduler, authService *auth.Service, passkeyService *passkey.Service, apiKeyService *apikey.Service, oidcService *oidc.Service, oauthService *oauth.Service, exportService *export.Service) {
+			scheduler.Every("purge expired tokens", cfg.TokenCleanupInterval, authService.PurgeExpiredTokens)
+			scheduler.Every("purge stale login attempts", cfg.TokenCleanupInterval, authService.PurgeLoginAttempts)
+			scheduler.Every("purge expired passkey ceremonies", cfg.TokenCleanupInterval, passkeyService.PurgeExpiredSessions)
+			scheduler.Every("purge expired api keys", cfg.TokenCleanupInterval, apiKeyService.PurgeExpired)
+			scheduler.Every("purge expired oidc states", cfg.TokenCleanupInterval, oidcService.PurgeExpiredStates)
+			scheduler.Every("purge expired oauth codes", cfg.TokenCleanupInterval, oauthService.PurgeExpiredCodes)
+			scheduler.Every("purge deleted accounts", cfg.TokenCleanupInterval, authService.PurgeDeletedAccounts)
+			scheduler.Every("build data exports", cfg.DataExportInterval, exportService.ProcessPending)
+			scheduler.Every("purge expired data exports", cfg.TokenCleanupInterval, exportService.PurgeExpired)
+		}),
 	).Run()
 }
+
+// asExporter - зарегистрировать раздел выгрузки персональных данных
+func asExporter(constructor any) any {
+	return fx.Annotate(
+		constructor,
+		fx.As(new(service.DataExporter)),
+		fx.ResultTags(`group:"exporters"`),
+	)
+}
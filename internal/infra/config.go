@@ -3,6 +3,10 @@ package infra
 import (
 	"errors"
 	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -18,6 +22,151 @@ type Config struct {
 	DbName     string `env:"POSTGRES_DB" env-default:"backend"`
 	JwtSecret  string `env:"JWT_SECRET"`
 	Debug      bool   `env:"DEBUG" env-default:"false"`
+
+	// JwtPrivateKeyPath - PEM private key (RSA, P-256 ECDSA or Ed25519) used to sign tokens instead of JwtSecret
+	JwtPrivateKeyPath string `env:"JWT_PRIVATE_KEY_PATH"`
+	// JwtPublicKeyPaths - PEM public keys of previous signing keys, still accepted during rotation
+	JwtPublicKeyPaths []string `env:"JWT_PUBLIC_KEY_PATHS" env-separator:","`
+
+	// RefreshTokenTTL - refresh token lifetime, rotated on every use
+	RefreshTokenTTL time.Duration `env:"REFRESH_TOKEN_TTL" env-default:"720h"`
+	// TokenCleanupInterval - how often expired revocations and refresh tokens are purged
+	TokenCleanupInterval time.Duration `env:"TOKEN_CLEANUP_INTERVAL" env-default:"1h"`
+
+	// LoginMaxFailures - failed logins in a row after which the account is locked, 0 disables
+	LoginMaxFailures int32 `env:"LOGIN_MAX_FAILURES" env-default:"5"`
+	// LoginMaxFailuresPerIP - failed logins from one client IP after which the IP is locked, 0 disables
+	LoginMaxFailuresPerIP int32 `env:"LOGIN_MAX_FAILURES_PER_IP" env-default:"20"`
+	// LoginFailureWindow - failures older than this are forgotten
+	LoginFailureWindow time.Duration `env:"LOGIN_FAILURE_WINDOW" env-default:"1h"`
+	// LoginLockoutBase - first lockout duration, doubled on every further failure
+	LoginLockoutBase time.Duration `env:"LOGIN_LOCKOUT_BASE" env-default:"1m"`
+	// LoginLockoutMax - upper bound of a single lockout
+	LoginLockoutMax time.Duration `env:"LOGIN_LOCKOUT_MAX" env-default:"30m"`
+	// CORSOrigins - origins allowed to call the API from a browser. "*" allows any origin,
+	// but then browsers do not send cookies, so cookie sessions need the frontend origins listed
+	CORSOrigins []string `env:"CORS_ORIGINS" env-separator:"," env-default:"*"`
+	// CookieDomain - domain of the session cookies, empty for the API host only
+	CookieDomain string `env:"COOKIE_DOMAIN"`
+	// CookieSecure - send session cookies over HTTPS only, disable for local development over plain http
+	CookieSecure bool `env:"COOKIE_SECURE" env-default:"true"`
+	// CookieSameSite - SameSite of the session cookies: strict, lax or none for a frontend on another site
+	CookieSameSite string `env:"COOKIE_SAME_SITE" env-default:"strict"`
+	// TrustProxyHeaders - take client IP from X-Forwarded-For, enable only behind a reverse proxy
+	TrustProxyHeaders bool `env:"TRUST_PROXY_HEADERS" env-default:"false"`
+
+	// APIKeyMaxTTL - longest lifetime a user may give to an API key
+	APIKeyMaxTTL time.Duration `env:"API_KEY_MAX_TTL" env-default:"8760h"`
+
+	// AuthProviders - where Login checks email and password, in this order: "local" (password hashes
+	// in the database) and "ldap". The first provider that knows the user decides.
+	AuthProviders []string `env:"AUTH_PROVIDERS" env-separator:"," env-default:"local"`
+	// LDAPURL - ldap:// or ldaps:// address of the directory, required for the ldap provider
+	LDAPURL string `env:"LDAP_URL"`
+	// LDAPStartTLS - upgrade an ldap:// connection with StartTLS before sending any password
+	LDAPStartTLS bool `env:"LDAP_START_TLS" env-default:"false"`
+	// LDAPBindDN - service account that searches for the user entry, empty for an anonymous search
+	LDAPBindDN       string `env:"LDAP_BIND_DN"`
+	LDAPBindPassword string `env:"LDAP_BIND_PASSWORD"`
+	// LDAPBaseDN - subtree the users are searched in, required for the ldap provider
+	LDAPBaseDN string `env:"LDAP_BASE_DN"`
+	// LDAPUserFilter - search filter, %s is replaced with the escaped email the user logs in with
+	LDAPUserFilter string `env:"LDAP_USER_FILTER" env-default:"(mail=%s)"`
+	// LDAPEmailAttribute - attribute with the email the user is provisioned with
+	LDAPEmailAttribute string `env:"LDAP_EMAIL_ATTRIBUTE" env-default:"mail"`
+	// LDAPIDAttribute - stable entry ID the user is linked by: entryUUID in OpenLDAP, objectGUID in Active Directory
+	LDAPIDAttribute string `env:"LDAP_ID_ATTRIBUTE" env-default:"entryUUID"`
+
+	// OIDCProviderNames - OpenID Connect providers for "Sign in with ...", each one is configured by
+	// OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET and optional OIDC_<NAME>_SCOPES
+	OIDCProviderNames []string `env:"OIDC_PROVIDERS" env-separator:","`
+	// OIDCProviders - filled from the per-provider variables above
+	OIDCProviders []OIDCProvider
+	// OIDCRedirectURL - frontend page the provider returns to, it posts code and state back to the API
+	OIDCRedirectURL string `env:"OIDC_REDIRECT_URL" env-default:"http://localhost:8080/oidc/callback"`
+	// OAuthCodeTTL - lifetime of an authorization code issued to an OAuth client, exchanged right after the redirect
+	OAuthCodeTTL time.Duration `env:"OAUTH_CODE_TTL" env-default:"1m"`
+	// ImpersonationTTL - lifetime of the token an admin gets to act as another user, it cannot be refreshed
+	ImpersonationTTL time.Duration `env:"IMPERSONATION_TTL" env-default:"15m"`
+
+	// TotpIssuer - issuer shown in authenticator apps
+	TotpIssuer string `env:"TOTP_ISSUER" env-default:"webTemplate"`
+	// MfaChallengeTTL - how long the second factor may be entered after a successful password check
+	MfaChallengeTTL time.Duration `env:"MFA_CHALLENGE_TTL" env-default:"5m"`
+
+	// WebAuthnRPID - relying party ID, the domain passkeys are bound to
+	WebAuthnRPID string `env:"WEBAUTHN_RP_ID" env-default:"localhost"`
+	// WebAuthnRPName - relying party name shown by the authenticator
+	WebAuthnRPName string `env:"WEBAUTHN_RP_NAME" env-default:"webTemplate"`
+	// WebAuthnOrigins - origins allowed to run WebAuthn ceremonies
+	WebAuthnOrigins []string `env:"WEBAUTHN_ORIGINS" env-separator:"," env-default:"http://localhost:8080"`
+
+	// PublicURL - base URL of the frontend, used to build links sent by email
+	PublicURL string `env:"PUBLIC_URL" env-default:"http://localhost:8080"`
+	// RequireEmailVerification - forbid password login until the email is verified
+	RequireEmailVerification bool `env:"REQUIRE_EMAIL_VERIFICATION" env-default:"false"`
+	// EmailVerificationTTL - lifetime of the link sent after registration
+	EmailVerificationTTL time.Duration `env:"EMAIL_VERIFICATION_TTL" env-default:"24h"`
+	// VerificationMailMaxPerEmail - verification emails sent to one address within LOGIN_FAILURE_WINDOW, 0 disables the limit
+	VerificationMailMaxPerEmail int32 `env:"VERIFICATION_MAIL_MAX_PER_EMAIL" env-default:"5"`
+	// EmailRevertTTL - lifetime of the link sent to the old address after an email change, it undoes the change
+	EmailRevertTTL time.Duration `env:"EMAIL_REVERT_TTL" env-default:"168h"`
+	// AccountDeletionGrace - how long a deleted account can be restored before the purge removes all of its data
+	AccountDeletionGrace time.Duration `env:"ACCOUNT_DELETION_GRACE" env-default:"720h"`
+	// DataExportTTL - how long a personal data archive and its download link are kept
+	DataExportTTL time.Duration `env:"DATA_EXPORT_TTL" env-default:"72h"`
+	// DataExportInterval - how often requested data exports are picked up and built
+	DataExportInterval time.Duration `env:"DATA_EXPORT_INTERVAL" env-default:"30s"`
+	// PasswordResetTTL - lifetime of the password reset link
+	PasswordResetTTL time.Duration `env:"PASSWORD_RESET_TTL" env-default:"1h"`
+	// PasswordResetMailMaxPerEmail - password reset emails sent to one address within LOGIN_FAILURE_WINDOW, 0 disables the limit
+	PasswordResetMailMaxPerEmail int32 `env:"PASSWORD_RESET_MAIL_MAX_PER_EMAIL" env-default:"5"`
+	// MagicLinkTTL - lifetime of the passwordless login link
+	MagicLinkTTL time.Duration `env:"MAGIC_LINK_TTL" env-default:"15m"`
+	// MagicLinkMaxPerEmail - login links sent to one address within LOGIN_FAILURE_WINDOW, 0 disables the limit
+	MagicLinkMaxPerEmail int32 `env:"MAGIC_LINK_MAX_PER_EMAIL" env-default:"5"`
+
+	// PasswordHashMemory - argon2id memory in KiB, changing any hash param rehashes passwords on the next login
+	PasswordHashMemory uint32 `env:"PASSWORD_HASH_MEMORY" env-default:"65536"`
+	// PasswordHashIterations - argon2id passes over the memory
+	PasswordHashIterations uint32 `env:"PASSWORD_HASH_ITERATIONS" env-default:"1"`
+	// PasswordHashParallelism - argon2id threads
+	PasswordHashParallelism uint8  `env:"PASSWORD_HASH_PARALLELISM" env-default:"2"`
+	PasswordHashSaltLength  uint32 `env:"PASSWORD_HASH_SALT_LENGTH" env-default:"16"`
+	PasswordHashKeyLength   uint32 `env:"PASSWORD_HASH_KEY_LENGTH" env-default:"32"`
+
+	// PasswordMinLength - shortest accepted password in characters, 0 disables the rule
+	PasswordMinLength int `env:"PASSWORD_MIN_LENGTH" env-default:"8"`
+	// PasswordMaxLength - longest accepted password in characters, 0 disables the rule
+	PasswordMaxLength int `env:"PASSWORD_MAX_LENGTH" env-default:"128"`
+	// PasswordMinCharClasses - how many of lowercase, uppercase, digits and symbols are required
+	PasswordMinCharClasses int `env:"PASSWORD_MIN_CHAR_CLASSES" env-default:"3"`
+	// PasswordMinEntropy - minimal estimated strength in bits, 0 disables the rule
+	PasswordMinEntropy float64 `env:"PASSWORD_MIN_ENTROPY" env-default:"50"`
+	// PasswordBannedWords - words the password must not contain, the email local part is always banned
+	PasswordBannedWords []string `env:"PASSWORD_BANNED_WORDS" env-separator:","`
+	// PasswordBreachedList - file with SHA-1 hashes of leaked passwords, one "HASH[:COUNT]" per line
+	PasswordBreachedList string `env:"PASSWORD_BREACHED_LIST"`
+
+	// MailSender - how emails are delivered: "smtp" or "log" (only written to the log, for development)
+	MailSender   string `env:"MAIL_SENDER" env-default:"smtp"`
+	MailFrom     string `env:"MAIL_FROM" env-default:"webTemplate <noreply@localhost>"`
+	SmtpHost     string `env:"SMTP_HOST" env-default:"localhost"`
+	SmtpPort     string `env:"SMTP_PORT" env-default:"25"`
+	SmtpUsername string `env:"SMTP_USERNAME"`
+	SmtpPassword string `env:"SMTP_PASSWORD"`
+
+	// BootstrapAdminEmail - registered user with a verified email who gets the admin role on start while there is no admin yet
+	BootstrapAdminEmail string `env:"BOOTSTRAP_ADMIN_EMAIL"`
+}
+
+// OIDCProvider - OpenID Connect provider registered as a confidential client
+type OIDCProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
 }
 
 func NewConfig() (*Config, error) {
@@ -27,9 +176,68 @@ func NewConfig() (*Config, error) {
 	}
 
 	cfg.DbUrl = fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", cfg.DbUser, cfg.DbPassword, cfg.DbHost, cfg.DbPort, cfg.DbName)
-	if cfg.JwtSecret == "" {
-		return nil, errors.New("JWT_SECRET is REQUIRED not to be null")
+	if cfg.JwtSecret == "" && cfg.JwtPrivateKeyPath == "" {
+		return nil, errors.New("JWT_SECRET or JWT_PRIVATE_KEY_PATH is REQUIRED not to be null")
+	}
+
+	providers, err := readOIDCProviders(cfg.OIDCProviderNames)
+	if err != nil {
+		return nil, err
+	}
+	cfg.OIDCProviders = providers
+
+	authProviders := make([]string, 0, len(cfg.AuthProviders))
+	for _, name := range cfg.AuthProviders {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			authProviders = append(authProviders, name)
+		}
+	}
+	cfg.AuthProviders = authProviders
+
+	switch cfg.CookieSameSite = strings.ToLower(cfg.CookieSameSite); cfg.CookieSameSite {
+	case "strict", "lax":
+	case "none":
+		// browsers drop SameSite=None cookies without Secure
+		if !cfg.CookieSecure {
+			return nil, errors.New("COOKIE_SAME_SITE=none requires COOKIE_SECURE")
+		}
+	default:
+		return nil, fmt.Errorf("unknown COOKIE_SAME_SITE %q, expected strict, lax or none", cfg.CookieSameSite)
+	}
+
+	if slices.Contains(cfg.AuthProviders, "ldap") && (cfg.LDAPURL == "" || cfg.LDAPBaseDN == "") {
+		return nil, errors.New("LDAP_URL and LDAP_BASE_DN are required for the ldap auth provider")
 	}
 
 	return &cfg, nil
 }
+
+// readOIDCProviders - прочитать настройки каждого провайдера из переменных OIDC_<NAME>_*
+func readOIDCProviders(names []string) ([]OIDCProvider, error) {
+	providers := make([]OIDCProvider, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		provider := OIDCProvider{
+			Name:         name,
+			Issuer:       strings.TrimSuffix(os.Getenv(prefix+"ISSUER"), "/"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			Scopes:       []string{"openid", "email", "profile"},
+		}
+		if scopes := strings.Fields(strings.ReplaceAll(os.Getenv(prefix+"SCOPES"), ",", " ")); len(scopes) > 0 {
+			provider.Scopes = scopes
+		}
+
+		if provider.Issuer == "" || provider.ClientID == "" {
+			return nil, fmt.Errorf("%sISSUER and %sCLIENT_ID are required for OIDC provider %q", prefix, prefix, name)
+		}
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
@@ -0,0 +1,105 @@
+package infra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigOIDCProviders(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	t.Setenv("OIDC_PROVIDERS", "Google, corp-sso")
+	t.Setenv("OIDC_GOOGLE_ISSUER", "https://accounts.google.com/")
+	t.Setenv("OIDC_GOOGLE_CLIENT_ID", "google-client")
+	t.Setenv("OIDC_GOOGLE_CLIENT_SECRET", "google-secret")
+	t.Setenv("OIDC_CORP_SSO_ISSUER", "https://sso.example.com/realms/corp")
+	t.Setenv("OIDC_CORP_SSO_CLIENT_ID", "corp-client")
+	t.Setenv("OIDC_CORP_SSO_SCOPES", "openid,email groups")
+
+	cfg, err := NewConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, []OIDCProvider{
+		{
+			Name:         "google",
+			Issuer:       "https://accounts.google.com",
+			ClientID:     "google-client",
+			ClientSecret: "google-secret",
+			Scopes:       []string{"openid", "email", "profile"},
+		},
+		{
+			Name:     "corp-sso",
+			Issuer:   "https://sso.example.com/realms/corp",
+			ClientID: "corp-client",
+			Scopes:   []string{"openid", "email", "groups"},
+		},
+	}, cfg.OIDCProviders)
+}
+
+func TestNewConfigOIDCProviderIncomplete(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	t.Setenv("OIDC_PROVIDERS", "google")
+	t.Setenv("OIDC_GOOGLE_ISSUER", "https://accounts.google.com")
+
+	_, err := NewConfig()
+	assert.ErrorContains(t, err, "OIDC_GOOGLE_CLIENT_ID")
+}
+
+func TestNewConfigAuthProviders(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	t.Setenv("AUTH_PROVIDERS", " LDAP,local,")
+	t.Setenv("LDAP_URL", "ldap://localhost:389")
+	t.Setenv("LDAP_BASE_DN", "ou=people,dc=example,dc=org")
+
+	cfg, err := NewConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ldap", "local"}, cfg.AuthProviders)
+	assert.Equal(t, "(mail=%s)", cfg.LDAPUserFilter)
+}
+
+func TestNewConfigLDAPIncomplete(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	t.Setenv("AUTH_PROVIDERS", "local,ldap")
+
+	_, err := NewConfig()
+	assert.ErrorContains(t, err, "LDAP_URL")
+}
+
+func TestNewConfigCookieSameSite(t *testing.T) {
+	tests := []struct {
+		name     string
+		sameSite string
+		secure   string
+		err      string
+	}{
+		{name: "default", secure: "true"},
+		{name: "case insensitive", sameSite: "Lax", secure: "true"},
+		{name: "none over https", sameSite: "none", secure: "true"},
+		{name: "none without secure", sameSite: "none", secure: "false", err: "COOKIE_SECURE"},
+		{name: "unknown", sameSite: "relaxed", secure: "true", err: "COOKIE_SAME_SITE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_SECRET", "test-secret")
+			t.Setenv("COOKIE_SECURE", tt.secure)
+			if tt.sameSite != "" {
+				t.Setenv("COOKIE_SAME_SITE", tt.sameSite)
+			}
+
+			_, err := NewConfig()
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCORSConfig(t *testing.T) {
+	// browsers reject credentials with a wildcard origin
+	assert.False(t, corsConfig([]string{"*"}).AllowCredentials)
+	assert.True(t, corsConfig([]string{"https://app.example.com"}).AllowCredentials)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"slices"
 
 	"github.com/MarceloPetrucio/go-scalar-api-reference"
 	"github.com/bytedance/sonic"
@@ -37,6 +38,17 @@ func (s *sonicJSONSerializer) Deserialize(c echo.Context, i interface{}) error {
 	return sonic.ConfigStd.NewDecoder(c.Request().Body).Decode(i)
 }
 
+// corsConfig - cookie сессии требуют credentials, а браузер не принимает их вместе с "*",
+// поэтому credentials разрешены только для явно перечисленных origin
+func corsConfig(origins []string) middleware.CORSConfig {
+	return middleware.CORSConfig{
+		AllowOrigins:     origins,
+		AllowCredentials: !slices.Contains(origins, "*"),
+		// lockout responses tell the frontend when to retry
+		ExposeHeaders: []string{"Retry-After"},
+	}
+}
+
 func NewEcho(lc fx.Lifecycle, cfg *Config, logger *Logger, loggerWare echo.MiddlewareFunc) (*echo.Echo, error) {
 	swaggerContent, err := getSpec()
 	if err != nil {
@@ -51,15 +63,18 @@ func NewEcho(lc fx.Lifecycle, cfg *Config, logger *Logger, loggerWare echo.Middl
 
 	router.JSONSerializer = &sonicJSONSerializer{}
 
+	// client IP is used for login throttling, so headers are trusted only when configured
+	if cfg.TrustProxyHeaders {
+		router.IPExtractor = echo.ExtractIPFromXFFHeader()
+	} else {
+		router.IPExtractor = echo.ExtractIPDirect()
+	}
+
 	router.HideBanner = true
 
 	router.HidePort = true
 
-	router.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{
-			"*",
-		},
-	}))
+	router.Use(middleware.CORSWithConfig(corsConfig(cfg.CORSOrigins)))
 
 	router.Use(loggerWare)
 
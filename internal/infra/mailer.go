@@ -0,0 +1,109 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"go.uber.org/zap"
+)
+
+// Mail - письмо в виде простого текста
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer - отправка писем, реализация выбирается через MAIL_SENDER
+type Mailer interface {
+	Send(ctx context.Context, message Mail) error
+}
+
+func NewMailer(cfg *Config, logger *Logger) (Mailer, error) {
+	switch cfg.MailSender {
+	case "smtp":
+		from, err := mail.ParseAddress(cfg.MailFrom)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MAIL_FROM: %w", err)
+		}
+
+		return &SMTPMailer{
+			addr:     net.JoinHostPort(cfg.SmtpHost, cfg.SmtpPort),
+			host:     cfg.SmtpHost,
+			from:     from,
+			username: cfg.SmtpUsername,
+			password: cfg.SmtpPassword,
+		}, nil
+	case "log":
+		return &LogMailer{logger: logger}, nil
+	default:
+		return nil, fmt.Errorf("unknown MAIL_SENDER %q", cfg.MailSender)
+	}
+}
+
+// SMTPMailer - отправка через SMTP сервер, STARTTLS используется если сервер его поддерживает
+type SMTPMailer struct {
+	addr     string
+	host     string
+	from     *mail.Address
+	username string
+	password string
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, message Mail) error {
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	// net/smtp has no context support, so the deadline is checked only before sending
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	return smtp.SendMail(m.addr, auth, m.from.Address, []string{to.Address}, m.compose(to, message))
+}
+
+func (m *SMTPMailer) compose(to *mail.Address, message Mail) []byte {
+	var buf bytes.Buffer
+	headers := []struct{ key, value string }{
+		{"From", m.from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", message.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", ulid.Make().String(), m.host)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "8bit"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", header.key, header.value)
+	}
+
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return buf.Bytes()
+}
+
+// LogMailer - письма только пишутся в лог, для локальной разработки без SMTP
+type LogMailer struct {
+	logger *Logger
+}
+
+func (m *LogMailer) Send(_ context.Context, message Mail) error {
+	m.logger.Infow("mail", zap.String("to", message.To), zap.String("subject", message.Subject), zap.String("body", message.Body))
+	return nil
+}
@@ -0,0 +1,129 @@
+package infra
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpStandIn - минимальный SMTP сервер, принимает одно письмо и отдаёт его в канал
+type smtpStandIn struct {
+	listener net.Listener
+	received chan receivedMail
+}
+
+type receivedMail struct {
+	from string
+	to   []string
+	data string
+}
+
+func newSMTPStandIn(t *testing.T) *smtpStandIn {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	server := &smtpStandIn{listener: listener, received: make(chan receivedMail, 1)}
+	go server.serve()
+	return server
+}
+
+func (s *smtpStandIn) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	var mail receivedMail
+	reply("220 localhost ESMTP stand-in")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimSpace(line)
+
+		switch upper := strings.ToUpper(command); {
+		case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(upper, "MAIL FROM:"):
+			mail.from = strings.Trim(command[len("MAIL FROM:"):], "<> ")
+			reply("250 OK")
+		case strings.HasPrefix(upper, "RCPT TO:"):
+			mail.to = append(mail.to, strings.Trim(command[len("RCPT TO:"):], "<> "))
+			reply("250 OK")
+		case upper == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			mail.data = data.String()
+			s.received <- mail
+			reply("250 OK")
+		case upper == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPMailer(t *testing.T) {
+	server := newSMTPStandIn(t)
+	host, port, err := net.SplitHostPort(server.listener.Addr().String())
+	require.NoError(t, err)
+
+	mailer, err := NewMailer(&Config{
+		MailSender: "smtp",
+		MailFrom:   "webTemplate <noreply@example.com>",
+		SmtpHost:   host,
+		SmtpPort:   port,
+	}, nil)
+	require.NoError(t, err)
+
+	err = mailer.Send(context.Background(), Mail{
+		To:      "user@example.com",
+		Subject: "Подтверждение почты",
+		Body:    "first line\nsecond line",
+	})
+	require.NoError(t, err)
+
+	mail := <-server.received
+	assert.Equal(t, "noreply@example.com", mail.from)
+	assert.Equal(t, []string{"user@example.com"}, mail.to)
+	assert.Contains(t, mail.data, "From: \"webTemplate\" <noreply@example.com>\r\n")
+	assert.Contains(t, mail.data, "To: <user@example.com>\r\n")
+	assert.Contains(t, mail.data, "Subject: =?utf-8?q?")
+	assert.Contains(t, mail.data, "\r\n\r\nfirst line\r\nsecond line")
+}
+
+func TestNewMailer(t *testing.T) {
+	_, err := NewMailer(&Config{MailSender: "pigeon"}, nil)
+	assert.Error(t, err)
+
+	_, err = NewMailer(&Config{MailSender: "smtp", MailFrom: "not an address"}, nil)
+	assert.Error(t, err)
+
+	mailer, err := NewMailer(&Config{MailSender: "log"}, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &LogMailer{}, mailer)
+}
@@ -4,8 +4,224 @@
 
 package queries
 
-type User struct {
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ApiKey struct {
+	ID         string
+	UserID     string
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  pgtype.Timestamptz
+	CreatedAt  pgtype.Timestamptz
+	LastUsedAt pgtype.Timestamptz
+	RevokedAt  pgtype.Timestamptz
+}
+
+type DataExport struct {
+	ID          string
+	UserID      string
+	Status      string
+	Archive     []byte
+	Error       string
+	CreatedAt   pgtype.Timestamptz
+	StartedAt   pgtype.Timestamptz
+	CompletedAt pgtype.Timestamptz
+	ExpiresAt   pgtype.Timestamptz
+}
+
+type EmailChange struct {
+	UserID    string
+	NewEmail  string
+	ExpiresAt pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type ImpersonationEvent struct {
+	ID        string
+	ActorID   string
+	UserID    string
+	Action    string
+	Status    pgtype.Int4
+	Reason    pgtype.Text
+	Ip        string
+	CreatedAt pgtype.Timestamptz
+}
+
+type LoginAttempt struct {
+	Subject       string
+	Failures      int32
+	LastFailureAt pgtype.Timestamptz
+	LockedUntil   pgtype.Timestamptz
+}
+
+type MailSend struct {
+	Subject         string
+	Sent            int32
+	WindowStartedAt pgtype.Timestamptz
+}
+
+type OauthAuthorizationCode struct {
+	ID            string
+	ClientID      string
+	UserID        string
+	RedirectUri   string
+	Scopes        []string
+	CodeChallenge string
+	ExpiresAt     pgtype.Timestamptz
+}
+
+type OauthClient struct {
+	ID           string
+	Name         string
+	SecretHash   pgtype.Text
+	RedirectUris []string
+	GrantTypes   []string
+	Scopes       []string
+	FirstParty   bool
+	CreatedAt    pgtype.Timestamptz
+}
+
+type OauthConsent struct {
+	UserID    string
+	ClientID  string
+	Scopes    []string
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+}
+
+type OidcState struct {
 	ID           string
-	Email        string
-	PasswordHash string
+	Provider     string
+	UserID       pgtype.Text
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    pgtype.Timestamptz
+}
+
+type PasswordResetToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiresAt pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+	UsedAt    pgtype.Timestamptz
+}
+
+type Permission struct {
+	Name        string
+	Description string
+}
+
+type RecoveryCode struct {
+	ID       string
+	UserID   string
+	CodeHash string
+	UsedAt   pgtype.Timestamptz
+}
+
+type RefreshToken struct {
+	ID         string
+	UserID     string
+	FamilyID   string
+	TokenHash  string
+	ExpiresAt  pgtype.Timestamptz
+	CreatedAt  pgtype.Timestamptz
+	RevokedAt  pgtype.Timestamptz
+	ReplacedBy pgtype.Text
+	ClientID   pgtype.Text
+	Scopes     []string
+}
+
+type RevokedToken struct {
+	Jti       string
+	UserID    string
+	ExpiresAt pgtype.Timestamptz
+}
+
+type Role struct {
+	Name        string
+	Description string
+}
+
+type RolePermission struct {
+	Role       string
+	Permission string
+}
+
+type Session struct {
+	ID           string
+	UserID       string
+	ClientID     pgtype.Text
+	UserAgent    string
+	Ip           string
+	CreatedAt    pgtype.Timestamptz
+	LastActiveAt pgtype.Timestamptz
+	RevokedAt    pgtype.Timestamptz
+}
+
+type User struct {
+	ID              string
+	Email           string
+	PasswordHash    string
+	EmailVerifiedAt pgtype.Timestamptz
+	DisplayName     string
+	Locale          string
+	Timezone        string
+	DeletedAt       pgtype.Timestamptz
+}
+
+type UserIdentity struct {
+	Provider    string
+	Subject     string
+	UserID      string
+	Email       string
+	CreatedAt   pgtype.Timestamptz
+	LastLoginAt pgtype.Timestamptz
+}
+
+type UserRole struct {
+	UserID    string
+	Role      string
+	CreatedAt pgtype.Timestamptz
+}
+
+type UserTokenRevocation struct {
+	UserID    string
+	RevokedAt pgtype.Timestamptz
+	ExpiresAt pgtype.Timestamptz
+}
+
+type UserTotp struct {
+	UserID       string
+	Secret       string
+	LastUsedStep int64
+	CreatedAt    pgtype.Timestamptz
+	ConfirmedAt  pgtype.Timestamptz
+}
+
+type WebauthnCredential struct {
+	ID              string
+	UserID          string
+	Name            string
+	CredentialID    []byte
+	PublicKey       []byte
+	AttestationType string
+	Aaguid          []byte
+	Transports      []string
+	SignCount       int64
+	BackupEligible  bool
+	BackupState     bool
+	CreatedAt       pgtype.Timestamptz
+	LastUsedAt      pgtype.Timestamptz
+}
+
+type WebauthnSession struct {
+	ID        string
+	UserID    pgtype.Text
+	Data      []byte
+	ExpiresAt pgtype.Timestamptz
 }
@@ -7,7 +7,399 @@ package queries
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const assignUserRole = `-- name: AssignUserRole :exec
+INSERT INTO user_roles (user_id, role) VALUES ($1, $2) ON CONFLICT DO NOTHING
+`
+
+type AssignUserRoleParams struct {
+	UserID string
+	Role   string
+}
+
+func (q *Queries) AssignUserRole(ctx context.Context, arg AssignUserRoleParams) error {
+	_, err := q.db.Exec(ctx, assignUserRole, arg.UserID, arg.Role)
+	return err
+}
+
+const claimDataExport = `-- name: ClaimDataExport :one
+UPDATE data_exports SET status = 'running', started_at = now()
+WHERE id = (
+    SELECT id FROM data_exports
+    WHERE status = 'pending' OR (status = 'running' AND started_at < $1::timestamptz)
+    ORDER BY created_at LIMIT 1 FOR UPDATE SKIP LOCKED
 )
+RETURNING id, user_id
+`
+
+type ClaimDataExportRow struct {
+	ID     string
+	UserID string
+}
+
+func (q *Queries) ClaimDataExport(ctx context.Context, staleBefore pgtype.Timestamptz) (ClaimDataExportRow, error) {
+	row := q.db.QueryRow(ctx, claimDataExport, staleBefore)
+	var i ClaimDataExportRow
+	err := row.Scan(&i.ID, &i.UserID)
+	return i, err
+}
+
+const completeDataExport = `-- name: CompleteDataExport :exec
+UPDATE data_exports SET status = 'ready', archive = $2, completed_at = now(), expires_at = $3 WHERE id = $1
+`
+
+type CompleteDataExportParams struct {
+	ID        string
+	Archive   []byte
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) CompleteDataExport(ctx context.Context, arg CompleteDataExportParams) error {
+	_, err := q.db.Exec(ctx, completeDataExport, arg.ID, arg.Archive, arg.ExpiresAt)
+	return err
+}
+
+const confirmUserTOTP = `-- name: ConfirmUserTOTP :execrows
+UPDATE user_totp SET confirmed_at = now(), last_used_step = $2 WHERE user_id = $1 AND confirmed_at IS NULL
+`
+
+type ConfirmUserTOTPParams struct {
+	UserID       string
+	LastUsedStep int64
+}
+
+func (q *Queries) ConfirmUserTOTP(ctx context.Context, arg ConfirmUserTOTPParams) (int64, error) {
+	result, err := q.db.Exec(ctx, confirmUserTOTP, arg.UserID, arg.LastUsedStep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const consumeEmailChange = `-- name: ConsumeEmailChange :one
+DELETE FROM email_changes WHERE user_id = $1 AND LOWER(new_email) = $2 AND expires_at > now() RETURNING user_id, new_email, expires_at, created_at
+`
+
+type ConsumeEmailChangeParams struct {
+	UserID   string
+	NewEmail string
+}
+
+func (q *Queries) ConsumeEmailChange(ctx context.Context, arg ConsumeEmailChangeParams) (EmailChange, error) {
+	row := q.db.QueryRow(ctx, consumeEmailChange, arg.UserID, arg.NewEmail)
+	var i EmailChange
+	err := row.Scan(
+		&i.UserID,
+		&i.NewEmail,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const consumeOauthAuthorizationCode = `-- name: ConsumeOauthAuthorizationCode :one
+DELETE FROM oauth_authorization_codes WHERE id = $1 AND expires_at > now() RETURNING id, client_id, user_id, redirect_uri, scopes, code_challenge, expires_at
+`
+
+func (q *Queries) ConsumeOauthAuthorizationCode(ctx context.Context, id string) (OauthAuthorizationCode, error) {
+	row := q.db.QueryRow(ctx, consumeOauthAuthorizationCode, id)
+	var i OauthAuthorizationCode
+	err := row.Scan(
+		&i.ID,
+		&i.ClientID,
+		&i.UserID,
+		&i.RedirectUri,
+		&i.Scopes,
+		&i.CodeChallenge,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const consumeOidcState = `-- name: ConsumeOidcState :one
+DELETE FROM oidc_states WHERE id = $1 AND expires_at > now() RETURNING id, provider, user_id, nonce, code_verifier, expires_at
+`
+
+func (q *Queries) ConsumeOidcState(ctx context.Context, id string) (OidcState, error) {
+	row := q.db.QueryRow(ctx, consumeOidcState, id)
+	var i OidcState
+	err := row.Scan(
+		&i.ID,
+		&i.Provider,
+		&i.UserID,
+		&i.Nonce,
+		&i.CodeVerifier,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const consumeWebAuthnSession = `-- name: ConsumeWebAuthnSession :one
+DELETE FROM webauthn_sessions WHERE id = $1 AND expires_at > now() RETURNING id, user_id, data, expires_at
+`
+
+func (q *Queries) ConsumeWebAuthnSession(ctx context.Context, id string) (WebauthnSession, error) {
+	row := q.db.QueryRow(ctx, consumeWebAuthnSession, id)
+	var i WebauthnSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Data,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const countUsersWithRole = `-- name: CountUsersWithRole :one
+SELECT COUNT(*) FROM user_roles WHERE role = $1 AND user_id IN (SELECT id FROM users WHERE deleted_at IS NULL)
+`
+
+func (q *Queries) CountUsersWithRole(ctx context.Context, role string) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsersWithRole, role)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createApiKey = `-- name: CreateApiKey :exec
+INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateApiKeyParams struct {
+	ID        string
+	UserID    string
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []string
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) CreateApiKey(ctx context.Context, arg CreateApiKeyParams) error {
+	_, err := q.db.Exec(ctx, createApiKey,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.Prefix,
+		arg.KeyHash,
+		arg.Scopes,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const createDataExport = `-- name: CreateDataExport :execrows
+INSERT INTO data_exports (id, user_id) VALUES ($1, $2)
+ON CONFLICT (user_id) WHERE status IN ('pending', 'running') DO NOTHING
+`
+
+type CreateDataExportParams struct {
+	ID     string
+	UserID string
+}
+
+func (q *Queries) CreateDataExport(ctx context.Context, arg CreateDataExportParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createDataExport, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createImpersonationEvent = `-- name: CreateImpersonationEvent :exec
+INSERT INTO impersonation_events (id, actor_id, user_id, action, status, reason, ip) VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateImpersonationEventParams struct {
+	ID      string
+	ActorID string
+	UserID  string
+	Action  string
+	Status  pgtype.Int4
+	Reason  pgtype.Text
+	Ip      string
+}
+
+func (q *Queries) CreateImpersonationEvent(ctx context.Context, arg CreateImpersonationEventParams) error {
+	_, err := q.db.Exec(ctx, createImpersonationEvent,
+		arg.ID,
+		arg.ActorID,
+		arg.UserID,
+		arg.Action,
+		arg.Status,
+		arg.Reason,
+		arg.Ip,
+	)
+	return err
+}
+
+const createOauthAuthorizationCode = `-- name: CreateOauthAuthorizationCode :exec
+INSERT INTO oauth_authorization_codes (id, client_id, user_id, redirect_uri, scopes, code_challenge, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateOauthAuthorizationCodeParams struct {
+	ID            string
+	ClientID      string
+	UserID        string
+	RedirectUri   string
+	Scopes        []string
+	CodeChallenge string
+	ExpiresAt     pgtype.Timestamptz
+}
+
+func (q *Queries) CreateOauthAuthorizationCode(ctx context.Context, arg CreateOauthAuthorizationCodeParams) error {
+	_, err := q.db.Exec(ctx, createOauthAuthorizationCode,
+		arg.ID,
+		arg.ClientID,
+		arg.UserID,
+		arg.RedirectUri,
+		arg.Scopes,
+		arg.CodeChallenge,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const createOauthClient = `-- name: CreateOauthClient :exec
+INSERT INTO oauth_clients (id, name, secret_hash, redirect_uris, grant_types, scopes, first_party) VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateOauthClientParams struct {
+	ID           string
+	Name         string
+	SecretHash   pgtype.Text
+	RedirectUris []string
+	GrantTypes   []string
+	Scopes       []string
+	FirstParty   bool
+}
+
+func (q *Queries) CreateOauthClient(ctx context.Context, arg CreateOauthClientParams) error {
+	_, err := q.db.Exec(ctx, createOauthClient,
+		arg.ID,
+		arg.Name,
+		arg.SecretHash,
+		arg.RedirectUris,
+		arg.GrantTypes,
+		arg.Scopes,
+		arg.FirstParty,
+	)
+	return err
+}
+
+const createOidcState = `-- name: CreateOidcState :exec
+INSERT INTO oidc_states (id, provider, user_id, nonce, code_verifier, expires_at) VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateOidcStateParams struct {
+	ID           string
+	Provider     string
+	UserID       pgtype.Text
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    pgtype.Timestamptz
+}
+
+func (q *Queries) CreateOidcState(ctx context.Context, arg CreateOidcStateParams) error {
+	_, err := q.db.Exec(ctx, createOidcState,
+		arg.ID,
+		arg.Provider,
+		arg.UserID,
+		arg.Nonce,
+		arg.CodeVerifier,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const createPasswordResetToken = `-- name: CreatePasswordResetToken :exec
+INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at) VALUES ($1, $2, $3, $4)
+`
+
+type CreatePasswordResetTokenParams struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error {
+	_, err := q.db.Exec(ctx, createPasswordResetToken,
+		arg.ID,
+		arg.UserID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const createRecoveryCode = `-- name: CreateRecoveryCode :exec
+INSERT INTO recovery_codes (id, user_id, code_hash) VALUES ($1, $2, $3)
+`
+
+type CreateRecoveryCodeParams struct {
+	ID       string
+	UserID   string
+	CodeHash string
+}
+
+func (q *Queries) CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error {
+	_, err := q.db.Exec(ctx, createRecoveryCode, arg.ID, arg.UserID, arg.CodeHash)
+	return err
+}
+
+const createRefreshToken = `-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, client_id, scopes) VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type CreateRefreshTokenParams struct {
+	ID        string
+	UserID    string
+	FamilyID  string
+	TokenHash string
+	ExpiresAt pgtype.Timestamptz
+	ClientID  pgtype.Text
+	Scopes    []string
+}
+
+func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error {
+	_, err := q.db.Exec(ctx, createRefreshToken,
+		arg.ID,
+		arg.UserID,
+		arg.FamilyID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+		arg.ClientID,
+		arg.Scopes,
+	)
+	return err
+}
+
+const createSession = `-- name: CreateSession :exec
+INSERT INTO sessions (id, user_id, client_id, user_agent, ip) VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateSessionParams struct {
+	ID        string
+	UserID    string
+	ClientID  pgtype.Text
+	UserAgent string
+	Ip        string
+}
+
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) error {
+	_, err := q.db.Exec(ctx, createSession,
+		arg.ID,
+		arg.UserID,
+		arg.ClientID,
+		arg.UserAgent,
+		arg.Ip,
+	)
+	return err
+}
 
 const createUser = `-- name: CreateUser :exec
 INSERT INTO users (id, email, password_hash) VALUES ($1, $2, $3)
@@ -24,24 +416,1587 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
 	return err
 }
 
-const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password_hash FROM users WHERE LOWER(email) = $1 LIMIT 1
+const createUserIdentity = `-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (provider, subject, user_id, email) VALUES ($1, $2, $3, $4)
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByEmail, email)
-	var i User
-	err := row.Scan(&i.ID, &i.Email, &i.PasswordHash)
-	return i, err
+type CreateUserIdentityParams struct {
+	Provider string
+	Subject  string
+	UserID   string
+	Email    string
 }
 
-const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, password_hash FROM users WHERE id = $1 LIMIT 1
+func (q *Queries) CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) error {
+	_, err := q.db.Exec(ctx, createUserIdentity,
+		arg.Provider,
+		arg.Subject,
+		arg.UserID,
+		arg.Email,
+	)
+	return err
+}
+
+const createWebAuthnCredential = `-- name: CreateWebAuthnCredential :exec
+INSERT INTO webauthn_credentials (id, user_id, name, credential_id, public_key, attestation_type, aaguid, transports, sign_count, backup_eligible, backup_state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 `
 
-func (q *Queries) GetUserByID(ctx context.Context, id string) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByID, id)
-	var i User
-	err := row.Scan(&i.ID, &i.Email, &i.PasswordHash)
-	return i, err
+type CreateWebAuthnCredentialParams struct {
+	ID              string
+	UserID          string
+	Name            string
+	CredentialID    []byte
+	PublicKey       []byte
+	AttestationType string
+	Aaguid          []byte
+	Transports      []string
+	SignCount       int64
+	BackupEligible  bool
+	BackupState     bool
+}
+
+func (q *Queries) CreateWebAuthnCredential(ctx context.Context, arg CreateWebAuthnCredentialParams) error {
+	_, err := q.db.Exec(ctx, createWebAuthnCredential,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.CredentialID,
+		arg.PublicKey,
+		arg.AttestationType,
+		arg.Aaguid,
+		arg.Transports,
+		arg.SignCount,
+		arg.BackupEligible,
+		arg.BackupState,
+	)
+	return err
+}
+
+const createWebAuthnSession = `-- name: CreateWebAuthnSession :exec
+INSERT INTO webauthn_sessions (id, user_id, data, expires_at) VALUES ($1, $2, $3, $4)
+`
+
+type CreateWebAuthnSessionParams struct {
+	ID        string
+	UserID    pgtype.Text
+	Data      []byte
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) CreateWebAuthnSession(ctx context.Context, arg CreateWebAuthnSessionParams) error {
+	_, err := q.db.Exec(ctx, createWebAuthnSession,
+		arg.ID,
+		arg.UserID,
+		arg.Data,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const deleteEmailChange = `-- name: DeleteEmailChange :exec
+DELETE FROM email_changes WHERE user_id = $1
+`
+
+func (q *Queries) DeleteEmailChange(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, deleteEmailChange, userID)
+	return err
+}
+
+const deleteExpiredApiKeys = `-- name: DeleteExpiredApiKeys :execrows
+DELETE FROM api_keys WHERE expires_at < now() OR revoked_at IS NOT NULL
+`
+
+func (q *Queries) DeleteExpiredApiKeys(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredApiKeys)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredDataExports = `-- name: DeleteExpiredDataExports :execrows
+DELETE FROM data_exports WHERE expires_at <= now()
+`
+
+func (q *Queries) DeleteExpiredDataExports(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredDataExports)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredEmailChanges = `-- name: DeleteExpiredEmailChanges :execrows
+DELETE FROM email_changes WHERE expires_at < now()
+`
+
+func (q *Queries) DeleteExpiredEmailChanges(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredEmailChanges)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredOauthAuthorizationCodes = `-- name: DeleteExpiredOauthAuthorizationCodes :execrows
+DELETE FROM oauth_authorization_codes WHERE expires_at <= now()
+`
+
+func (q *Queries) DeleteExpiredOauthAuthorizationCodes(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredOauthAuthorizationCodes)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredOidcStates = `-- name: DeleteExpiredOidcStates :execrows
+DELETE FROM oidc_states WHERE expires_at <= now()
+`
+
+func (q *Queries) DeleteExpiredOidcStates(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredOidcStates)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredPasswordResetTokens = `-- name: DeleteExpiredPasswordResetTokens :execrows
+DELETE FROM password_reset_tokens WHERE expires_at < now()
+`
+
+func (q *Queries) DeleteExpiredPasswordResetTokens(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredPasswordResetTokens)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredRefreshTokens = `-- name: DeleteExpiredRefreshTokens :execrows
+DELETE FROM refresh_tokens WHERE expires_at < now()
+`
+
+func (q *Queries) DeleteExpiredRefreshTokens(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredRefreshTokens)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredRevokedTokens = `-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_tokens WHERE expires_at < now()
+`
+
+func (q *Queries) DeleteExpiredRevokedTokens(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredRevokedTokens)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredUserTokenRevocations = `-- name: DeleteExpiredUserTokenRevocations :execrows
+DELETE FROM user_token_revocations WHERE expires_at < now()
+`
+
+func (q *Queries) DeleteExpiredUserTokenRevocations(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredUserTokenRevocations)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredWebAuthnSessions = `-- name: DeleteExpiredWebAuthnSessions :execrows
+DELETE FROM webauthn_sessions WHERE expires_at <= now()
+`
+
+func (q *Queries) DeleteExpiredWebAuthnSessions(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredWebAuthnSessions)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteLoginAttempts = `-- name: DeleteLoginAttempts :exec
+DELETE FROM login_attempts WHERE subject = $1
+`
+
+func (q *Queries) DeleteLoginAttempts(ctx context.Context, subject string) error {
+	_, err := q.db.Exec(ctx, deleteLoginAttempts, subject)
+	return err
+}
+
+const deleteOauthClient = `-- name: DeleteOauthClient :execrows
+DELETE FROM oauth_clients WHERE id = $1
+`
+
+func (q *Queries) DeleteOauthClient(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOauthClient, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteOauthConsent = `-- name: DeleteOauthConsent :execrows
+DELETE FROM oauth_consents WHERE user_id = $1 AND client_id = $2
+`
+
+type DeleteOauthConsentParams struct {
+	UserID   string
+	ClientID string
+}
+
+func (q *Queries) DeleteOauthConsent(ctx context.Context, arg DeleteOauthConsentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOauthConsent, arg.UserID, arg.ClientID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRecoveryCodes = `-- name: DeleteRecoveryCodes :exec
+DELETE FROM recovery_codes WHERE user_id = $1
+`
+
+func (q *Queries) DeleteRecoveryCodes(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, deleteRecoveryCodes, userID)
+	return err
+}
+
+const deleteStaleLoginAttempts = `-- name: DeleteStaleLoginAttempts :execrows
+DELETE FROM login_attempts WHERE last_failure_at < $1 AND (locked_until IS NULL OR locked_until < now())
+`
+
+func (q *Queries) DeleteStaleLoginAttempts(ctx context.Context, lastFailureAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStaleLoginAttempts, lastFailureAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteStaleMailSends = `-- name: DeleteStaleMailSends :execrows
+DELETE FROM mail_sends WHERE window_started_at < $1
+`
+
+func (q *Queries) DeleteStaleMailSends(ctx context.Context, windowStartedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStaleMailSends, windowStartedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteStaleSessions = `-- name: DeleteStaleSessions :execrows
+DELETE FROM sessions WHERE NOT EXISTS(SELECT 1 FROM refresh_tokens WHERE refresh_tokens.family_id = sessions.id)
+`
+
+func (q *Queries) DeleteStaleSessions(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStaleSessions)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteUserDataExports = `-- name: DeleteUserDataExports :exec
+DELETE FROM data_exports WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserDataExports(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, deleteUserDataExports, userID)
+	return err
+}
+
+const deleteUserIdentity = `-- name: DeleteUserIdentity :execrows
+DELETE FROM user_identities WHERE user_id = $1 AND provider = $2
+`
+
+type DeleteUserIdentityParams struct {
+	UserID   string
+	Provider string
+}
+
+func (q *Queries) DeleteUserIdentity(ctx context.Context, arg DeleteUserIdentityParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUserIdentity, arg.UserID, arg.Provider)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteUserTOTP = `-- name: DeleteUserTOTP :exec
+DELETE FROM user_totp WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserTOTP(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, deleteUserTOTP, userID)
+	return err
+}
+
+const deleteUsersRevokedTokens = `-- name: DeleteUsersRevokedTokens :exec
+DELETE FROM revoked_tokens WHERE user_id = ANY($1::text[])
+`
+
+func (q *Queries) DeleteUsersRevokedTokens(ctx context.Context, userIds []string) error {
+	_, err := q.db.Exec(ctx, deleteUsersRevokedTokens, userIds)
+	return err
+}
+
+const deleteUsersTokenRevocations = `-- name: DeleteUsersTokenRevocations :exec
+DELETE FROM user_token_revocations WHERE user_id = ANY($1::text[])
+`
+
+func (q *Queries) DeleteUsersTokenRevocations(ctx context.Context, userIds []string) error {
+	_, err := q.db.Exec(ctx, deleteUsersTokenRevocations, userIds)
+	return err
+}
+
+const deleteWebAuthnCredential = `-- name: DeleteWebAuthnCredential :execrows
+DELETE FROM webauthn_credentials WHERE id = $1 AND user_id = $2
+`
+
+type DeleteWebAuthnCredentialParams struct {
+	ID     string
+	UserID string
+}
+
+func (q *Queries) DeleteWebAuthnCredential(ctx context.Context, arg DeleteWebAuthnCredentialParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWebAuthnCredential, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const failDataExport = `-- name: FailDataExport :exec
+UPDATE data_exports SET status = 'failed', error = $2, completed_at = now(), expires_at = $3 WHERE id = $1
+`
+
+type FailDataExportParams struct {
+	ID        string
+	Error     string
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) FailDataExport(ctx context.Context, arg FailDataExportParams) error {
+	_, err := q.db.Exec(ctx, failDataExport, arg.ID, arg.Error, arg.ExpiresAt)
+	return err
+}
+
+const getApiKeyByHash = `-- name: GetApiKeyByHash :one
+SELECT id, user_id, name, prefix, key_hash, scopes, expires_at, created_at, last_used_at, revoked_at FROM api_keys WHERE key_hash = $1 AND user_id IN (SELECT id FROM users WHERE deleted_at IS NULL) LIMIT 1
+`
+
+func (q *Queries) GetApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, getApiKeyByHash, keyHash)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getDataExportArchive = `-- name: GetDataExportArchive :one
+SELECT archive FROM data_exports WHERE id = $1 AND user_id = $2 AND status = 'ready' AND expires_at > now()
+`
+
+type GetDataExportArchiveParams struct {
+	ID     string
+	UserID string
+}
+
+func (q *Queries) GetDataExportArchive(ctx context.Context, arg GetDataExportArchiveParams) ([]byte, error) {
+	row := q.db.QueryRow(ctx, getDataExportArchive, arg.ID, arg.UserID)
+	var archive []byte
+	err := row.Scan(&archive)
+	return archive, err
+}
+
+const getImpersonationEvents = `-- name: GetImpersonationEvents :many
+SELECT id, actor_id, user_id, action, status, reason, ip, created_at FROM impersonation_events WHERE user_id = $1 OR actor_id = $1 ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) GetImpersonationEvents(ctx context.Context, userID string) ([]ImpersonationEvent, error) {
+	rows, err := q.db.Query(ctx, getImpersonationEvents, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ImpersonationEvent
+	for rows.Next() {
+		var i ImpersonationEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.ActorID,
+			&i.UserID,
+			&i.Action,
+			&i.Status,
+			&i.Reason,
+			&i.Ip,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLockedLoginAttempts = `-- name: GetLockedLoginAttempts :many
+SELECT subject, failures, last_failure_at, locked_until FROM login_attempts WHERE subject = ANY($1::text[]) AND locked_until > now()
+`
+
+func (q *Queries) GetLockedLoginAttempts(ctx context.Context, subjects []string) ([]LoginAttempt, error) {
+	rows, err := q.db.Query(ctx, getLockedLoginAttempts, subjects)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LoginAttempt
+	for rows.Next() {
+		var i LoginAttempt
+		if err := rows.Scan(
+			&i.Subject,
+			&i.Failures,
+			&i.LastFailureAt,
+			&i.LockedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOauthClient = `-- name: GetOauthClient :one
+SELECT id, name, secret_hash, redirect_uris, grant_types, scopes, first_party, created_at FROM oauth_clients WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetOauthClient(ctx context.Context, id string) (OauthClient, error) {
+	row := q.db.QueryRow(ctx, getOauthClient, id)
+	var i OauthClient
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.SecretHash,
+		&i.RedirectUris,
+		&i.GrantTypes,
+		&i.Scopes,
+		&i.FirstParty,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getOauthClients = `-- name: GetOauthClients :many
+SELECT id, name, secret_hash, redirect_uris, grant_types, scopes, first_party, created_at FROM oauth_clients ORDER BY created_at
+`
+
+func (q *Queries) GetOauthClients(ctx context.Context) ([]OauthClient, error) {
+	rows, err := q.db.Query(ctx, getOauthClients)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OauthClient
+	for rows.Next() {
+		var i OauthClient
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.SecretHash,
+			&i.RedirectUris,
+			&i.GrantTypes,
+			&i.Scopes,
+			&i.FirstParty,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOauthConsent = `-- name: GetOauthConsent :one
+SELECT user_id, client_id, scopes, created_at, updated_at FROM oauth_consents WHERE user_id = $1 AND client_id = $2 LIMIT 1
+`
+
+type GetOauthConsentParams struct {
+	UserID   string
+	ClientID string
+}
+
+func (q *Queries) GetOauthConsent(ctx context.Context, arg GetOauthConsentParams) (OauthConsent, error) {
+	row := q.db.QueryRow(ctx, getOauthConsent, arg.UserID, arg.ClientID)
+	var i OauthConsent
+	err := row.Scan(
+		&i.UserID,
+		&i.ClientID,
+		&i.Scopes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPasswordResetTokenByHash = `-- name: GetPasswordResetTokenByHash :one
+SELECT id, user_id, token_hash, expires_at, created_at, used_at FROM password_reset_tokens WHERE token_hash = $1 LIMIT 1
+`
+
+func (q *Queries) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (PasswordResetToken, error) {
+	row := q.db.QueryRow(ctx, getPasswordResetTokenByHash, tokenHash)
+	var i PasswordResetToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UsedAt,
+	)
+	return i, err
+}
+
+const getRefreshTokenByHash = `-- name: GetRefreshTokenByHash :one
+SELECT id, user_id, family_id, token_hash, expires_at, created_at, revoked_at, replaced_by, client_id, scopes FROM refresh_tokens WHERE token_hash = $1 LIMIT 1
+`
+
+func (q *Queries) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error) {
+	row := q.db.QueryRow(ctx, getRefreshTokenByHash, tokenHash)
+	var i RefreshToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FamilyID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.RevokedAt,
+		&i.ReplacedBy,
+		&i.ClientID,
+		&i.Scopes,
+	)
+	return i, err
+}
+
+const getRolesPermissions = `-- name: GetRolesPermissions :many
+SELECT DISTINCT permission FROM role_permissions WHERE role = ANY($1::text[]) ORDER BY permission
+`
+
+func (q *Queries) GetRolesPermissions(ctx context.Context, roles []string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getRolesPermissions, roles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		items = append(items, permission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserApiKeys = `-- name: GetUserApiKeys :many
+SELECT id, user_id, name, prefix, key_hash, scopes, expires_at, created_at, last_used_at, revoked_at FROM api_keys WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at
+`
+
+func (q *Queries) GetUserApiKeys(ctx context.Context, userID string) ([]ApiKey, error) {
+	rows, err := q.db.Query(ctx, getUserApiKeys, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiKey
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Prefix,
+			&i.KeyHash,
+			&i.Scopes,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, email, password_hash, email_verified_at, display_name, locale, timezone, deleted_at FROM users WHERE LOWER(email) = $1 AND deleted_at IS NULL LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.EmailVerifiedAt,
+		&i.DisplayName,
+		&i.Locale,
+		&i.Timezone,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getUserByID = `-- name: GetUserByID :one
+SELECT id, email, password_hash, email_verified_at, display_name, locale, timezone, deleted_at FROM users WHERE id = $1 AND deleted_at IS NULL LIMIT 1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.EmailVerifiedAt,
+		&i.DisplayName,
+		&i.Locale,
+		&i.Timezone,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getUserDataExports = `-- name: GetUserDataExports :many
+SELECT id, user_id, status, error, created_at, started_at, completed_at, expires_at
+FROM data_exports WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2
+`
+
+type GetUserDataExportsParams struct {
+	UserID string
+	Limit  int32
+}
+
+type GetUserDataExportsRow struct {
+	ID          string
+	UserID      string
+	Status      string
+	Error       string
+	CreatedAt   pgtype.Timestamptz
+	StartedAt   pgtype.Timestamptz
+	CompletedAt pgtype.Timestamptz
+	ExpiresAt   pgtype.Timestamptz
+}
+
+func (q *Queries) GetUserDataExports(ctx context.Context, arg GetUserDataExportsParams) ([]GetUserDataExportsRow, error) {
+	rows, err := q.db.Query(ctx, getUserDataExports, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserDataExportsRow
+	for rows.Next() {
+		var i GetUserDataExportsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Status,
+			&i.Error,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserIdentities = `-- name: GetUserIdentities :many
+SELECT provider, subject, user_id, email, created_at, last_login_at FROM user_identities WHERE user_id = $1 ORDER BY created_at
+`
+
+func (q *Queries) GetUserIdentities(ctx context.Context, userID string) ([]UserIdentity, error) {
+	rows, err := q.db.Query(ctx, getUserIdentities, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserIdentity
+	for rows.Next() {
+		var i UserIdentity
+		if err := rows.Scan(
+			&i.Provider,
+			&i.Subject,
+			&i.UserID,
+			&i.Email,
+			&i.CreatedAt,
+			&i.LastLoginAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserIdentity = `-- name: GetUserIdentity :one
+SELECT provider, subject, user_id, email, created_at, last_login_at FROM user_identities WHERE provider = $1 AND subject = $2 LIMIT 1
+`
+
+type GetUserIdentityParams struct {
+	Provider string
+	Subject  string
+}
+
+func (q *Queries) GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error) {
+	row := q.db.QueryRow(ctx, getUserIdentity, arg.Provider, arg.Subject)
+	var i UserIdentity
+	err := row.Scan(
+		&i.Provider,
+		&i.Subject,
+		&i.UserID,
+		&i.Email,
+		&i.CreatedAt,
+		&i.LastLoginAt,
+	)
+	return i, err
+}
+
+const getUserOauthConsents = `-- name: GetUserOauthConsents :many
+SELECT oauth_consents.client_id, oauth_clients.name AS client_name, oauth_consents.scopes, oauth_consents.created_at, oauth_consents.updated_at
+FROM oauth_consents JOIN oauth_clients ON oauth_clients.id = oauth_consents.client_id
+WHERE oauth_consents.user_id = $1 ORDER BY oauth_consents.created_at
+`
+
+type GetUserOauthConsentsRow struct {
+	ClientID   string
+	ClientName string
+	Scopes     []string
+	CreatedAt  pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+}
+
+func (q *Queries) GetUserOauthConsents(ctx context.Context, userID string) ([]GetUserOauthConsentsRow, error) {
+	rows, err := q.db.Query(ctx, getUserOauthConsents, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserOauthConsentsRow
+	for rows.Next() {
+		var i GetUserOauthConsentsRow
+		if err := rows.Scan(
+			&i.ClientID,
+			&i.ClientName,
+			&i.Scopes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserRoles = `-- name: GetUserRoles :many
+SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role
+`
+
+func (q *Queries) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getUserRoles, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		items = append(items, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserSessions = `-- name: GetUserSessions :many
+SELECT id, user_id, client_id, user_agent, ip, created_at, last_active_at, revoked_at FROM sessions
+WHERE user_id = $1 AND revoked_at IS NULL
+  AND EXISTS(SELECT 1 FROM refresh_tokens WHERE refresh_tokens.family_id = sessions.id AND refresh_tokens.revoked_at IS NULL AND refresh_tokens.expires_at > now())
+ORDER BY last_active_at DESC
+`
+
+func (q *Queries) GetUserSessions(ctx context.Context, userID string) ([]Session, error) {
+	rows, err := q.db.Query(ctx, getUserSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ClientID,
+			&i.UserAgent,
+			&i.Ip,
+			&i.CreatedAt,
+			&i.LastActiveAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserTOTP = `-- name: GetUserTOTP :one
+SELECT user_id, secret, last_used_step, created_at, confirmed_at FROM user_totp WHERE user_id = $1
+`
+
+func (q *Queries) GetUserTOTP(ctx context.Context, userID string) (UserTotp, error) {
+	row := q.db.QueryRow(ctx, getUserTOTP, userID)
+	var i UserTotp
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+	)
+	return i, err
+}
+
+const getUserWebAuthnCredentials = `-- name: GetUserWebAuthnCredentials :many
+SELECT id, user_id, name, credential_id, public_key, attestation_type, aaguid, transports, sign_count, backup_eligible, backup_state, created_at, last_used_at FROM webauthn_credentials WHERE user_id = $1 ORDER BY created_at
+`
+
+func (q *Queries) GetUserWebAuthnCredentials(ctx context.Context, userID string) ([]WebauthnCredential, error) {
+	rows, err := q.db.Query(ctx, getUserWebAuthnCredentials, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebauthnCredential
+	for rows.Next() {
+		var i WebauthnCredential
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CredentialID,
+			&i.PublicKey,
+			&i.AttestationType,
+			&i.Aaguid,
+			&i.Transports,
+			&i.SignCount,
+			&i.BackupEligible,
+			&i.BackupState,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWebAuthnCredentialByCredentialID = `-- name: GetWebAuthnCredentialByCredentialID :one
+SELECT id, user_id, name, credential_id, public_key, attestation_type, aaguid, transports, sign_count, backup_eligible, backup_state, created_at, last_used_at FROM webauthn_credentials WHERE credential_id = $1
+`
+
+func (q *Queries) GetWebAuthnCredentialByCredentialID(ctx context.Context, credentialID []byte) (WebauthnCredential, error) {
+	row := q.db.QueryRow(ctx, getWebAuthnCredentialByCredentialID, credentialID)
+	var i WebauthnCredential
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.CredentialID,
+		&i.PublicKey,
+		&i.AttestationType,
+		&i.Aaguid,
+		&i.Transports,
+		&i.SignCount,
+		&i.BackupEligible,
+		&i.BackupState,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+	)
+	return i, err
+}
+
+const invalidateUserPasswordResetTokens = `-- name: InvalidateUserPasswordResetTokens :exec
+UPDATE password_reset_tokens SET used_at = now() WHERE user_id = $1 AND used_at IS NULL
+`
+
+func (q *Queries) InvalidateUserPasswordResetTokens(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, invalidateUserPasswordResetTokens, userID)
+	return err
+}
+
+const isAccessTokenRevoked = `-- name: IsAccessTokenRevoked :one
+SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1)
+    OR EXISTS(SELECT 1 FROM user_token_revocations
+              WHERE user_id = $2 AND date_trunc('second', revoked_at) >= $3::timestamptz
+                AND NOT EXISTS(SELECT 1 FROM sessions WHERE id = $4::text AND created_at > user_token_revocations.revoked_at))
+    OR EXISTS(SELECT 1 FROM sessions WHERE id = $4::text AND revoked_at IS NOT NULL) AS revoked
+`
+
+type IsAccessTokenRevokedParams struct {
+	Jti       string
+	UserID    string
+	IssuedAt  pgtype.Timestamptz
+	SessionID string
+}
+
+func (q *Queries) IsAccessTokenRevoked(ctx context.Context, arg IsAccessTokenRevokedParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isAccessTokenRevoked,
+		arg.Jti,
+		arg.UserID,
+		arg.IssuedAt,
+		arg.SessionID,
+	)
+	var revoked bool
+	err := row.Scan(&revoked)
+	return revoked, err
+}
+
+const isEmailTaken = `-- name: IsEmailTaken :one
+SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(email) = $1)
+    OR EXISTS(SELECT 1 FROM email_changes WHERE LOWER(new_email) = $1 AND user_id <> $2 AND expires_at > now()) AS taken
+`
+
+type IsEmailTakenParams struct {
+	Email  string
+	UserID string
+}
+
+func (q *Queries) IsEmailTaken(ctx context.Context, arg IsEmailTakenParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isEmailTaken, arg.Email, arg.UserID)
+	var taken bool
+	err := row.Scan(&taken)
+	return taken, err
+}
+
+const lockAdminRole = `-- name: LockAdminRole :exec
+SELECT pg_advisory_xact_lock(hashtext('user_roles:admin'))
+`
+
+func (q *Queries) LockAdminRole(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockAdminRole)
+	return err
+}
+
+const lockEmail = `-- name: LockEmail :exec
+SELECT pg_advisory_xact_lock(hashtext('email:' || $1::text))
+`
+
+func (q *Queries) LockEmail(ctx context.Context, email string) error {
+	_, err := q.db.Exec(ctx, lockEmail, email)
+	return err
+}
+
+const lockLoginAttempts = `-- name: LockLoginAttempts :exec
+UPDATE login_attempts SET locked_until = $2 WHERE subject = $1
+`
+
+type LockLoginAttemptsParams struct {
+	Subject     string
+	LockedUntil pgtype.Timestamptz
+}
+
+func (q *Queries) LockLoginAttempts(ctx context.Context, arg LockLoginAttemptsParams) error {
+	_, err := q.db.Exec(ctx, lockLoginAttempts, arg.Subject, arg.LockedUntil)
+	return err
+}
+
+const purgeDeletedUsers = `-- name: PurgeDeletedUsers :many
+DELETE FROM users WHERE deleted_at < $1::timestamptz RETURNING id
+`
+
+func (q *Queries) PurgeDeletedUsers(ctx context.Context, deletedBefore pgtype.Timestamptz) ([]string, error) {
+	rows, err := q.db.Query(ctx, purgeDeletedUsers, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const registerLoginFailure = `-- name: RegisterLoginFailure :one
+INSERT INTO login_attempts (subject, failures, last_failure_at) VALUES ($1, 1, now())
+ON CONFLICT (subject) DO UPDATE SET
+    failures = CASE WHEN login_attempts.last_failure_at < $2::timestamptz THEN 1 ELSE login_attempts.failures + 1 END,
+    last_failure_at = now()
+RETURNING subject, failures, last_failure_at, locked_until
+`
+
+type RegisterLoginFailureParams struct {
+	Subject     string
+	WindowStart pgtype.Timestamptz
+}
+
+func (q *Queries) RegisterLoginFailure(ctx context.Context, arg RegisterLoginFailureParams) (LoginAttempt, error) {
+	row := q.db.QueryRow(ctx, registerLoginFailure, arg.Subject, arg.WindowStart)
+	var i LoginAttempt
+	err := row.Scan(
+		&i.Subject,
+		&i.Failures,
+		&i.LastFailureAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const registerMailSend = `-- name: RegisterMailSend :one
+INSERT INTO mail_sends (subject, sent, window_started_at) VALUES ($1, 1, now())
+ON CONFLICT (subject) DO UPDATE SET
+    sent = CASE WHEN mail_sends.window_started_at < $2::timestamptz THEN 1 ELSE mail_sends.sent + 1 END,
+    window_started_at = CASE WHEN mail_sends.window_started_at < $2::timestamptz THEN now() ELSE mail_sends.window_started_at END
+RETURNING subject, sent, window_started_at
+`
+
+type RegisterMailSendParams struct {
+	Subject     string
+	WindowStart pgtype.Timestamptz
+}
+
+func (q *Queries) RegisterMailSend(ctx context.Context, arg RegisterMailSendParams) (MailSend, error) {
+	row := q.db.QueryRow(ctx, registerMailSend, arg.Subject, arg.WindowStart)
+	var i MailSend
+	err := row.Scan(&i.Subject, &i.Sent, &i.WindowStartedAt)
+	return i, err
+}
+
+const removeUserRole = `-- name: RemoveUserRole :exec
+DELETE FROM user_roles WHERE user_id = $1 AND role = $2
+`
+
+type RemoveUserRoleParams struct {
+	UserID string
+	Role   string
+}
+
+func (q *Queries) RemoveUserRole(ctx context.Context, arg RemoveUserRoleParams) error {
+	_, err := q.db.Exec(ctx, removeUserRole, arg.UserID, arg.Role)
+	return err
+}
+
+const restoreUser = `-- name: RestoreUser :execrows
+UPDATE users SET deleted_at = NULL WHERE id = $1 AND deleted_at > $2::timestamptz
+`
+
+type RestoreUserParams struct {
+	ID           string
+	DeletedAfter pgtype.Timestamptz
+}
+
+func (q *Queries) RestoreUser(ctx context.Context, arg RestoreUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreUser, arg.ID, arg.DeletedAfter)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeAccessToken = `-- name: RevokeAccessToken :execrows
+INSERT INTO revoked_tokens (jti, user_id, expires_at) VALUES ($1, $2, $3) ON CONFLICT (jti) DO NOTHING
+`
+
+type RevokeAccessTokenParams struct {
+	Jti       string
+	UserID    string
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) RevokeAccessToken(ctx context.Context, arg RevokeAccessTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeAccessToken, arg.Jti, arg.UserID, arg.ExpiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeApiKey = `-- name: RevokeApiKey :execrows
+UPDATE api_keys SET revoked_at = now() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+`
+
+type RevokeApiKeyParams struct {
+	ID     string
+	UserID string
+}
+
+func (q *Queries) RevokeApiKey(ctx context.Context, arg RevokeApiKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeApiKey, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeClientRefreshTokens = `-- name: RevokeClientRefreshTokens :exec
+UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND client_id = $2 AND revoked_at IS NULL
+`
+
+type RevokeClientRefreshTokensParams struct {
+	UserID   string
+	ClientID pgtype.Text
+}
+
+func (q *Queries) RevokeClientRefreshTokens(ctx context.Context, arg RevokeClientRefreshTokensParams) error {
+	_, err := q.db.Exec(ctx, revokeClientRefreshTokens, arg.UserID, arg.ClientID)
+	return err
+}
+
+const revokeOtherUserRefreshTokens = `-- name: RevokeOtherUserRefreshTokens :exec
+UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND family_id <> $2 AND revoked_at IS NULL
+`
+
+type RevokeOtherUserRefreshTokensParams struct {
+	UserID       string
+	KeepFamilyID string
+}
+
+func (q *Queries) RevokeOtherUserRefreshTokens(ctx context.Context, arg RevokeOtherUserRefreshTokensParams) error {
+	_, err := q.db.Exec(ctx, revokeOtherUserRefreshTokens, arg.UserID, arg.KeepFamilyID)
+	return err
+}
+
+const revokeOtherUserSessions = `-- name: RevokeOtherUserSessions :exec
+UPDATE sessions SET revoked_at = now() WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL
+`
+
+type RevokeOtherUserSessionsParams struct {
+	UserID string
+	KeepID string
+}
+
+func (q *Queries) RevokeOtherUserSessions(ctx context.Context, arg RevokeOtherUserSessionsParams) error {
+	_, err := q.db.Exec(ctx, revokeOtherUserSessions, arg.UserID, arg.KeepID)
+	return err
+}
+
+const revokeRefreshToken = `-- name: RevokeRefreshToken :execrows
+UPDATE refresh_tokens SET revoked_at = now(), replaced_by = $2 WHERE id = $1 AND revoked_at IS NULL
+`
+
+type RevokeRefreshTokenParams struct {
+	ID         string
+	ReplacedBy pgtype.Text
+}
+
+func (q *Queries) RevokeRefreshToken(ctx context.Context, arg RevokeRefreshTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeRefreshToken, arg.ID, arg.ReplacedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeRefreshTokenFamily = `-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens SET revoked_at = now() WHERE family_id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	_, err := q.db.Exec(ctx, revokeRefreshTokenFamily, familyID)
+	return err
+}
+
+const revokeSession = `-- name: RevokeSession :execrows
+UPDATE sessions SET revoked_at = now() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+`
+
+type RevokeSessionParams struct {
+	ID     string
+	UserID string
+}
+
+func (q *Queries) RevokeSession(ctx context.Context, arg RevokeSessionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeSession, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeUserAccessTokens = `-- name: RevokeUserAccessTokens :exec
+INSERT INTO user_token_revocations (user_id, revoked_at, expires_at) VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE SET revoked_at = EXCLUDED.revoked_at, expires_at = EXCLUDED.expires_at
+`
+
+type RevokeUserAccessTokensParams struct {
+	UserID    string
+	RevokedAt pgtype.Timestamptz
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) RevokeUserAccessTokens(ctx context.Context, arg RevokeUserAccessTokensParams) error {
+	_, err := q.db.Exec(ctx, revokeUserAccessTokens, arg.UserID, arg.RevokedAt, arg.ExpiresAt)
+	return err
+}
+
+const revokeUserApiKeys = `-- name: RevokeUserApiKeys :exec
+UPDATE api_keys SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeUserApiKeys(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, revokeUserApiKeys, userID)
+	return err
+}
+
+const revokeUserRefreshTokens = `-- name: RevokeUserRefreshTokens :exec
+UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeUserRefreshTokens(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, revokeUserRefreshTokens, userID)
+	return err
+}
+
+const revokeUserSessions = `-- name: RevokeUserSessions :exec
+UPDATE sessions SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeUserSessions(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, revokeUserSessions, userID)
+	return err
+}
+
+const saveOauthConsent = `-- name: SaveOauthConsent :exec
+INSERT INTO oauth_consents (user_id, client_id, scopes) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, client_id) DO UPDATE SET scopes = EXCLUDED.scopes, updated_at = now()
+`
+
+type SaveOauthConsentParams struct {
+	UserID   string
+	ClientID string
+	Scopes   []string
+}
+
+func (q *Queries) SaveOauthConsent(ctx context.Context, arg SaveOauthConsentParams) error {
+	_, err := q.db.Exec(ctx, saveOauthConsent, arg.UserID, arg.ClientID, arg.Scopes)
+	return err
+}
+
+const softDeleteUser = `-- name: SoftDeleteUser :execrows
+UPDATE users SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) SoftDeleteUser(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const touchApiKey = `-- name: TouchApiKey :exec
+UPDATE api_keys SET last_used_at = now() WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2::timestamptz)
+`
+
+type TouchApiKeyParams struct {
+	ID         string
+	UsedBefore pgtype.Timestamptz
+}
+
+func (q *Queries) TouchApiKey(ctx context.Context, arg TouchApiKeyParams) error {
+	_, err := q.db.Exec(ctx, touchApiKey, arg.ID, arg.UsedBefore)
+	return err
+}
+
+const touchSession = `-- name: TouchSession :exec
+UPDATE sessions SET last_active_at = now() WHERE id = $1 AND last_active_at < $2::timestamptz
+`
+
+type TouchSessionParams struct {
+	ID           string
+	ActiveBefore pgtype.Timestamptz
+}
+
+func (q *Queries) TouchSession(ctx context.Context, arg TouchSessionParams) error {
+	_, err := q.db.Exec(ctx, touchSession, arg.ID, arg.ActiveBefore)
+	return err
+}
+
+const touchUserIdentity = `-- name: TouchUserIdentity :exec
+UPDATE user_identities SET last_login_at = now(), email = $3 WHERE provider = $1 AND subject = $2
+`
+
+type TouchUserIdentityParams struct {
+	Provider string
+	Subject  string
+	Email    string
+}
+
+func (q *Queries) TouchUserIdentity(ctx context.Context, arg TouchUserIdentityParams) error {
+	_, err := q.db.Exec(ctx, touchUserIdentity, arg.Provider, arg.Subject, arg.Email)
+	return err
+}
+
+const updateUserEmail = `-- name: UpdateUserEmail :exec
+UPDATE users SET email = $2, email_verified_at = now() WHERE id = $1 AND deleted_at IS NULL
+`
+
+type UpdateUserEmailParams struct {
+	ID    string
+	Email string
+}
+
+func (q *Queries) UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) error {
+	_, err := q.db.Exec(ctx, updateUserEmail, arg.ID, arg.Email)
+	return err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users SET password_hash = $2 WHERE id = $1 AND deleted_at IS NULL
+`
+
+type UpdateUserPasswordParams struct {
+	ID           string
+	PasswordHash string
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.PasswordHash)
+	return err
+}
+
+const updateUserProfile = `-- name: UpdateUserProfile :one
+UPDATE users SET display_name = COALESCE($1::text, display_name),
+    locale = COALESCE($2::text, locale),
+    timezone = COALESCE($3::text, timezone)
+WHERE id = $4 AND deleted_at IS NULL RETURNING id, email, password_hash, email_verified_at, display_name, locale, timezone, deleted_at
+`
+
+type UpdateUserProfileParams struct {
+	DisplayName pgtype.Text
+	Locale      pgtype.Text
+	Timezone    pgtype.Text
+	ID          string
+}
+
+func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserProfile,
+		arg.DisplayName,
+		arg.Locale,
+		arg.Timezone,
+		arg.ID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.EmailVerifiedAt,
+		&i.DisplayName,
+		&i.Locale,
+		&i.Timezone,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const updateWebAuthnCredentialUsage = `-- name: UpdateWebAuthnCredentialUsage :exec
+UPDATE webauthn_credentials SET sign_count = $2, backup_state = $3, last_used_at = now() WHERE id = $1
+`
+
+type UpdateWebAuthnCredentialUsageParams struct {
+	ID          string
+	SignCount   int64
+	BackupState bool
+}
+
+func (q *Queries) UpdateWebAuthnCredentialUsage(ctx context.Context, arg UpdateWebAuthnCredentialUsageParams) error {
+	_, err := q.db.Exec(ctx, updateWebAuthnCredentialUsage, arg.ID, arg.SignCount, arg.BackupState)
+	return err
+}
+
+const upgradeUserPasswordHash = `-- name: UpgradeUserPasswordHash :execrows
+UPDATE users SET password_hash = $1 WHERE id = $2 AND password_hash = $3 AND deleted_at IS NULL
+`
+
+type UpgradeUserPasswordHashParams struct {
+	NewHash string
+	ID      string
+	OldHash string
+}
+
+func (q *Queries) UpgradeUserPasswordHash(ctx context.Context, arg UpgradeUserPasswordHashParams) (int64, error) {
+	result, err := q.db.Exec(ctx, upgradeUserPasswordHash, arg.NewHash, arg.ID, arg.OldHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertEmailChange = `-- name: UpsertEmailChange :exec
+INSERT INTO email_changes (user_id, new_email, expires_at) VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE SET new_email = EXCLUDED.new_email, expires_at = EXCLUDED.expires_at, created_at = now()
+`
+
+type UpsertEmailChangeParams struct {
+	UserID    string
+	NewEmail  string
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) UpsertEmailChange(ctx context.Context, arg UpsertEmailChangeParams) error {
+	_, err := q.db.Exec(ctx, upsertEmailChange, arg.UserID, arg.NewEmail, arg.ExpiresAt)
+	return err
+}
+
+const upsertUserTOTP = `-- name: UpsertUserTOTP :execrows
+INSERT INTO user_totp (user_id, secret) VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = 0, created_at = now()
+WHERE user_totp.confirmed_at IS NULL
+`
+
+type UpsertUserTOTPParams struct {
+	UserID string
+	Secret string
+}
+
+func (q *Queries) UpsertUserTOTP(ctx context.Context, arg UpsertUserTOTPParams) (int64, error) {
+	result, err := q.db.Exec(ctx, upsertUserTOTP, arg.UserID, arg.Secret)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const usePasswordResetToken = `-- name: UsePasswordResetToken :execrows
+UPDATE password_reset_tokens SET used_at = now() WHERE id = $1 AND used_at IS NULL AND expires_at > now()
+`
+
+func (q *Queries) UsePasswordResetToken(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, usePasswordResetToken, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const useRecoveryCode = `-- name: UseRecoveryCode :execrows
+UPDATE recovery_codes SET used_at = now() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+`
+
+type UseRecoveryCodeParams struct {
+	UserID   string
+	CodeHash string
+}
+
+func (q *Queries) UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useRecoveryCode, arg.UserID, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const useUserTOTPStep = `-- name: UseUserTOTPStep :execrows
+UPDATE user_totp SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2
+`
+
+type UseUserTOTPStepParams struct {
+	UserID       string
+	LastUsedStep int64
+}
+
+func (q *Queries) UseUserTOTPStep(ctx context.Context, arg UseUserTOTPStepParams) (int64, error) {
+	result, err := q.db.Exec(ctx, useUserTOTPStep, arg.UserID, arg.LastUsedStep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const verifyUserEmail = `-- name: VerifyUserEmail :execrows
+UPDATE users SET email_verified_at = now() WHERE id = $1 AND email = $2 AND email_verified_at IS NULL AND deleted_at IS NULL
+`
+
+type VerifyUserEmailParams struct {
+	ID    string
+	Email string
+}
+
+func (q *Queries) VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams) (int64, error) {
+	result, err := q.db.Exec(ctx, verifyUserEmail, arg.ID, arg.Email)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
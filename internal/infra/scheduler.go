@@ -0,0 +1,74 @@
+package infra
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+// Scheduler - периодические фоновые задачи, живущие вместе с приложением
+type Scheduler struct {
+	logger *Logger
+	jobs   []job
+}
+
+func NewScheduler(lc fx.Lifecycle, logger *Logger) *Scheduler {
+	scheduler := &Scheduler{logger: logger}
+
+	ctxWithCancel, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			for _, j := range scheduler.jobs {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					scheduler.loop(ctxWithCancel, j)
+				}()
+			}
+
+			logger.Infow("scheduler started", zap.Int("jobs", len(scheduler.jobs)))
+			return nil
+		},
+
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			wg.Wait()
+			logger.Info("scheduler stopped")
+			return nil
+		},
+	})
+
+	return scheduler
+}
+
+// Every - зарегистрировать задачу, должна вызываться до старта приложения
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context) error) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+func (s *Scheduler) loop(ctx context.Context, j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.run(ctx); err != nil {
+				s.logger.Errorw("scheduled job failed", zap.String("job", j.name), zap.Error(err))
+			}
+		}
+	}
+}
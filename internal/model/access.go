@@ -0,0 +1,60 @@
+package model
+
+import "slices"
+
+// Роли, создаваемые миграцией
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Права, создаваемые миграцией
+const (
+	PermissionUsersRead     = "users:read"
+	PermissionUsersWrite    = "users:write"
+	PermissionRolesManage   = "roles:manage"
+	PermissionClientsManage = "clients:manage"
+	// PermissionUsersImpersonate - не входит в Scopes: имперсонация доступна только из сессии администратора
+	PermissionUsersImpersonate = "users:impersonate"
+)
+
+// Scopes - scope, которые можно выдать API ключу или клиенту OAuth. Совпадают с правами, поэтому
+// ключ или клиент получает право, только если оно есть и в scope, и у ролей пользователя.
+var Scopes = []string{PermissionUsersRead, PermissionUsersWrite, PermissionRolesManage, PermissionClientsManage}
+
+// Principal - аутентифицированный субъект запроса, извлечённый из токена или API ключа
+type Principal struct {
+	// UserID - пустой у токена, выданного клиенту OAuth от своего имени (client credentials)
+	UserID string
+	Roles  []string
+	// APIKeyID - ключ, которым аутентифицирован запрос, пустой для токенов
+	APIKeyID string
+	// ClientID - клиент OAuth, которому выдан токен
+	ClientID string
+	// SessionID - сессия, которой выдан токен, пустой у API ключей и client credentials
+	SessionID string
+	// ActorID - администратор, действующий от имени UserID (claim act), пустой без имперсонации
+	ActorID string
+	// Scopes - nil у токенов сессии, их scope не ограничены
+	Scopes []string
+}
+
+// Restricted - ограничен ли субъект своими scope: API ключ или токен клиента OAuth
+func (p Principal) Restricted() bool {
+	return p.APIKeyID != "" || p.Scopes != nil
+}
+
+// Allows - разрешают ли scope субъекта перечисленные действия
+func (p Principal) Allows(scopes ...string) bool {
+	if !p.Restricted() {
+		return true
+	}
+
+	for _, scope := range scopes {
+		if !slices.Contains(p.Scopes, scope) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// APIKey - API ключ пользователя для скриптов и CI. Key заполнен только при создании,
+// в БД хранится лишь его хеш, а для узнавания ключа в списке - Prefix.
+type APIKey struct {
+	ID         string
+	Name       string
+	Prefix     string
+	Key        string
+	Scopes     []string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
@@ -0,0 +1,100 @@
+package model
+
+import "time"
+
+// Состояния выгрузки персональных данных
+const (
+	DataExportPending = "pending"
+	DataExportRunning = "running"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+)
+
+// DataExport - выгрузка персональных данных пользователя. DownloadToken заполнен только у готовой выгрузки,
+// это подписанная ссылка на архив, действующая до ExpiresAt
+type DataExport struct {
+	ID            string
+	Status        string
+	Error         string
+	CreatedAt     time.Time
+	CompletedAt   *time.Time
+	ExpiresAt     *time.Time
+	DownloadToken string
+}
+
+// DataExportArchive - ZIP архив с JSON файлом на каждый раздел персональных данных
+type DataExportArchive struct {
+	Name    string
+	Content []byte
+}
+
+// Разделы выгрузки персональных данных. Формат файлов архива - часть публичного контракта,
+// поэтому у разделов свои типы с явными именами полей, а не модели сервисов
+
+// ExportProfile - раздел "profile": почта и настройки профиля
+type ExportProfile struct {
+	ID              string     `json:"id"`
+	Email           string     `json:"email"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	DisplayName     string     `json:"display_name"`
+	Locale          string     `json:"locale"`
+	Timezone        string     `json:"timezone"`
+}
+
+// ExportSession - запись раздела "sessions"
+type ExportSession struct {
+	ID           string    `json:"id"`
+	ClientID     string    `json:"client_id,omitempty"`
+	UserAgent    string    `json:"user_agent"`
+	IP           string    `json:"ip"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastActiveAt time.Time `json:"last_active_at"`
+}
+
+// ExportAPIKey - запись раздела "api_keys", сам ключ не хранится
+type ExportAPIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// ExportIdentity - запись раздела "identities"
+type ExportIdentity struct {
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+// ExportPasskey - запись раздела "passkeys", без открытого ключа
+type ExportPasskey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// ExportOAuthConsent - запись раздела "oauth_consents"
+type ExportOAuthConsent struct {
+	ClientID   string    `json:"client_id"`
+	ClientName string    `json:"client_name"`
+	Scopes     []string  `json:"scopes"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ExportImpersonationEvent - запись раздела "impersonation_events"
+type ExportImpersonationEvent struct {
+	ID        string    `json:"id"`
+	ActorID   string    `json:"actor_id"`
+	UserID    string    `json:"user_id"`
+	Action    string    `json:"action"`
+	Status    int       `json:"status,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+}
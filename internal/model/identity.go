@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Провайдеры проверки пароля из AUTH_PROVIDERS, ldap - также имя аккаунта каталога в привязанных аккаунтах
+const (
+	AuthProviderLocal = "local"
+	AuthProviderLDAP  = "ldap"
+)
+
+// OIDCAuthorization - начатый вход через OpenID Connect провайдера
+type OIDCAuthorization struct {
+	URL   string
+	State string
+}
+
+// Identity - аккаунт внешнего провайдера, привязанный к пользователю
+type Identity struct {
+	Provider    string
+	Email       string
+	CreatedAt   time.Time
+	LastLoginAt *time.Time
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// ActionImpersonationStarted - запись журнала о выдаче токена, остальные записи - запросы вида "GET /api/..."
+const ActionImpersonationStarted = "impersonation started"
+
+// Impersonation - токен для входа администратора от имени пользователя, refresh токен не выдаётся
+type Impersonation struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+// ImpersonationEvent - запись журнала имперсонации: выдача токена или запрос, выполненный с ним
+type ImpersonationEvent struct {
+	ID      string
+	ActorID string
+	UserID  string
+	Action  string
+	// Status - HTTP статус ответа, 0 у записи о выдаче токена
+	Status int
+	// Reason - причина, указанная администратором при выдаче токена
+	Reason    string
+	IP        string
+	CreatedAt time.Time
+}
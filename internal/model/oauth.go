@@ -0,0 +1,75 @@
+package model
+
+import "time"
+
+// Grant types, которые можно разрешить клиенту OAuth.
+// refresh_token отдельно не регистрируется: он доступен всем клиентам с authorization_code.
+const (
+	GrantAuthorizationCode = "authorization_code"
+	GrantClientCredentials = "client_credentials"
+	GrantRefreshToken      = "refresh_token"
+)
+
+// OAuthClient - зарегистрированный клиент OAuth
+type OAuthClient struct {
+	ID   string
+	Name string
+	// Secret - заполнен только при регистрации конфиденциального клиента, хранится хеш
+	Secret       string
+	RedirectURIs []string
+	GrantTypes   []string
+	Scopes       []string
+	// Confidential - клиент хранит секрет (сервер партнёра), публичные клиенты (SPA, мобильные) обязаны использовать PKCE
+	Confidential bool
+	// FirstParty - собственное приложение: согласие не спрашивается, зарегистрировать его можно только из сессии
+	FirstParty bool
+	CreatedAt  time.Time
+}
+
+// OAuthAuthorizeRequest - параметры запроса авторизации (RFC 6749, раздел 4.1.1) и решение пользователя
+type OAuthAuthorizeRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	// Consent - пользователь подтвердил запрошенные scope
+	Consent bool
+}
+
+// OAuthAuthorization - результат запроса авторизации: адрес возврата с кодом либо запрос согласия
+type OAuthAuthorization struct {
+	RedirectTo      string
+	ConsentRequired bool
+	ClientName      string
+	Scopes          []string
+}
+
+// OAuthTokenRequest - параметры token endpoint (RFC 6749, раздел 4)
+type OAuthTokenRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+}
+
+// OAuthToken - токены, выданные клиенту, Scopes пустые у токенов без ограничений
+type OAuthToken struct {
+	TokenPair
+	Scopes []string
+}
+
+// OAuthConsent - согласие пользователя на доступ клиента
+type OAuthConsent struct {
+	ClientID   string
+	ClientName string
+	Scopes     []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
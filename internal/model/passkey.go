@@ -0,0 +1,20 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PasskeyCeremony - начатая WebAuthn церемония: ID сессии на сервере и options для navigator.credentials
+type PasskeyCeremony struct {
+	SessionID string
+	Options   json.RawMessage
+}
+
+// Passkey - зарегистрированный WebAuthn ключ пользователя
+type Passkey struct {
+	ID         string
+	Name       string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
@@ -0,0 +1,10 @@
+package model
+
+// PasswordChange - смена пароля пользователем, который знает текущий пароль
+type PasswordChange struct {
+	CurrentPassword string
+	NewPassword     string
+	// RevokeOtherSessions - завершить все сессии, кроме той, из которой меняется пароль, и отозвать API ключи.
+	// Токены имперсонации сессии не имеют и действуют до истечения
+	RevokeOtherSessions bool
+}
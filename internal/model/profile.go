@@ -0,0 +1,8 @@
+package model
+
+// ProfileUpdate - изменение профиля, nil поля остаются как были
+type ProfileUpdate struct {
+	DisplayName *string
+	Locale      *string
+	Timezone    *string
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// Session - вход пользователя на устройстве. Совпадает с семейством refresh токенов,
+// её ID попадает в access токены, поэтому отзыв сессии отзывает и их.
+type Session struct {
+	ID string
+	// ClientID - клиент OAuth, через который выполнен вход, пустой при входе в само приложение
+	ClientID     string
+	UserAgent    string
+	IP           string
+	CreatedAt    time.Time
+	LastActiveAt time.Time
+	// Current - сессия, которой выдан токен текущего запроса
+	Current bool
+}
+
+// ClientInfo - устройство, с которого пришёл запрос
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+type clientInfoKey struct{}
+
+// WithClientInfo - сохранить в контексте устройство запроса, чтобы сервисы могли записать его в сессию
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+// ClientInfoFromContext - устройство запроса, пустое вне HTTP запроса
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
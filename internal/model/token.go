@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// TokenPair - access и refresh токены, выдаваемые при входе
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	// ExpiresIn - время жизни access токена
+	ExpiresIn time.Duration
+}
+
+// LoginResult - результат входа по паролю: пара токенов либо challenge для второго фактора
+type LoginResult struct {
+	Tokens         TokenPair
+	ChallengeToken string
+}
+
+// TOTPEnrollment - данные для добавления секрета в приложение-аутентификатор
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+	QRCode []byte
+}
@@ -0,0 +1,69 @@
+package apiKeyRepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+)
+
+func (ar *APIKeyRepository) Create(ctx context.Context, key queries.ApiKey) error {
+	rq := queries.New(ar.pgxpool)
+	return rq.CreateApiKey(ctx, queries.CreateApiKeyParams{
+		ID:        key.ID,
+		UserID:    key.UserID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		KeyHash:   key.KeyHash,
+		Scopes:    key.Scopes,
+		ExpiresAt: key.ExpiresAt,
+	})
+}
+
+func (ar *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (queries.ApiKey, error) {
+	rq := queries.New(ar.pgxpool)
+	return rq.GetApiKeyByHash(ctx, keyHash)
+}
+
+// GetUserKeys - неотозванные ключи пользователя, включая истёкшие
+func (ar *APIKeyRepository) GetUserKeys(ctx context.Context, userID string) ([]queries.ApiKey, error) {
+	rq := queries.New(ar.pgxpool)
+	return rq.GetUserApiKeys(ctx, userID)
+}
+
+// Revoke - отозвать ключ пользователя, чужой, несуществующий или уже отозванный ключ даёт pgx.ErrNoRows
+func (ar *APIKeyRepository) Revoke(ctx context.Context, id, userID string) error {
+	rq := queries.New(ar.pgxpool)
+
+	rows, err := rq.RevokeApiKey(ctx, queries.RevokeApiKeyParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// Touch - обновить last_used_at, если он старше usedBefore, чтобы не писать в БД на каждый запрос
+func (ar *APIKeyRepository) Touch(ctx context.Context, id string, usedBefore time.Time) error {
+	rq := queries.New(ar.pgxpool)
+	return rq.TouchApiKey(ctx, queries.TouchApiKeyParams{
+		ID:         id,
+		UsedBefore: pgtype.Timestamptz{Time: usedBefore, Valid: true},
+	})
+}
+
+// DeleteExpired - удалить истёкшие и отозванные ключи
+func (ar *APIKeyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	rq := queries.New(ar.pgxpool)
+	return rq.DeleteExpiredApiKeys(ctx)
+}
@@ -0,0 +1,13 @@
+package apiKeyRepo
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type APIKeyRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func New(pgxpool *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{
+		pgxpool: pgxpool,
+	}
+}
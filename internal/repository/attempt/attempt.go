@@ -0,0 +1,90 @@
+package attemptRepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// GetLockedUntil - самая поздняя из действующих блокировок среди subjects, нулевое время если блокировок нет
+func (ar *AttemptRepository) GetLockedUntil(ctx context.Context, subjects []string) (time.Time, error) {
+	rq := queries.New(ar.pgxpool)
+	locked, err := rq.GetLockedLoginAttempts(ctx, subjects)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var lockedUntil time.Time
+	for _, attempt := range locked {
+		if attempt.LockedUntil.Time.After(lockedUntil) {
+			lockedUntil = attempt.LockedUntil.Time
+		}
+	}
+	return lockedUntil, nil
+}
+
+// RegisterFailure - учесть неудачную попытку и вернуть число неудач подряд.
+// Неудачи старше windowStart забываются, счёт начинается заново.
+func (ar *AttemptRepository) RegisterFailure(ctx context.Context, subject string, windowStart time.Time) (int32, error) {
+	rq := queries.New(ar.pgxpool)
+	attempt, err := rq.RegisterLoginFailure(ctx, queries.RegisterLoginFailureParams{
+		Subject:     subject,
+		WindowStart: pgtype.Timestamptz{Time: windowStart, Valid: true},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return attempt.Failures, nil
+}
+
+// RegisterSend - учесть письмо на subject и вернуть число писем в окне и начало окна.
+// Окно отсчитывается от первого письма и не продлевается повторными запросами, после windowStart счёт начинается заново
+func (ar *AttemptRepository) RegisterSend(ctx context.Context, subject string, windowStart time.Time) (int32, time.Time, error) {
+	rq := queries.New(ar.pgxpool)
+	send, err := rq.RegisterMailSend(ctx, queries.RegisterMailSendParams{
+		Subject:     subject,
+		WindowStart: pgtype.Timestamptz{Time: windowStart, Valid: true},
+	})
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return send.Sent, send.WindowStartedAt.Time, nil
+}
+
+func (ar *AttemptRepository) Lock(ctx context.Context, subject string, until time.Time) error {
+	rq := queries.New(ar.pgxpool)
+	return rq.LockLoginAttempts(ctx, queries.LockLoginAttemptsParams{
+		Subject:     subject,
+		LockedUntil: pgtype.Timestamptz{Time: until, Valid: true},
+	})
+}
+
+// Reset - забыть неудачные попытки и снять блокировку
+func (ar *AttemptRepository) Reset(ctx context.Context, subject string) error {
+	rq := queries.New(ar.pgxpool)
+	return rq.DeleteLoginAttempts(ctx, subject)
+}
+
+// DeleteStale - удалить счётчики без неудач после before и без действующей блокировки
+// и счётчики писем, окно которых началось до before
+func (ar *AttemptRepository) DeleteStale(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	err := utils.ExecInTx(ctx, ar.pgxpool, func(tq *queries.Queries) error {
+		for _, purge := range []func(context.Context, pgtype.Timestamptz) (int64, error){
+			tq.DeleteStaleLoginAttempts,
+			tq.DeleteStaleMailSends,
+		} {
+			rows, err := purge(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+			if err != nil {
+				return err
+			}
+			total += rows
+		}
+		return nil
+	})
+	return total, err
+}
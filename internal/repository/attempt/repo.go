@@ -0,0 +1,13 @@
+package attemptRepo
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type AttemptRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func New(pgxpool *pgxpool.Pool) *AttemptRepository {
+	return &AttemptRepository{
+		pgxpool: pgxpool,
+	}
+}
@@ -0,0 +1,81 @@
+package exportRepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// Create - поставить выгрузку в очередь, пока предыдущая не собрана, новая даёт utils.ErrExportInProgress
+func (er *ExportRepository) Create(ctx context.Context, id, userID string) error {
+	rq := queries.New(er.pgxpool)
+
+	rows, err := rq.CreateDataExport(ctx, queries.CreateDataExportParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return utils.ErrExportInProgress
+	}
+
+	return nil
+}
+
+// GetUserExports - последние выгрузки пользователя без самих архивов, сначала новые
+func (er *ExportRepository) GetUserExports(ctx context.Context, userID string, limit int32) ([]queries.GetUserDataExportsRow, error) {
+	rq := queries.New(er.pgxpool)
+	return rq.GetUserDataExports(ctx, queries.GetUserDataExportsParams{
+		UserID: userID,
+		Limit:  limit,
+	})
+}
+
+// Claim - взять в работу самую старую выгрузку из очереди или зависшую с начатой до staleBefore сборкой.
+// Пустая очередь даёт pgx.ErrNoRows, параллельные сборщики получают разные выгрузки
+func (er *ExportRepository) Claim(ctx context.Context, staleBefore time.Time) (queries.ClaimDataExportRow, error) {
+	rq := queries.New(er.pgxpool)
+	return rq.ClaimDataExport(ctx, pgtype.Timestamptz{Time: staleBefore, Valid: true})
+}
+
+// Complete - сохранить собранный архив, скачать его можно до expiresAt
+func (er *ExportRepository) Complete(ctx context.Context, id string, archive []byte, expiresAt time.Time) error {
+	rq := queries.New(er.pgxpool)
+	return rq.CompleteDataExport(ctx, queries.CompleteDataExportParams{
+		ID:        id,
+		Archive:   archive,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+}
+
+// Fail - отметить, что архив собрать не удалось, запись видна пользователю до expiresAt
+func (er *ExportRepository) Fail(ctx context.Context, id, reason string, expiresAt time.Time) error {
+	rq := queries.New(er.pgxpool)
+	return rq.FailDataExport(ctx, queries.FailDataExportParams{
+		ID:        id,
+		Error:     reason,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+}
+
+// GetArchive - готовый и не истёкший архив пользователя, иначе pgx.ErrNoRows
+func (er *ExportRepository) GetArchive(ctx context.Context, id, userID string) ([]byte, error) {
+	rq := queries.New(er.pgxpool)
+	return rq.GetDataExportArchive(ctx, queries.GetDataExportArchiveParams{
+		ID:     id,
+		UserID: userID,
+	})
+}
+
+// DeleteExpired - удалить истёкшие архивы и записи о неудачных выгрузках
+func (er *ExportRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	rq := queries.New(er.pgxpool)
+	return rq.DeleteExpiredDataExports(ctx)
+}
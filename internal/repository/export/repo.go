@@ -0,0 +1,13 @@
+package exportRepo
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type ExportRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func New(pgxpool *pgxpool.Pool) *ExportRepository {
+	return &ExportRepository{
+		pgxpool: pgxpool,
+	}
+}
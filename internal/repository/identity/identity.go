@@ -0,0 +1,146 @@
+package identityRepo
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const uniqueViolation = "23505"
+
+func (ir *IdentityRepository) GetIdentity(ctx context.Context, provider, subject string) (queries.UserIdentity, error) {
+	rq := queries.New(ir.pgxpool)
+	return rq.GetUserIdentity(ctx, queries.GetUserIdentityParams{
+		Provider: provider,
+		Subject:  subject,
+	})
+}
+
+func (ir *IdentityRepository) GetUserIdentities(ctx context.Context, userID string) ([]queries.UserIdentity, error) {
+	rq := queries.New(ir.pgxpool)
+	return rq.GetUserIdentities(ctx, userID)
+}
+
+// Link - привязать внешний аккаунт к пользователю, если аккаунт уже привязан
+// или у пользователя уже есть аккаунт этого провайдера, возвращается utils.ErrIdentityLinked
+func (ir *IdentityRepository) Link(ctx context.Context, identity queries.UserIdentity) error {
+	rq := queries.New(ir.pgxpool)
+	return linkError(rq.CreateUserIdentity(ctx, queries.CreateUserIdentityParams{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		UserID:   identity.UserID,
+		Email:    identity.Email,
+	}))
+}
+
+// CreateUser - зарегистрировать пользователя без пароля сразу с привязанным внешним аккаунтом,
+// почта считается подтверждённой провайдером
+func (ir *IdentityRepository) CreateUser(ctx context.Context, user queries.User, identity queries.UserIdentity) error {
+	return utils.ExecInTx(ctx, ir.pgxpool, func(tq *queries.Queries) error {
+		// serialized with registrations and email changes to the same address
+		email := strings.ToLower(user.Email)
+		if err := tq.LockEmail(ctx, email); err != nil {
+			return err
+		}
+
+		if taken, err := tq.IsEmailTaken(ctx, queries.IsEmailTakenParams{Email: email}); err != nil {
+			return err
+		} else if taken {
+			return utils.ErrEmailAlreadySignup
+		}
+
+		if err := tq.CreateUser(ctx, queries.CreateUserParams{
+			ID:           user.ID,
+			Email:        user.Email,
+			PasswordHash: user.PasswordHash,
+		}); err != nil {
+			return err
+		}
+
+		if err := tq.AssignUserRole(ctx, queries.AssignUserRoleParams{
+			UserID: user.ID,
+			Role:   model.RoleUser,
+		}); err != nil {
+			return err
+		}
+
+		if _, err := tq.VerifyUserEmail(ctx, queries.VerifyUserEmailParams{
+			ID:    user.ID,
+			Email: user.Email,
+		}); err != nil {
+			return err
+		}
+
+		return linkError(tq.CreateUserIdentity(ctx, queries.CreateUserIdentityParams{
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			UserID:   user.ID,
+			Email:    identity.Email,
+		}))
+	})
+}
+
+// Touch - запомнить время входа и актуальную почту из провайдера
+func (ir *IdentityRepository) Touch(ctx context.Context, provider, subject, email string) error {
+	rq := queries.New(ir.pgxpool)
+	return rq.TouchUserIdentity(ctx, queries.TouchUserIdentityParams{
+		Provider: provider,
+		Subject:  subject,
+		Email:    email,
+	})
+}
+
+// Unlink - отвязать аккаунт провайдера, если его нет, возвращается pgx.ErrNoRows
+func (ir *IdentityRepository) Unlink(ctx context.Context, userID, provider string) error {
+	rq := queries.New(ir.pgxpool)
+	rows, err := rq.DeleteUserIdentity(ctx, queries.DeleteUserIdentityParams{
+		UserID:   userID,
+		Provider: provider,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (ir *IdentityRepository) CreateState(ctx context.Context, state queries.OidcState) error {
+	rq := queries.New(ir.pgxpool)
+	return rq.CreateOidcState(ctx, queries.CreateOidcStateParams{
+		ID:           state.ID,
+		Provider:     state.Provider,
+		UserID:       state.UserID,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+		ExpiresAt:    state.ExpiresAt,
+	})
+}
+
+// ConsumeState - получить и сразу удалить неистёкший state, повторно его использовать нельзя
+func (ir *IdentityRepository) ConsumeState(ctx context.Context, id string) (queries.OidcState, error) {
+	rq := queries.New(ir.pgxpool)
+	return rq.ConsumeOidcState(ctx, id)
+}
+
+func (ir *IdentityRepository) DeleteExpiredStates(ctx context.Context) (int64, error) {
+	rq := queries.New(ir.pgxpool)
+	return rq.DeleteExpiredOidcStates(ctx)
+}
+
+func linkError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return utils.ErrIdentityLinked
+	}
+	return err
+}
@@ -0,0 +1,13 @@
+package identityRepo
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type IdentityRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func New(pgxpool *pgxpool.Pool) *IdentityRepository {
+	return &IdentityRepository{
+		pgxpool: pgxpool,
+	}
+}
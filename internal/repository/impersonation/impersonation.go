@@ -0,0 +1,26 @@
+package impersonationRepo
+
+import (
+	"context"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+)
+
+func (ir *ImpersonationRepository) Record(ctx context.Context, event queries.ImpersonationEvent) error {
+	rq := queries.New(ir.pgxpool)
+	return rq.CreateImpersonationEvent(ctx, queries.CreateImpersonationEventParams{
+		ID:      event.ID,
+		ActorID: event.ActorID,
+		UserID:  event.UserID,
+		Action:  event.Action,
+		Status:  event.Status,
+		Reason:  event.Reason,
+		Ip:      event.Ip,
+	})
+}
+
+// GetEvents - записи журнала, где пользователь был целью или администратором, сначала новые
+func (ir *ImpersonationRepository) GetEvents(ctx context.Context, userID string) ([]queries.ImpersonationEvent, error) {
+	rq := queries.New(ir.pgxpool)
+	return rq.GetImpersonationEvents(ctx, userID)
+}
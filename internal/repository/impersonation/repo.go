@@ -0,0 +1,13 @@
+package impersonationRepo
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type ImpersonationRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func New(pgxpool *pgxpool.Pool) *ImpersonationRepository {
+	return &ImpersonationRepository{
+		pgxpool: pgxpool,
+	}
+}
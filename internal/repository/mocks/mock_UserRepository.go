@@ -6,7 +6,9 @@ package repository
 
 import (
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
 	"context"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -38,6 +40,69 @@ func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
 	return &MockUserRepository_Expecter{mock: &_m.Mock}
 }
 
+// ConfirmEmailChange provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ConfirmEmailChange(ctx context.Context, userID string, newEmail string) error {
+	ret := _mock.Called(ctx, userID, newEmail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEmailChange")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, newEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_ConfirmEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmEmailChange'
+type MockUserRepository_ConfirmEmailChange_Call struct {
+	*mock.Call
+}
+
+// ConfirmEmailChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - newEmail string
+func (_e *MockUserRepository_Expecter) ConfirmEmailChange(ctx interface{}, userID interface{}, newEmail interface{}) *MockUserRepository_ConfirmEmailChange_Call {
+	return &MockUserRepository_ConfirmEmailChange_Call{Call: _e.mock.On("ConfirmEmailChange", ctx, userID, newEmail)}
+}
+
+func (_c *MockUserRepository_ConfirmEmailChange_Call) Run(run func(ctx context.Context, userID string, newEmail string)) *MockUserRepository_ConfirmEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ConfirmEmailChange_Call) Return(err error) *MockUserRepository_ConfirmEmailChange_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_ConfirmEmailChange_Call) RunAndReturn(run func(ctx context.Context, userID string, newEmail string) error) *MockUserRepository_ConfirmEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) Create(ctx context.Context, user queries.User) error {
 	ret := _mock.Called(ctx, user)
@@ -95,6 +160,132 @@ func (_c *MockUserRepository_Create_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// CreateEmailChange provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) CreateEmailChange(ctx context.Context, change queries.EmailChange) error {
+	ret := _mock.Called(ctx, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEmailChange")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, queries.EmailChange) error); ok {
+		r0 = returnFunc(ctx, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_CreateEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEmailChange'
+type MockUserRepository_CreateEmailChange_Call struct {
+	*mock.Call
+}
+
+// CreateEmailChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - change queries.EmailChange
+func (_e *MockUserRepository_Expecter) CreateEmailChange(ctx interface{}, change interface{}) *MockUserRepository_CreateEmailChange_Call {
+	return &MockUserRepository_CreateEmailChange_Call{Call: _e.mock.On("CreateEmailChange", ctx, change)}
+}
+
+func (_c *MockUserRepository_CreateEmailChange_Call) Run(run func(ctx context.Context, change queries.EmailChange)) *MockUserRepository_CreateEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 queries.EmailChange
+		if args[1] != nil {
+			arg1 = args[1].(queries.EmailChange)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_CreateEmailChange_Call) Return(err error) *MockUserRepository_CreateEmailChange_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_CreateEmailChange_Call) RunAndReturn(run func(ctx context.Context, change queries.EmailChange) error) *MockUserRepository_CreateEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Delete(ctx context.Context, id string, revokedAt time.Time, expiresAt time.Time) error {
+	ret := _mock.Called(ctx, id, revokedAt, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, revokedAt, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockUserRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - revokedAt time.Time
+//   - expiresAt time.Time
+func (_e *MockUserRepository_Expecter) Delete(ctx interface{}, id interface{}, revokedAt interface{}, expiresAt interface{}) *MockUserRepository_Delete_Call {
+	return &MockUserRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id, revokedAt, expiresAt)}
+}
+
+func (_c *MockUserRepository_Delete_Call) Run(run func(ctx context.Context, id string, revokedAt time.Time, expiresAt time.Time)) *MockUserRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Delete_Call) Return(err error) *MockUserRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string, revokedAt time.Time, expiresAt time.Time) error) *MockUserRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserByEmail provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (queries.User, error) {
 	ret := _mock.Called(ctx, email)
@@ -226,3 +417,422 @@ func (_c *MockUserRepository_GetUserByID_Call) RunAndReturn(run func(ctx context
 	_c.Call.Return(run)
 	return _c
 }
+
+// PurgeDeleted provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	ret := _mock.Called(ctx, deletedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeleted")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]string, error)); ok {
+		return returnFunc(ctx, deletedBefore)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []string); ok {
+		r0 = returnFunc(ctx, deletedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_PurgeDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeleted'
+type MockUserRepository_PurgeDeleted_Call struct {
+	*mock.Call
+}
+
+// PurgeDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deletedBefore time.Time
+func (_e *MockUserRepository_Expecter) PurgeDeleted(ctx interface{}, deletedBefore interface{}) *MockUserRepository_PurgeDeleted_Call {
+	return &MockUserRepository_PurgeDeleted_Call{Call: _e.mock.On("PurgeDeleted", ctx, deletedBefore)}
+}
+
+func (_c *MockUserRepository_PurgeDeleted_Call) Run(run func(ctx context.Context, deletedBefore time.Time)) *MockUserRepository_PurgeDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_PurgeDeleted_Call) Return(strings []string, err error) *MockUserRepository_PurgeDeleted_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockUserRepository_PurgeDeleted_Call) RunAndReturn(run func(ctx context.Context, deletedBefore time.Time) ([]string, error)) *MockUserRepository_PurgeDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Restore(ctx context.Context, link queries.RevokedToken, deletedAfter time.Time) error {
+	ret := _mock.Called(ctx, link, deletedAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, queries.RevokedToken, time.Time) error); ok {
+		r0 = returnFunc(ctx, link, deletedAfter)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockUserRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link queries.RevokedToken
+//   - deletedAfter time.Time
+func (_e *MockUserRepository_Expecter) Restore(ctx interface{}, link interface{}, deletedAfter interface{}) *MockUserRepository_Restore_Call {
+	return &MockUserRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, link, deletedAfter)}
+}
+
+func (_c *MockUserRepository_Restore_Call) Run(run func(ctx context.Context, link queries.RevokedToken, deletedAfter time.Time)) *MockUserRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 queries.RevokedToken
+		if args[1] != nil {
+			arg1 = args[1].(queries.RevokedToken)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Restore_Call) Return(err error) *MockUserRepository_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, link queries.RevokedToken, deletedAfter time.Time) error) *MockUserRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevertEmailChange provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) RevertEmailChange(ctx context.Context, link queries.RevokedToken, email string, revokedAt time.Time, expiresAt time.Time) error {
+	ret := _mock.Called(ctx, link, email, revokedAt, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertEmailChange")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, queries.RevokedToken, string, time.Time, time.Time) error); ok {
+		r0 = returnFunc(ctx, link, email, revokedAt, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_RevertEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertEmailChange'
+type MockUserRepository_RevertEmailChange_Call struct {
+	*mock.Call
+}
+
+// RevertEmailChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link queries.RevokedToken
+//   - email string
+//   - revokedAt time.Time
+//   - expiresAt time.Time
+func (_e *MockUserRepository_Expecter) RevertEmailChange(ctx interface{}, link interface{}, email interface{}, revokedAt interface{}, expiresAt interface{}) *MockUserRepository_RevertEmailChange_Call {
+	return &MockUserRepository_RevertEmailChange_Call{Call: _e.mock.On("RevertEmailChange", ctx, link, email, revokedAt, expiresAt)}
+}
+
+func (_c *MockUserRepository_RevertEmailChange_Call) Run(run func(ctx context.Context, link queries.RevokedToken, email string, revokedAt time.Time, expiresAt time.Time)) *MockUserRepository_RevertEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 queries.RevokedToken
+		if args[1] != nil {
+			arg1 = args[1].(queries.RevokedToken)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_RevertEmailChange_Call) Return(err error) *MockUserRepository_RevertEmailChange_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_RevertEmailChange_Call) RunAndReturn(run func(ctx context.Context, link queries.RevokedToken, email string, revokedAt time.Time, expiresAt time.Time) error) *MockUserRepository_RevertEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfile provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) UpdateProfile(ctx context.Context, id string, update model.ProfileUpdate) (queries.User, error) {
+	ret := _mock.Called(ctx, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 queries.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.ProfileUpdate) (queries.User, error)); ok {
+		return returnFunc(ctx, id, update)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.ProfileUpdate) queries.User); ok {
+		r0 = returnFunc(ctx, id, update)
+	} else {
+		r0 = ret.Get(0).(queries.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.ProfileUpdate) error); ok {
+		r1 = returnFunc(ctx, id, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type MockUserRepository_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - update model.ProfileUpdate
+func (_e *MockUserRepository_Expecter) UpdateProfile(ctx interface{}, id interface{}, update interface{}) *MockUserRepository_UpdateProfile_Call {
+	return &MockUserRepository_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, id, update)}
+}
+
+func (_c *MockUserRepository_UpdateProfile_Call) Run(run func(ctx context.Context, id string, update model.ProfileUpdate)) *MockUserRepository_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.ProfileUpdate
+		if args[2] != nil {
+			arg2 = args[2].(model.ProfileUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateProfile_Call) Return(user queries.User, err error) *MockUserRepository_UpdateProfile_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateProfile_Call) RunAndReturn(run func(ctx context.Context, id string, update model.ProfileUpdate) (queries.User, error)) *MockUserRepository_UpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpgradePasswordHash provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) UpgradePasswordHash(ctx context.Context, id string, oldHash string, newHash string) error {
+	ret := _mock.Called(ctx, id, oldHash, newHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpgradePasswordHash")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, id, oldHash, newHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_UpgradePasswordHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpgradePasswordHash'
+type MockUserRepository_UpgradePasswordHash_Call struct {
+	*mock.Call
+}
+
+// UpgradePasswordHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - oldHash string
+//   - newHash string
+func (_e *MockUserRepository_Expecter) UpgradePasswordHash(ctx interface{}, id interface{}, oldHash interface{}, newHash interface{}) *MockUserRepository_UpgradePasswordHash_Call {
+	return &MockUserRepository_UpgradePasswordHash_Call{Call: _e.mock.On("UpgradePasswordHash", ctx, id, oldHash, newHash)}
+}
+
+func (_c *MockUserRepository_UpgradePasswordHash_Call) Run(run func(ctx context.Context, id string, oldHash string, newHash string)) *MockUserRepository_UpgradePasswordHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UpgradePasswordHash_Call) Return(err error) *MockUserRepository_UpgradePasswordHash_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_UpgradePasswordHash_Call) RunAndReturn(run func(ctx context.Context, id string, oldHash string, newHash string) error) *MockUserRepository_UpgradePasswordHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyEmail provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) VerifyEmail(ctx context.Context, id string, email string) (bool, error) {
+	ret := _mock.Called(ctx, id, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, id, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, id, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type MockUserRepository_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - email string
+func (_e *MockUserRepository_Expecter) VerifyEmail(ctx interface{}, id interface{}, email interface{}) *MockUserRepository_VerifyEmail_Call {
+	return &MockUserRepository_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", ctx, id, email)}
+}
+
+func (_c *MockUserRepository_VerifyEmail_Call) Run(run func(ctx context.Context, id string, email string)) *MockUserRepository_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_VerifyEmail_Call) Return(b bool, err error) *MockUserRepository_VerifyEmail_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUserRepository_VerifyEmail_Call) RunAndReturn(run func(ctx context.Context, id string, email string) (bool, error)) *MockUserRepository_VerifyEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
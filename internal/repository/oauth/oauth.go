@@ -0,0 +1,119 @@
+package oauthRepo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func (or *OAuthRepository) CreateClient(ctx context.Context, client queries.OauthClient) error {
+	rq := queries.New(or.pgxpool)
+	return rq.CreateOauthClient(ctx, queries.CreateOauthClientParams{
+		ID:           client.ID,
+		Name:         client.Name,
+		SecretHash:   client.SecretHash,
+		RedirectUris: client.RedirectUris,
+		GrantTypes:   client.GrantTypes,
+		Scopes:       client.Scopes,
+		FirstParty:   client.FirstParty,
+	})
+}
+
+func (or *OAuthRepository) GetClient(ctx context.Context, id string) (queries.OauthClient, error) {
+	rq := queries.New(or.pgxpool)
+	return rq.GetOauthClient(ctx, id)
+}
+
+func (or *OAuthRepository) GetClients(ctx context.Context) ([]queries.OauthClient, error) {
+	rq := queries.New(or.pgxpool)
+	return rq.GetOauthClients(ctx)
+}
+
+// DeleteClient - удалить клиента вместе с его кодами, согласиями и refresh токенами,
+// если клиента нет, возвращается pgx.ErrNoRows
+func (or *OAuthRepository) DeleteClient(ctx context.Context, id string) error {
+	rq := queries.New(or.pgxpool)
+	rows, err := rq.DeleteOauthClient(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (or *OAuthRepository) CreateCode(ctx context.Context, code queries.OauthAuthorizationCode) error {
+	rq := queries.New(or.pgxpool)
+	return rq.CreateOauthAuthorizationCode(ctx, queries.CreateOauthAuthorizationCodeParams{
+		ID:            code.ID,
+		ClientID:      code.ClientID,
+		UserID:        code.UserID,
+		RedirectUri:   code.RedirectUri,
+		Scopes:        code.Scopes,
+		CodeChallenge: code.CodeChallenge,
+		ExpiresAt:     code.ExpiresAt,
+	})
+}
+
+// ConsumeCode - получить и сразу удалить неистёкший код авторизации, повторно его использовать нельзя
+func (or *OAuthRepository) ConsumeCode(ctx context.Context, id string) (queries.OauthAuthorizationCode, error) {
+	rq := queries.New(or.pgxpool)
+	return rq.ConsumeOauthAuthorizationCode(ctx, id)
+}
+
+func (or *OAuthRepository) DeleteExpiredCodes(ctx context.Context) (int64, error) {
+	rq := queries.New(or.pgxpool)
+	return rq.DeleteExpiredOauthAuthorizationCodes(ctx)
+}
+
+func (or *OAuthRepository) GetConsent(ctx context.Context, userID, clientID string) (queries.OauthConsent, error) {
+	rq := queries.New(or.pgxpool)
+	return rq.GetOauthConsent(ctx, queries.GetOauthConsentParams{
+		UserID:   userID,
+		ClientID: clientID,
+	})
+}
+
+func (or *OAuthRepository) GetUserConsents(ctx context.Context, userID string) ([]queries.GetUserOauthConsentsRow, error) {
+	rq := queries.New(or.pgxpool)
+	return rq.GetUserOauthConsents(ctx, userID)
+}
+
+// SaveConsent - сохранить согласие, scope заменяют ранее выданные
+func (or *OAuthRepository) SaveConsent(ctx context.Context, consent queries.OauthConsent) error {
+	rq := queries.New(or.pgxpool)
+	return rq.SaveOauthConsent(ctx, queries.SaveOauthConsentParams{
+		UserID:   consent.UserID,
+		ClientID: consent.ClientID,
+		Scopes:   consent.Scopes,
+	})
+}
+
+// RevokeConsent - отозвать согласие и все refresh токены, выданные клиенту от имени пользователя,
+// если согласия нет, возвращается pgx.ErrNoRows
+func (or *OAuthRepository) RevokeConsent(ctx context.Context, userID, clientID string) error {
+	return utils.ExecInTx(ctx, or.pgxpool, func(tq *queries.Queries) error {
+		rows, err := tq.DeleteOauthConsent(ctx, queries.DeleteOauthConsentParams{
+			UserID:   userID,
+			ClientID: clientID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return tq.RevokeClientRefreshTokens(ctx, queries.RevokeClientRefreshTokensParams{
+			UserID:   userID,
+			ClientID: pgtype.Text{String: clientID, Valid: true},
+		})
+	})
+}
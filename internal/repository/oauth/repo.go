@@ -0,0 +1,13 @@
+package oauthRepo
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type OAuthRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func New(pgxpool *pgxpool.Pool) *OAuthRepository {
+	return &OAuthRepository{
+		pgxpool: pgxpool,
+	}
+}
@@ -0,0 +1,80 @@
+package passkeyRepo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+)
+
+func (pr *PasskeyRepository) CreateCredential(ctx context.Context, credential queries.WebauthnCredential) error {
+	rq := queries.New(pr.pgxpool)
+	return rq.CreateWebAuthnCredential(ctx, queries.CreateWebAuthnCredentialParams{
+		ID:              credential.ID,
+		UserID:          credential.UserID,
+		Name:            credential.Name,
+		CredentialID:    credential.CredentialID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Aaguid:          credential.Aaguid,
+		Transports:      credential.Transports,
+		SignCount:       credential.SignCount,
+		BackupEligible:  credential.BackupEligible,
+		BackupState:     credential.BackupState,
+	})
+}
+
+func (pr *PasskeyRepository) GetUserCredentials(ctx context.Context, userID string) ([]queries.WebauthnCredential, error) {
+	rq := queries.New(pr.pgxpool)
+	return rq.GetUserWebAuthnCredentials(ctx, userID)
+}
+
+func (pr *PasskeyRepository) GetCredential(ctx context.Context, credentialID []byte) (queries.WebauthnCredential, error) {
+	rq := queries.New(pr.pgxpool)
+	return rq.GetWebAuthnCredentialByCredentialID(ctx, credentialID)
+}
+
+func (pr *PasskeyRepository) UpdateCredentialUsage(ctx context.Context, id string, signCount int64, backupState bool) error {
+	rq := queries.New(pr.pgxpool)
+	return rq.UpdateWebAuthnCredentialUsage(ctx, queries.UpdateWebAuthnCredentialUsageParams{
+		ID:          id,
+		SignCount:   signCount,
+		BackupState: backupState,
+	})
+}
+
+// DeleteCredential - удалить ключ пользователя, чужой или несуществующий ключ даёт pgx.ErrNoRows
+func (pr *PasskeyRepository) DeleteCredential(ctx context.Context, id, userID string) error {
+	rq := queries.New(pr.pgxpool)
+
+	rows, err := rq.DeleteWebAuthnCredential(ctx, queries.DeleteWebAuthnCredentialParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (pr *PasskeyRepository) CreateSession(ctx context.Context, session queries.WebauthnSession) error {
+	rq := queries.New(pr.pgxpool)
+	return rq.CreateWebAuthnSession(ctx, queries.CreateWebAuthnSessionParams(session))
+}
+
+// ConsumeSession - получить и сразу удалить неистёкшую сессию церемонии, повторно её использовать нельзя
+func (pr *PasskeyRepository) ConsumeSession(ctx context.Context, id string) (queries.WebauthnSession, error) {
+	rq := queries.New(pr.pgxpool)
+	return rq.ConsumeWebAuthnSession(ctx, id)
+}
+
+func (pr *PasskeyRepository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	rq := queries.New(pr.pgxpool)
+	return rq.DeleteExpiredWebAuthnSessions(ctx)
+}
@@ -0,0 +1,13 @@
+package passkeyRepo
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type PasskeyRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func New(pgxpool *pgxpool.Pool) *PasskeyRepository {
+	return &PasskeyRepository{
+		pgxpool: pgxpool,
+	}
+}
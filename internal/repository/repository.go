@@ -2,12 +2,129 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user queries.User) error
 	GetUserByID(ctx context.Context, id string) (queries.User, error)
 	GetUserByEmail(ctx context.Context, email string) (queries.User, error)
+	VerifyEmail(ctx context.Context, id, email string) (bool, error)
+	UpgradePasswordHash(ctx context.Context, id, oldHash, newHash string) error
+	UpdateProfile(ctx context.Context, id string, update model.ProfileUpdate) (queries.User, error)
+	CreateEmailChange(ctx context.Context, change queries.EmailChange) error
+	ConfirmEmailChange(ctx context.Context, userID, newEmail string) error
+	RevertEmailChange(ctx context.Context, link queries.RevokedToken, email string, revokedAt, expiresAt time.Time) error
+	Delete(ctx context.Context, id string, revokedAt, expiresAt time.Time) error
+	Restore(ctx context.Context, link queries.RevokedToken, deletedAfter time.Time) error
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error)
+}
+
+type TokenRepository interface {
+	CreateSession(ctx context.Context, session queries.Session, token queries.RefreshToken) error
+	GetUserSessions(ctx context.Context, userID string) ([]queries.Session, error)
+	RevokeSession(ctx context.Context, id, userID string) error
+	TouchSession(ctx context.Context, id string, activeBefore time.Time) error
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (queries.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, oldID string, next queries.RefreshToken) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeAccessToken(ctx context.Context, jti, userID string, expiresAt time.Time) error
+	RevokeUserTokens(ctx context.Context, userID string, revokedAt, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti, userID, sessionID string, issuedAt time.Time) (bool, error)
+	CreatePasswordResetToken(ctx context.Context, token queries.PasswordResetToken) error
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (queries.PasswordResetToken, error)
+	ResetPassword(ctx context.Context, token queries.PasswordResetToken, passwordHash string, revokedAt, expiresAt time.Time) error
+	ChangePassword(ctx context.Context, userID, oldHash, newHash, keepSessionID string, revokeOtherSessions bool) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type AttemptRepository interface {
+	GetLockedUntil(ctx context.Context, subjects []string) (time.Time, error)
+	RegisterFailure(ctx context.Context, subject string, windowStart time.Time) (int32, error)
+	RegisterSend(ctx context.Context, subject string, windowStart time.Time) (int32, time.Time, error)
+	Lock(ctx context.Context, subject string, until time.Time) error
+	Reset(ctx context.Context, subject string) error
+	DeleteStale(ctx context.Context, before time.Time) (int64, error)
+}
+
+type RoleRepository interface {
+	GetUserRoles(ctx context.Context, userID string) ([]string, error)
+	GetPermissions(ctx context.Context, roles []string) ([]string, error)
+	AssignRole(ctx context.Context, userID, role string) error
+	RemoveRole(ctx context.Context, userID, role string) error
+	CountUsersWithRole(ctx context.Context, role string) (int64, error)
+}
+
+type TwoFactorRepository interface {
+	GetTOTP(ctx context.Context, userID string) (queries.UserTotp, error)
+	SaveTOTP(ctx context.Context, userID, secret string) error
+	ConfirmTOTP(ctx context.Context, userID string, step int64, recoveryCodes []queries.RecoveryCode) error
+	UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID string, recoveryCodes []queries.RecoveryCode) error
+	DeleteTOTP(ctx context.Context, userID string) error
+}
+
+type PasskeyRepository interface {
+	CreateCredential(ctx context.Context, credential queries.WebauthnCredential) error
+	GetUserCredentials(ctx context.Context, userID string) ([]queries.WebauthnCredential, error)
+	GetCredential(ctx context.Context, credentialID []byte) (queries.WebauthnCredential, error)
+	UpdateCredentialUsage(ctx context.Context, id string, signCount int64, backupState bool) error
+	DeleteCredential(ctx context.Context, id, userID string) error
+	CreateSession(ctx context.Context, session queries.WebauthnSession) error
+	ConsumeSession(ctx context.Context, id string) (queries.WebauthnSession, error)
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key queries.ApiKey) error
+	GetByHash(ctx context.Context, keyHash string) (queries.ApiKey, error)
+	GetUserKeys(ctx context.Context, userID string) ([]queries.ApiKey, error)
+	Revoke(ctx context.Context, id, userID string) error
+	Touch(ctx context.Context, id string, usedBefore time.Time) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type IdentityRepository interface {
+	GetIdentity(ctx context.Context, provider, subject string) (queries.UserIdentity, error)
+	GetUserIdentities(ctx context.Context, userID string) ([]queries.UserIdentity, error)
+	Link(ctx context.Context, identity queries.UserIdentity) error
+	CreateUser(ctx context.Context, user queries.User, identity queries.UserIdentity) error
+	Touch(ctx context.Context, provider, subject, email string) error
+	Unlink(ctx context.Context, userID, provider string) error
+	CreateState(ctx context.Context, state queries.OidcState) error
+	ConsumeState(ctx context.Context, id string) (queries.OidcState, error)
+	DeleteExpiredStates(ctx context.Context) (int64, error)
+}
+
+type OAuthRepository interface {
+	CreateClient(ctx context.Context, client queries.OauthClient) error
+	GetClient(ctx context.Context, id string) (queries.OauthClient, error)
+	GetClients(ctx context.Context) ([]queries.OauthClient, error)
+	DeleteClient(ctx context.Context, id string) error
+	CreateCode(ctx context.Context, code queries.OauthAuthorizationCode) error
+	ConsumeCode(ctx context.Context, id string) (queries.OauthAuthorizationCode, error)
+	DeleteExpiredCodes(ctx context.Context) (int64, error)
+	GetConsent(ctx context.Context, userID, clientID string) (queries.OauthConsent, error)
+	GetUserConsents(ctx context.Context, userID string) ([]queries.GetUserOauthConsentsRow, error)
+	SaveConsent(ctx context.Context, consent queries.OauthConsent) error
+	RevokeConsent(ctx context.Context, userID, clientID string) error
+}
+
+type ImpersonationRepository interface {
+	Record(ctx context.Context, event queries.ImpersonationEvent) error
+	GetEvents(ctx context.Context, userID string) ([]queries.ImpersonationEvent, error)
+}
+
+type ExportRepository interface {
+	Create(ctx context.Context, id, userID string) error
+	GetUserExports(ctx context.Context, userID string, limit int32) ([]queries.GetUserDataExportsRow, error)
+	Claim(ctx context.Context, staleBefore time.Time) (queries.ClaimDataExportRow, error)
+	Complete(ctx context.Context, id string, archive []byte, expiresAt time.Time) error
+	Fail(ctx context.Context, id, reason string, expiresAt time.Time) error
+	GetArchive(ctx context.Context, id, userID string) ([]byte, error)
+	DeleteExpired(ctx context.Context) (int64, error)
 }
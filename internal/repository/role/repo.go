@@ -0,0 +1,13 @@
+package roleRepo
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type RoleRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func New(pgxpool *pgxpool.Pool) *RoleRepository {
+	return &RoleRepository{
+		pgxpool: pgxpool,
+	}
+}
@@ -0,0 +1,96 @@
+package roleRepo
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// foreignKeyViolation - код ошибки postgres при нарушении внешнего ключа
+const foreignKeyViolation = "23503"
+
+func (rr *RoleRepository) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	rq := queries.New(rr.pgxpool)
+	return rq.GetUserRoles(ctx, userID)
+}
+
+// GetPermissions - получить объединение прав переданных ролей
+func (rr *RoleRepository) GetPermissions(ctx context.Context, roles []string) ([]string, error) {
+	rq := queries.New(rr.pgxpool)
+	return rq.GetRolesPermissions(ctx, roles)
+}
+
+func (rr *RoleRepository) AssignRole(ctx context.Context, userID, role string) error {
+	rq := queries.New(rr.pgxpool)
+
+	err := rq.AssignUserRole(ctx, queries.AssignUserRoleParams{
+		UserID: userID,
+		Role:   role,
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		if pgErr.ConstraintName == "user_roles_role_fkey" {
+			return utils.ErrUnknownRole
+		}
+		return pgx.ErrNoRows
+	}
+
+	return err
+}
+
+// RemoveRole - снять роль с пользователя, последнего администратора снять нельзя (utils.ErrLastAdmin)
+func (rr *RoleRepository) RemoveRole(ctx context.Context, userID, role string) error {
+	return utils.ExecInTx(ctx, rr.pgxpool, func(tq *queries.Queries) error {
+		if role == model.RoleAdmin {
+			if err := EnsureNotLastAdmin(ctx, tq, userID); err != nil {
+				return err
+			}
+		}
+
+		return tq.RemoveUserRole(ctx, queries.RemoveUserRoleParams{
+			UserID: userID,
+			Role:   role,
+		})
+	})
+}
+
+// EnsureNotLastAdmin - вернуть utils.ErrLastAdmin, если userID - последний администратор.
+// Вызывается в транзакции tq перед снятием роли или удалением пользователя: блокировка держится до конца транзакции,
+// поэтому параллельные проверки выполняются по очереди и видят результат друг друга
+func EnsureNotLastAdmin(ctx context.Context, tq *queries.Queries, userID string) error {
+	if err := tq.LockAdminRole(ctx); err != nil {
+		return err
+	}
+
+	roles, err := tq.GetUserRoles(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(roles, model.RoleAdmin) {
+		return nil
+	}
+
+	admins, err := tq.CountUsersWithRole(ctx, model.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if admins <= 1 {
+		return utils.ErrLastAdmin
+	}
+	return nil
+}
+
+func (rr *RoleRepository) CountUsersWithRole(ctx context.Context, role string) (int64, error) {
+	rq := queries.New(rr.pgxpool)
+	return rq.CountUsersWithRole(ctx, role)
+}
@@ -0,0 +1,119 @@
+package tokenRepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func (tr *TokenRepository) CreatePasswordResetToken(ctx context.Context, token queries.PasswordResetToken) error {
+	rq := queries.New(tr.pgxpool)
+	return rq.CreatePasswordResetToken(ctx, queries.CreatePasswordResetTokenParams{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	})
+}
+
+func (tr *TokenRepository) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (queries.PasswordResetToken, error) {
+	rq := queries.New(tr.pgxpool)
+	return rq.GetPasswordResetTokenByHash(ctx, tokenHash)
+}
+
+// ResetPassword - атомарно погасить токен сброса, сменить хеш пароля и отозвать все токены, сессии и API ключи пользователя.
+// Остальные неиспользованные токены сброса этого пользователя тоже гасятся.
+func (tr *TokenRepository) ResetPassword(ctx context.Context, token queries.PasswordResetToken, passwordHash string, revokedAt, expiresAt time.Time) error {
+	return utils.ExecInTx(ctx, tr.pgxpool, func(tq *queries.Queries) error {
+		rows, err := tq.UsePasswordResetToken(ctx, token.ID)
+		if err != nil {
+			return err
+		}
+
+		// token was used by a concurrent request or has just expired
+		if rows == 0 {
+			return utils.ErrInvalidToken
+		}
+
+		if err = tq.UpdateUserPassword(ctx, queries.UpdateUserPasswordParams{
+			ID:           token.UserID,
+			PasswordHash: passwordHash,
+		}); err != nil {
+			return err
+		}
+
+		if err = tq.InvalidateUserPasswordResetTokens(ctx, token.UserID); err != nil {
+			return err
+		}
+
+		if err = tq.RevokeUserAccessTokens(ctx, queries.RevokeUserAccessTokensParams{
+			UserID:    token.UserID,
+			RevokedAt: pgtype.Timestamptz{Time: revokedAt, Valid: true},
+			ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		}); err != nil {
+			return err
+		}
+
+		if err = tq.RevokeUserApiKeys(ctx, token.UserID); err != nil {
+			return err
+		}
+
+		if err = tq.RevokeUserSessions(ctx, token.UserID); err != nil {
+			return err
+		}
+
+		return tq.RevokeUserRefreshTokens(ctx, token.UserID)
+	})
+}
+
+// ChangePassword - атомарно заменить хеш пароля, если его не сменили параллельно, и погасить токены сброса.
+// С revokeOtherSessions отзываются все сессии пользователя, кроме keepSessionID, вместе с их refresh токенами,
+// и все API ключи. Токен имперсонации привязан к сессии выдавшего его администратора и отзывается вместе с ней.
+func (tr *TokenRepository) ChangePassword(ctx context.Context, userID, oldHash, newHash, keepSessionID string, revokeOtherSessions bool) error {
+	return utils.ExecInTx(ctx, tr.pgxpool, func(tq *queries.Queries) error {
+		rows, err := tq.UpgradeUserPasswordHash(ctx, queries.UpgradeUserPasswordHashParams{
+			NewHash: newHash,
+			ID:      userID,
+			OldHash: oldHash,
+		})
+		if err != nil {
+			return err
+		}
+
+		// the password was changed by a concurrent request, the checked one is not current anymore
+		if rows == 0 {
+			return utils.ErrInvalidPassword
+		}
+
+		// a reset link sent for the old password must not override the new one
+		if err = tq.InvalidateUserPasswordResetTokens(ctx, userID); err != nil {
+			return err
+		}
+
+		if !revokeOtherSessions {
+			return nil
+		}
+
+		// a key created by whoever knew the old password outlives any session
+		if err = tq.RevokeUserApiKeys(ctx, userID); err != nil {
+			return err
+		}
+
+		// access tokens of revoked sessions are rejected by the session check
+		if err = tq.RevokeOtherUserSessions(ctx, queries.RevokeOtherUserSessionsParams{
+			UserID: userID,
+			KeepID: keepSessionID,
+		}); err != nil {
+			return err
+		}
+
+		return tq.RevokeOtherUserRefreshTokens(ctx, queries.RevokeOtherUserRefreshTokensParams{
+			UserID:       userID,
+			KeepFamilyID: keepSessionID,
+		})
+	})
+}
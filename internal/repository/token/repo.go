@@ -0,0 +1,13 @@
+package tokenRepo
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type TokenRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func New(pgxpool *pgxpool.Pool) *TokenRepository {
+	return &TokenRepository{
+		pgxpool: pgxpool,
+	}
+}
@@ -0,0 +1,73 @@
+package tokenRepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// CreateSession - сохранить сессию нового входа вместе с первым refresh токеном её семейства.
+// Удалённый пользователь даёт utils.ErrInvalidUser, какой бы способ входа его ни пропустил
+func (tr *TokenRepository) CreateSession(ctx context.Context, session queries.Session, token queries.RefreshToken) error {
+	return utils.ExecInTx(ctx, tr.pgxpool, func(tq *queries.Queries) error {
+		if _, err := tq.GetUserByID(ctx, session.UserID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return utils.ErrInvalidUser
+			}
+			return err
+		}
+
+		if err := tq.CreateSession(ctx, queries.CreateSessionParams{
+			ID:        session.ID,
+			UserID:    session.UserID,
+			ClientID:  session.ClientID,
+			UserAgent: session.UserAgent,
+			Ip:        session.Ip,
+		}); err != nil {
+			return err
+		}
+
+		return tq.CreateRefreshToken(ctx, createParams(token))
+	})
+}
+
+// GetUserSessions - активные сессии пользователя: не отозванные и с действующим refresh токеном
+func (tr *TokenRepository) GetUserSessions(ctx context.Context, userID string) ([]queries.Session, error) {
+	rq := queries.New(tr.pgxpool)
+	return rq.GetUserSessions(ctx, userID)
+}
+
+// RevokeSession - отозвать сессию пользователя вместе с её refresh токенами,
+// чужая, несуществующая или уже отозванная сессия даёт pgx.ErrNoRows
+func (tr *TokenRepository) RevokeSession(ctx context.Context, id, userID string) error {
+	return utils.ExecInTx(ctx, tr.pgxpool, func(tq *queries.Queries) error {
+		rows, err := tq.RevokeSession(ctx, queries.RevokeSessionParams{
+			ID:     id,
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return tq.RevokeRefreshTokenFamily(ctx, id)
+	})
+}
+
+// TouchSession - обновить last_active_at, если он старше activeBefore, чтобы не писать в БД на каждый запрос
+func (tr *TokenRepository) TouchSession(ctx context.Context, id string, activeBefore time.Time) error {
+	rq := queries.New(tr.pgxpool)
+	return rq.TouchSession(ctx, queries.TouchSessionParams{
+		ID:           id,
+		ActiveBefore: pgtype.Timestamptz{Time: activeBefore, Valid: true},
+	})
+}
@@ -0,0 +1,135 @@
+package tokenRepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func (tr *TokenRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (queries.RefreshToken, error) {
+	rq := queries.New(tr.pgxpool)
+	return rq.GetRefreshTokenByHash(ctx, tokenHash)
+}
+
+// RotateRefreshToken - атомарно отозвать старый refresh токен, сохранить следующий в семействе
+// и отметить активность сессии этого семейства
+func (tr *TokenRepository) RotateRefreshToken(ctx context.Context, oldID string, next queries.RefreshToken) error {
+	return utils.ExecInTx(ctx, tr.pgxpool, func(tq *queries.Queries) error {
+		rows, err := tq.RevokeRefreshToken(ctx, queries.RevokeRefreshTokenParams{
+			ID:         oldID,
+			ReplacedBy: pgtype.Text{String: next.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		// token was already rotated by a concurrent request
+		if rows == 0 {
+			return utils.ErrRefreshTokenReused
+		}
+
+		if err = tq.CreateRefreshToken(ctx, createParams(next)); err != nil {
+			return err
+		}
+
+		return tq.TouchSession(ctx, queries.TouchSessionParams{
+			ID:           next.FamilyID,
+			ActiveBefore: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		})
+	})
+}
+
+func (tr *TokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	rq := queries.New(tr.pgxpool)
+	return rq.RevokeRefreshTokenFamily(ctx, familyID)
+}
+
+func createParams(token queries.RefreshToken) queries.CreateRefreshTokenParams {
+	return queries.CreateRefreshTokenParams{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		FamilyID:  token.FamilyID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		ClientID:  token.ClientID,
+		Scopes:    token.Scopes,
+	}
+}
+
+// RevokeAccessToken - отозвать токен по jti. Уже отозванный токен даёт utils.ErrInvalidToken, поэтому из параллельных
+// запросов с одноразовым токеном отзыв удаётся только одному
+func (tr *TokenRepository) RevokeAccessToken(ctx context.Context, jti, userID string, expiresAt time.Time) error {
+	rq := queries.New(tr.pgxpool)
+	rows, err := rq.RevokeAccessToken(ctx, queries.RevokeAccessTokenParams{
+		Jti:       jti,
+		UserID:    userID,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return utils.ErrInvalidToken
+	}
+
+	return nil
+}
+
+// RevokeUserTokens - отозвать все access токены пользователя, выданные до revokedAt, и все его refresh токены
+func (tr *TokenRepository) RevokeUserTokens(ctx context.Context, userID string, revokedAt, expiresAt time.Time) error {
+	return utils.ExecInTx(ctx, tr.pgxpool, func(tq *queries.Queries) error {
+		if err := tq.RevokeUserAccessTokens(ctx, queries.RevokeUserAccessTokensParams{
+			UserID:    userID,
+			RevokedAt: pgtype.Timestamptz{Time: revokedAt, Valid: true},
+			ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		}); err != nil {
+			return err
+		}
+
+		return tq.RevokeUserRefreshTokens(ctx, userID)
+	})
+}
+
+// IsAccessTokenRevoked - отозван ли токен сам по себе, вместе со всеми токенами пользователя или вместе со своей сессией.
+// iat хранится с точностью до секунды, поэтому отзыв всех токенов задевает и токены, выданные в ту же секунду.
+// Токены сессии, начатой после отзыва, проверяются только по сессии: новый вход сразу после выхода на всех устройствах
+// не даёт уже отозванный токен
+func (tr *TokenRepository) IsAccessTokenRevoked(ctx context.Context, jti, userID, sessionID string, issuedAt time.Time) (bool, error) {
+	rq := queries.New(tr.pgxpool)
+	return rq.IsAccessTokenRevoked(ctx, queries.IsAccessTokenRevokedParams{
+		Jti:       jti,
+		UserID:    userID,
+		IssuedAt:  pgtype.Timestamptz{Time: issuedAt, Valid: true},
+		SessionID: sessionID,
+	})
+}
+
+// DeleteExpired - удалить записи об отзыве, refresh токены, токены сброса пароля и смены почты, срок действия которых истёк,
+// и сессии, у которых не осталось refresh токенов
+func (tr *TokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	var total int64
+	err := utils.ExecInTx(ctx, tr.pgxpool, func(tq *queries.Queries) error {
+		for _, purge := range []func(context.Context) (int64, error){
+			tq.DeleteExpiredRevokedTokens,
+			tq.DeleteExpiredUserTokenRevocations,
+			tq.DeleteExpiredRefreshTokens,
+			// a revoked session is kept while its refresh tokens are, which outlives its access tokens
+			tq.DeleteStaleSessions,
+			tq.DeleteExpiredPasswordResetTokens,
+			tq.DeleteExpiredEmailChanges,
+		} {
+			rows, err := purge(ctx)
+			if err != nil {
+				return err
+			}
+			total += rows
+		}
+		return nil
+	})
+	return total, err
+}
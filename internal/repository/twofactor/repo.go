@@ -0,0 +1,13 @@
+package twoFactorRepo
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+type TwoFactorRepository struct {
+	pgxpool *pgxpool.Pool
+}
+
+func New(pgxpool *pgxpool.Pool) *TwoFactorRepository {
+	return &TwoFactorRepository{
+		pgxpool: pgxpool,
+	}
+}
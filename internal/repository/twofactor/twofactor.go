@@ -0,0 +1,116 @@
+package twoFactorRepo
+
+import (
+	"context"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func (tr *TwoFactorRepository) GetTOTP(ctx context.Context, userID string) (queries.UserTotp, error) {
+	rq := queries.New(tr.pgxpool)
+	return rq.GetUserTOTP(ctx, userID)
+}
+
+// SaveTOTP - сохранить новый неподтверждённый секрет, подтверждённый секрет не перезаписывается
+func (tr *TwoFactorRepository) SaveTOTP(ctx context.Context, userID, secret string) error {
+	rq := queries.New(tr.pgxpool)
+
+	rows, err := rq.UpsertUserTOTP(ctx, queries.UpsertUserTOTPParams{
+		UserID: userID,
+		Secret: secret,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return utils.ErrTwoFactorEnabled
+	}
+
+	return nil
+}
+
+// ConfirmTOTP - включить 2FA и выдать новый набор кодов восстановления
+func (tr *TwoFactorRepository) ConfirmTOTP(ctx context.Context, userID string, step int64, recoveryCodes []queries.RecoveryCode) error {
+	return utils.ExecInTx(ctx, tr.pgxpool, func(tq *queries.Queries) error {
+		rows, err := tq.ConfirmUserTOTP(ctx, queries.ConfirmUserTOTPParams{
+			UserID:       userID,
+			LastUsedStep: step,
+		})
+		if err != nil {
+			return err
+		}
+
+		// confirmed by a concurrent request
+		if rows == 0 {
+			return utils.ErrTwoFactorEnabled
+		}
+
+		return replaceRecoveryCodes(ctx, tq, userID, recoveryCodes)
+	})
+}
+
+// UseTOTPStep - отметить временной шаг использованным, false если код этого шага уже предъявлялся
+func (tr *TwoFactorRepository) UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	rq := queries.New(tr.pgxpool)
+
+	rows, err := rq.UseUserTOTPStep(ctx, queries.UseUserTOTPStepParams{
+		UserID:       userID,
+		LastUsedStep: step,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// UseRecoveryCode - погасить код восстановления, false если код не найден или уже использован
+func (tr *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	rq := queries.New(tr.pgxpool)
+
+	rows, err := rq.UseRecoveryCode(ctx, queries.UseRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (tr *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, recoveryCodes []queries.RecoveryCode) error {
+	return utils.ExecInTx(ctx, tr.pgxpool, func(tq *queries.Queries) error {
+		return replaceRecoveryCodes(ctx, tq, userID, recoveryCodes)
+	})
+}
+
+// DeleteTOTP - выключить 2FA вместе с оставшимися кодами восстановления
+func (tr *TwoFactorRepository) DeleteTOTP(ctx context.Context, userID string) error {
+	return utils.ExecInTx(ctx, tr.pgxpool, func(tq *queries.Queries) error {
+		if err := tq.DeleteRecoveryCodes(ctx, userID); err != nil {
+			return err
+		}
+		return tq.DeleteUserTOTP(ctx, userID)
+	})
+}
+
+func replaceRecoveryCodes(ctx context.Context, tq *queries.Queries, userID string, recoveryCodes []queries.RecoveryCode) error {
+	if err := tq.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+
+	for _, code := range recoveryCodes {
+		if err := tq.CreateRecoveryCode(ctx, queries.CreateRecoveryCodeParams{
+			ID:       code.ID,
+			UserID:   userID,
+			CodeHash: code.CodeHash,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
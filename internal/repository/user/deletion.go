@@ -0,0 +1,100 @@
+package userRepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	roleRepo "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/role"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// Delete - пометить пользователя удалённым и отозвать все его токены, ссылки сброса пароля, заявку на смену почты
+// и готовые архивы персональных данных.
+// Строки пользователя остаются до PurgeDeleted, уже удалённый пользователь даёт pgx.ErrNoRows,
+// последний администратор - utils.ErrLastAdmin
+func (ur *UserRepository) Delete(ctx context.Context, id string, revokedAt, expiresAt time.Time) error {
+	return utils.ExecInTx(ctx, ur.pgxpool, func(tq *queries.Queries) error {
+		if err := roleRepo.EnsureNotLastAdmin(ctx, tq, id); err != nil {
+			return err
+		}
+
+		rows, err := tq.SoftDeleteUser(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return pgx.ErrNoRows
+		}
+
+		if err = tq.DeleteEmailChange(ctx, id); err != nil {
+			return err
+		}
+
+		if err = tq.DeleteUserDataExports(ctx, id); err != nil {
+			return err
+		}
+
+		if err = tq.InvalidateUserPasswordResetTokens(ctx, id); err != nil {
+			return err
+		}
+
+		if err = tq.RevokeUserAccessTokens(ctx, queries.RevokeUserAccessTokensParams{
+			UserID:    id,
+			RevokedAt: pgtype.Timestamptz{Time: revokedAt, Valid: true},
+			ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		}); err != nil {
+			return err
+		}
+
+		return tq.RevokeUserRefreshTokens(ctx, id)
+	})
+}
+
+// Restore - по ссылке link снять пометку удаления, если пользователь удалён после deletedAfter.
+// Ссылка отзывается в той же транзакции: использованная ссылка даёт utils.ErrInvalidToken,
+// не удалённый или удалённый раньше пользователь - pgx.ErrNoRows, и тогда ссылка остаётся действительной
+func (ur *UserRepository) Restore(ctx context.Context, link queries.RevokedToken, deletedAfter time.Time) error {
+	return utils.ExecInTx(ctx, ur.pgxpool, func(tq *queries.Queries) error {
+		if err := consumeLink(ctx, tq, link); err != nil {
+			return err
+		}
+
+		rows, err := tq.RestoreUser(ctx, queries.RestoreUserParams{
+			ID:           link.UserID,
+			DeletedAfter: pgtype.Timestamptz{Time: deletedAfter, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return pgx.ErrNoRows
+		}
+		return nil
+	})
+}
+
+// PurgeDeleted - окончательно удалить пользователей, удалённых до deletedBefore, и вернуть их ID.
+// Связанные строки удаляются каскадно, отзывы токенов без внешнего ключа - здесь же. Журнал имперсонации остаётся как аудит
+func (ur *UserRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	var ids []string
+	err := utils.ExecInTx(ctx, ur.pgxpool, func(tq *queries.Queries) error {
+		var err error
+		ids, err = tq.PurgeDeletedUsers(ctx, pgtype.Timestamptz{Time: deletedBefore, Valid: true})
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		if err = tq.DeleteUsersRevokedTokens(ctx, ids); err != nil {
+			return err
+		}
+
+		return tq.DeleteUsersTokenRevocations(ctx, ids)
+	})
+	return ids, err
+}
@@ -0,0 +1,149 @@
+package userRepo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// CreateEmailChange - зарезервировать новую почту пользователя до подтверждения, прошлая заявка заменяется.
+// Почта, занятая другим пользователем или его заявкой, даёт utils.ErrEmailAlreadySignup
+func (ur *UserRepository) CreateEmailChange(ctx context.Context, change queries.EmailChange) error {
+	return utils.ExecInTx(ctx, ur.pgxpool, func(tq *queries.Queries) error {
+		if err := checkEmailFree(ctx, tq, change.NewEmail, change.UserID); err != nil {
+			return err
+		}
+
+		return tq.UpsertEmailChange(ctx, queries.UpsertEmailChangeParams{
+			UserID:    change.UserID,
+			NewEmail:  change.NewEmail,
+			ExpiresAt: change.ExpiresAt,
+		})
+	})
+}
+
+// ConfirmEmailChange - заменить почту пользователя на подтверждённую новую.
+// Отменённая, истёкшая или заменённая другой заявка даёт utils.ErrInvalidToken
+func (ur *UserRepository) ConfirmEmailChange(ctx context.Context, userID, newEmail string) error {
+	return utils.ExecInTx(ctx, ur.pgxpool, func(tq *queries.Queries) error {
+		change, err := tq.ConsumeEmailChange(ctx, queries.ConsumeEmailChangeParams{
+			UserID:   userID,
+			NewEmail: strings.ToLower(newEmail),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return utils.ErrInvalidToken
+			}
+			return err
+		}
+
+		if err = checkEmailFree(ctx, tq, change.NewEmail, userID); err != nil {
+			return err
+		}
+
+		return tq.UpdateUserEmail(ctx, queries.UpdateUserEmailParams{
+			ID:    userID,
+			Email: change.NewEmail,
+		})
+	})
+}
+
+// RevertEmailChange - по ссылке link отменить заявку и вернуть её пользователю прежнюю почту, если её не занял другой пользователь.
+// Ссылка отзывается в той же транзакции: использованная ссылка даёт utils.ErrInvalidToken, а неудачная отмена ссылку не тратит.
+// Почта могла смениться без ведома владельца, поэтому все токены и API ключи пользователя и ссылки сброса пароля отзываются
+func (ur *UserRepository) RevertEmailChange(ctx context.Context, link queries.RevokedToken, email string, revokedAt, expiresAt time.Time) error {
+	userID := link.UserID
+	return utils.ExecInTx(ctx, ur.pgxpool, func(tq *queries.Queries) error {
+		if err := consumeLink(ctx, tq, link); err != nil {
+			return err
+		}
+
+		if err := tq.DeleteEmailChange(ctx, userID); err != nil {
+			return err
+		}
+
+		user, err := tq.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(user.Email, email) {
+			if err = checkEmailFree(ctx, tq, email, userID); err != nil {
+				return err
+			}
+
+			if err = tq.UpdateUserEmail(ctx, queries.UpdateUserEmailParams{
+				ID:    userID,
+				Email: email,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err = tq.InvalidateUserPasswordResetTokens(ctx, userID); err != nil {
+			return err
+		}
+
+		if err = tq.RevokeUserAccessTokens(ctx, queries.RevokeUserAccessTokensParams{
+			UserID:    userID,
+			RevokedAt: pgtype.Timestamptz{Time: revokedAt, Valid: true},
+			ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		}); err != nil {
+			return err
+		}
+
+		if err = tq.RevokeUserApiKeys(ctx, userID); err != nil {
+			return err
+		}
+
+		return tq.RevokeUserRefreshTokens(ctx, userID)
+	})
+}
+
+// checkEmailFree - почта не принадлежит другому пользователю и не ждёт подтверждения в чужой заявке.
+// Сравнение без учёта регистра, как в GetUserByEmail. Почта блокируется до конца транзакции tq,
+// так что параллельные регистрации и смены почты на тот же адрес проверяются по очереди
+func checkEmailFree(ctx context.Context, tq *queries.Queries, email, userID string) error {
+	email = strings.ToLower(email)
+	if err := tq.LockEmail(ctx, email); err != nil {
+		return err
+	}
+
+	taken, err := tq.IsEmailTaken(ctx, queries.IsEmailTakenParams{
+		Email:  email,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if taken {
+		return utils.ErrEmailAlreadySignup
+	}
+	return nil
+}
+
+// consumeLink - отозвать одноразовую ссылку из письма, уже использованная ссылка даёт utils.ErrInvalidToken
+func consumeLink(ctx context.Context, tq *queries.Queries, link queries.RevokedToken) error {
+	rows, err := tq.RevokeAccessToken(ctx, queries.RevokeAccessTokenParams{
+		Jti:       link.Jti,
+		UserID:    link.UserID,
+		ExpiresAt: link.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return utils.ErrInvalidToken
+	}
+
+	return nil
+}
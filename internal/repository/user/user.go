@@ -4,19 +4,32 @@ import (
 	"context"
 	"strings"
 
+	"github.com/jackc/pgx/v5/pgtype"
+
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
 	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
 )
 
 func (ur *UserRepository) Create(ctx context.Context, user queries.User) error {
-	rq := queries.New(ur.pgxpool)
+	if err := utils.ExecInTx(ctx, ur.pgxpool, func(tq *queries.Queries) error {
+		// an address waiting for confirmation by another user is taken as well
+		if err := checkEmailFree(ctx, tq, user.Email, ""); err != nil {
+			return err
+		}
 
-	if _, err := rq.GetUserByEmail(ctx, strings.ToLower(user.Email)); err == nil {
-		return utils.ErrEmailAlreadySignup
-	}
+		if err := tq.CreateUser(ctx, queries.CreateUserParams{
+			ID:           user.ID,
+			Email:        user.Email,
+			PasswordHash: user.PasswordHash,
+		}); err != nil {
+			return err
+		}
 
-	if err := utils.ExecInTx(ctx, ur.pgxpool, func(tq *queries.Queries) error {
-		return tq.CreateUser(ctx, queries.CreateUserParams(user))
+		return tq.AssignUserRole(ctx, queries.AssignUserRoleParams{
+			UserID: user.ID,
+			Role:   model.RoleUser,
+		})
 	}); err != nil {
 		return err
 	}
@@ -32,3 +45,47 @@ func (ur *UserRepository) GetUserByID(ctx context.Context, id string) (queries.U
 	rq := queries.New(ur.pgxpool)
 	return rq.GetUserByID(ctx, id)
 }
+
+// VerifyEmail - отметить почту подтверждённой, false если почта уже подтверждена или сменилась
+func (ur *UserRepository) VerifyEmail(ctx context.Context, id, email string) (bool, error) {
+	rq := queries.New(ur.pgxpool)
+	rows, err := rq.VerifyUserEmail(ctx, queries.VerifyUserEmailParams{
+		ID:    id,
+		Email: email,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// UpgradePasswordHash - заменить хеш пароля пересчитанным, если его не успели сменить параллельно
+func (ur *UserRepository) UpgradePasswordHash(ctx context.Context, id, oldHash, newHash string) error {
+	rq := queries.New(ur.pgxpool)
+	_, err := rq.UpgradeUserPasswordHash(ctx, queries.UpgradeUserPasswordHashParams{
+		NewHash: newHash,
+		ID:      id,
+		OldHash: oldHash,
+	})
+	return err
+}
+
+// UpdateProfile - сохранить переданные поля профиля одним запросом, nil поля остаются как были.
+// Вернуть обновлённую запись, удалённый или несуществующий пользователь даёт pgx.ErrNoRows
+func (ur *UserRepository) UpdateProfile(ctx context.Context, id string, update model.ProfileUpdate) (queries.User, error) {
+	rq := queries.New(ur.pgxpool)
+	return rq.UpdateUserProfile(ctx, queries.UpdateUserProfileParams{
+		DisplayName: optionalText(update.DisplayName),
+		Locale:      optionalText(update.Locale),
+		Timezone:    optionalText(update.Timezone),
+		ID:          id,
+	})
+}
+
+// optionalText - NULL для nil, чтобы COALESCE оставил значение столбца
+func optionalText(value *string) pgtype.Text {
+	if value == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *value, Valid: true}
+}
@@ -0,0 +1,57 @@
+package access
+
+import (
+	"context"
+	"slices"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// Authorize - проверить, что переданные роли вместе дают все требуемые права.
+// Роли берутся из токена, поэтому проверка не обращается к user_roles.
+func (s *Service) Authorize(ctx context.Context, roles []string, permissions ...string) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	if len(roles) == 0 {
+		return utils.ErrForbidden
+	}
+
+	granted, err := s.repository.GetPermissions(ctx, roles)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		if !slices.Contains(granted, permission) {
+			return utils.ErrForbidden
+		}
+	}
+
+	return nil
+}
+
+// CheckPermission - проверить права пользователя по его текущим ролям в БД,
+// для вызовов из сервисов и фоновых задач, где токена нет
+func (s *Service) CheckPermission(ctx context.Context, userID string, permissions ...string) error {
+	roles, err := s.repository.GetUserRoles(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.Authorize(ctx, roles, permissions...)
+}
+
+func (s *Service) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
+	return s.repository.GetUserRoles(ctx, userID)
+}
+
+func (s *Service) AssignRole(ctx context.Context, userID, role string) error {
+	return s.repository.AssignRole(ctx, userID, role)
+}
+
+// RemoveRole - снять роль с пользователя, последнего администратора снять нельзя
+func (s *Service) RemoveRole(ctx context.Context, userID, role string) error {
+	return s.repository.RemoveRole(ctx, userID, role)
+}
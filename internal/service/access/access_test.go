@@ -0,0 +1,233 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func newTestService(t *testing.T) (*Service, *repositoryMocks.MockRoleRepository, *repositoryMocks.MockUserRepository) {
+	t.Helper()
+	mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+	mockRepo := repositoryMocks.NewMockUserRepository(t)
+	logger := &infra.Logger{Zap: zap.NewNop(), SugaredLogger: zap.NewNop().Sugar()}
+
+	return NewService(mockRoleRepo, mockRepo, logger), mockRoleRepo, mockRepo
+}
+
+func TestAuthorize(t *testing.T) {
+	ctx := context.Background()
+	adminRoles := []string{model.RoleAdmin}
+
+	tests := []struct {
+		name          string
+		roles         []string
+		permissions   []string
+		mockSetup     func(*repositoryMocks.MockRoleRepository)
+		expectedError error
+	}{
+		{
+			name:        "all permissions granted",
+			roles:       adminRoles,
+			permissions: []string{model.PermissionUsersRead, model.PermissionRolesManage},
+			mockSetup: func(mockRoleRepo *repositoryMocks.MockRoleRepository) {
+				mockRoleRepo.On("GetPermissions", ctx, adminRoles).
+					Return([]string{model.PermissionRolesManage, model.PermissionUsersRead, model.PermissionUsersWrite}, nil).Once()
+			},
+		},
+		{
+			name:        "one permission missing",
+			roles:       []string{model.RoleUser},
+			permissions: []string{model.PermissionUsersRead},
+			mockSetup: func(mockRoleRepo *repositoryMocks.MockRoleRepository) {
+				mockRoleRepo.On("GetPermissions", ctx, []string{model.RoleUser}).
+					Return([]string(nil), nil).Once()
+			},
+			expectedError: utils.ErrForbidden,
+		},
+		{
+			name:          "no roles",
+			roles:         nil,
+			permissions:   []string{model.PermissionUsersRead},
+			mockSetup:     func(_ *repositoryMocks.MockRoleRepository) {},
+			expectedError: utils.ErrForbidden,
+		},
+		{
+			name:        "nothing required",
+			roles:       nil,
+			permissions: nil,
+			mockSetup:   func(_ *repositoryMocks.MockRoleRepository) {},
+		},
+		{
+			name:        "database error",
+			roles:       adminRoles,
+			permissions: []string{model.PermissionUsersRead},
+			mockSetup: func(mockRoleRepo *repositoryMocks.MockRoleRepository) {
+				mockRoleRepo.On("GetPermissions", ctx, adminRoles).
+					Return([]string(nil), errors.New("database connection error")).Once()
+			},
+			expectedError: errors.New("database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRoleRepo, _ := newTestService(t)
+			tt.mockSetup(mockRoleRepo)
+
+			err := service.Authorize(ctx, tt.roles, tt.permissions...)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckPermission(t *testing.T) {
+	ctx := context.Background()
+	service, mockRoleRepo, _ := newTestService(t)
+	userID := "test-user-123"
+
+	mockRoleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleUser}, nil).Once()
+	mockRoleRepo.On("GetPermissions", ctx, []string{model.RoleUser}).Return([]string(nil), nil).Once()
+
+	err := service.CheckPermission(ctx, userID, model.PermissionUsersWrite)
+	require.ErrorIs(t, err, utils.ErrForbidden)
+}
+
+func TestRemoveRole(t *testing.T) {
+	ctx := context.Background()
+	userID := "test-user-123"
+
+	tests := []struct {
+		name          string
+		role          string
+		mockSetup     func(*repositoryMocks.MockRoleRepository)
+		expectedError error
+	}{
+		{
+			name: "regular role",
+			role: model.RoleUser,
+			mockSetup: func(mockRoleRepo *repositoryMocks.MockRoleRepository) {
+				mockRoleRepo.On("RemoveRole", ctx, userID, model.RoleUser).Return(nil).Once()
+			},
+		},
+		{
+			name: "one of several admins",
+			role: model.RoleAdmin,
+			mockSetup: func(mockRoleRepo *repositoryMocks.MockRoleRepository) {
+				mockRoleRepo.On("RemoveRole", ctx, userID, model.RoleAdmin).Return(nil).Once()
+			},
+		},
+		{
+			name: "last admin",
+			role: model.RoleAdmin,
+			mockSetup: func(mockRoleRepo *repositoryMocks.MockRoleRepository) {
+				mockRoleRepo.On("RemoveRole", ctx, userID, model.RoleAdmin).Return(utils.ErrLastAdmin).Once()
+			},
+			expectedError: utils.ErrLastAdmin,
+		},
+		{
+			name: "admin role the user does not have",
+			role: model.RoleAdmin,
+			mockSetup: func(mockRoleRepo *repositoryMocks.MockRoleRepository) {
+				mockRoleRepo.On("RemoveRole", ctx, userID, model.RoleAdmin).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRoleRepo, _ := newTestService(t)
+			tt.mockSetup(mockRoleRepo)
+
+			err := service.RemoveRole(ctx, userID, tt.role)
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBootstrapAdmin(t *testing.T) {
+	ctx := context.Background()
+	email := "admin@example.com"
+	admin := queries.User{
+		ID:              "admin-123",
+		Email:           email,
+		EmailVerifiedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+
+	tests := []struct {
+		name      string
+		email     string
+		mockSetup func(*repositoryMocks.MockRoleRepository, *repositoryMocks.MockUserRepository)
+	}{
+		{
+			name:      "not configured",
+			email:     "",
+			mockSetup: func(_ *repositoryMocks.MockRoleRepository, _ *repositoryMocks.MockUserRepository) {},
+		},
+		{
+			name:  "admin assigned",
+			email: email,
+			mockSetup: func(mockRoleRepo *repositoryMocks.MockRoleRepository, mockRepo *repositoryMocks.MockUserRepository) {
+				mockRoleRepo.On("CountUsersWithRole", ctx, model.RoleAdmin).Return(int64(0), nil).Once()
+				mockRepo.On("GetUserByEmail", ctx, email).Return(admin, nil).Once()
+				mockRoleRepo.On("AssignRole", ctx, admin.ID, model.RoleAdmin).Return(nil).Once()
+			},
+		},
+		{
+			name:  "admin already exists",
+			email: email,
+			mockSetup: func(mockRoleRepo *repositoryMocks.MockRoleRepository, _ *repositoryMocks.MockUserRepository) {
+				mockRoleRepo.On("CountUsersWithRole", ctx, model.RoleAdmin).Return(int64(1), nil).Once()
+			},
+		},
+		{
+			name:  "user not registered yet",
+			email: email,
+			mockSetup: func(mockRoleRepo *repositoryMocks.MockRoleRepository, mockRepo *repositoryMocks.MockUserRepository) {
+				mockRoleRepo.On("CountUsersWithRole", ctx, model.RoleAdmin).Return(int64(0), nil).Once()
+				mockRepo.On("GetUserByEmail", ctx, email).Return(queries.User{}, pgx.ErrNoRows).Once()
+			},
+		},
+		{
+			name:  "email not verified",
+			email: email,
+			mockSetup: func(mockRoleRepo *repositoryMocks.MockRoleRepository, mockRepo *repositoryMocks.MockUserRepository) {
+				mockRoleRepo.On("CountUsersWithRole", ctx, model.RoleAdmin).Return(int64(0), nil).Once()
+				mockRepo.On("GetUserByEmail", ctx, email).Return(queries.User{ID: admin.ID, Email: email}, nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRoleRepo, mockRepo := newTestService(t)
+			tt.mockSetup(mockRoleRepo, mockRepo)
+
+			require.NoError(t, service.BootstrapAdmin(ctx, tt.email))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package access
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+// BootstrapAdmin - выдать роль администратора пользователю с указанной почтой,
+// если в системе ещё нет ни одного администратора. Почта должна быть подтверждена:
+// иначе роль получил бы тот, кто первым зарегистрировал чужой адрес
+func (s *Service) BootstrapAdmin(ctx context.Context, email string) error {
+	if email == "" {
+		return nil
+	}
+
+	admins, err := s.repository.CountUsersWithRole(ctx, model.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if admins > 0 {
+		return nil
+	}
+
+	user, err := s.userRepository.GetUserByEmail(ctx, email)
+	if err != nil {
+		// user is expected to register first, bootstrap is retried on the next start
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warnw("bootstrap admin is not registered yet", zap.String("email", email))
+			return nil
+		}
+		return err
+	}
+
+	if !user.EmailVerifiedAt.Valid {
+		s.logger.Warnw("bootstrap admin email is not verified yet", zap.String("user_id", user.ID))
+		return nil
+	}
+
+	if err = s.repository.AssignRole(ctx, user.ID, model.RoleAdmin); err != nil {
+		return err
+	}
+
+	s.logger.Infow("bootstrap admin assigned", zap.String("user_id", user.ID))
+	return nil
+}
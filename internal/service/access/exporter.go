@@ -0,0 +1,21 @@
+package access
+
+import "context"
+
+// Exporter - раздел "roles" выгрузки персональных данных: роли пользователя
+type Exporter struct {
+	service *Service
+}
+
+// NewExporter - выгрузить роли, назначенные пользователю
+func NewExporter(service *Service) *Exporter {
+	return &Exporter{service: service}
+}
+
+func (e *Exporter) Name() string {
+	return "roles"
+}
+
+func (e *Exporter) Export(ctx context.Context, userID string) (any, error) {
+	return e.service.GetUserRoles(ctx, userID)
+}
@@ -0,0 +1,21 @@
+package access
+
+import (
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/repository"
+)
+
+type Service struct {
+	repository     repository.RoleRepository
+	userRepository repository.UserRepository
+	logger         *infra.Logger
+}
+
+// NewService - создать новый экземпляр сервиса ролей и прав
+func NewService(roleRepository repository.RoleRepository, userRepository repository.UserRepository, logger *infra.Logger) *Service {
+	return &Service{
+		repository:     roleRepository,
+		userRepository: userRepository,
+		logger:         logger,
+	}
+}
@@ -0,0 +1,156 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const (
+	// KeyPrefix - префикс всех ключей, по нему ключ отличается от JWT и находится сканерами секретов
+	KeyPrefix = "wtk_"
+	// prefixLength - сколько символов ключа хранится открыто, чтобы пользователь узнал ключ в списке
+	prefixLength = len(KeyPrefix) + 8
+	// touchInterval - last_used_at обновляется не чаще, чтобы не писать в БД на каждый запрос
+	touchInterval = time.Minute
+)
+
+// IsAPIKey - похожа ли строка на API ключ, а не на JWT
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, KeyPrefix)
+}
+
+// Create - выпустить ключ. Секрет возвращается один раз, в БД хранится только его хеш.
+// Выдать ключу можно только scope, которые сейчас есть у ролей пользователя.
+func (s *Service) Create(ctx context.Context, userID, name string, scopes []string, expiresAt time.Time) (model.APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.APIKey{}, utils.ErrNameRequired
+	}
+
+	now := time.Now()
+	if !expiresAt.After(now) || expiresAt.After(now.Add(s.maxTTL)) {
+		return model.APIKey{}, utils.ErrInvalidExpiry
+	}
+
+	// NOT NULL column, nil would be stored as NULL
+	scopes = append([]string{}, slices.Compact(slices.Sorted(slices.Values(scopes)))...)
+	for _, scope := range scopes {
+		if !slices.Contains(model.Scopes, scope) {
+			return model.APIKey{}, utils.ErrUnknownScope
+		}
+	}
+
+	if err := s.accessService.CheckPermission(ctx, userID, scopes...); err != nil {
+		return model.APIKey{}, err
+	}
+
+	secret := KeyPrefix + rand.Text()
+	stored := queries.ApiKey{
+		ID:        ulid.Make().String(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    secret[:prefixLength],
+		KeyHash:   utils.HashToken(secret),
+		Scopes:    scopes,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	}
+	if err := s.repository.Create(ctx, stored); err != nil {
+		return model.APIKey{}, err
+	}
+
+	key := toAPIKey(stored)
+	key.Key = secret
+	return key, nil
+}
+
+func (s *Service) List(ctx context.Context, userID string) ([]model.APIKey, error) {
+	stored, err := s.repository.GetUserKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]model.APIKey, 0, len(stored))
+	for _, key := range stored {
+		keys = append(keys, toAPIKey(key))
+	}
+
+	return keys, nil
+}
+
+func (s *Service) Revoke(ctx context.Context, userID, id string) error {
+	return s.repository.Revoke(ctx, id, userID)
+}
+
+// Authenticate - проверить ключ и вернуть владельца с его текущими ролями и scope ключа
+func (s *Service) Authenticate(ctx context.Context, key string) (model.Principal, error) {
+	if !IsAPIKey(key) {
+		return model.Principal{}, utils.ErrInvalidToken
+	}
+
+	stored, err := s.repository.GetByHash(ctx, utils.HashToken(key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Principal{}, utils.ErrInvalidToken
+		}
+		return model.Principal{}, err
+	}
+
+	now := time.Now()
+	if stored.RevokedAt.Valid || !stored.ExpiresAt.Time.After(now) {
+		return model.Principal{}, utils.ErrInvalidToken
+	}
+
+	// roles are read on every request, so a demoted owner loses access immediately
+	roles, err := s.roleRepository.GetUserRoles(ctx, stored.UserID)
+	if err != nil {
+		return model.Principal{}, err
+	}
+
+	if err = s.repository.Touch(ctx, stored.ID, now.Add(-touchInterval)); err != nil {
+		return model.Principal{}, err
+	}
+
+	return model.Principal{
+		UserID:   stored.UserID,
+		Roles:    roles,
+		APIKeyID: stored.ID,
+		Scopes:   stored.Scopes,
+	}, nil
+}
+
+// PurgeExpired - удалить истёкшие и отозванные ключи
+func (s *Service) PurgeExpired(ctx context.Context) error {
+	_, err := s.repository.DeleteExpired(ctx)
+	return err
+}
+
+func toAPIKey(stored queries.ApiKey) model.APIKey {
+	key := model.APIKey{
+		ID:        stored.ID,
+		Name:      stored.Name,
+		Prefix:    stored.Prefix,
+		Scopes:    stored.Scopes,
+		ExpiresAt: stored.ExpiresAt.Time,
+		CreatedAt: stored.CreatedAt.Time,
+	}
+
+	if stored.LastUsedAt.Valid {
+		lastUsedAt := stored.LastUsedAt.Time
+		key.LastUsedAt = &lastUsedAt
+	}
+
+	return key
+}
@@ -0,0 +1,187 @@
+package apikey
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	serviceMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/service/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func newTestService(t *testing.T) (*Service, *repositoryMocks.MockAPIKeyRepository, *repositoryMocks.MockRoleRepository, *serviceMocks.MockAccessService) {
+	t.Helper()
+
+	apiKeyRepository := repositoryMocks.NewMockAPIKeyRepository(t)
+	roleRepository := repositoryMocks.NewMockRoleRepository(t)
+	accessService := serviceMocks.NewMockAccessService(t)
+
+	return &Service{
+		maxTTL:         24 * time.Hour,
+		repository:     apiKeyRepository,
+		roleRepository: roleRepository,
+		accessService:  accessService,
+	}, apiKeyRepository, roleRepository, accessService
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		keyName       string
+		scopes        []string
+		expiresAt     time.Time
+		checkScopes   []string
+		permissionErr error
+		expectedError error
+	}{
+		{name: "success", keyName: " ci ", scopes: []string{model.PermissionUsersWrite, model.PermissionUsersRead, model.PermissionUsersRead}, expiresAt: expiresAt, checkScopes: []string{model.PermissionUsersRead, model.PermissionUsersWrite}},
+		{name: "no scopes", keyName: "ci", expiresAt: expiresAt, checkScopes: []string{}},
+		{name: "empty name", keyName: "  ", expiresAt: expiresAt, expectedError: utils.ErrNameRequired},
+		{name: "already expired", keyName: "ci", expiresAt: time.Now().Add(-time.Minute), expectedError: utils.ErrInvalidExpiry},
+		{name: "expiry over limit", keyName: "ci", expiresAt: time.Now().Add(48 * time.Hour), expectedError: utils.ErrInvalidExpiry},
+		{name: "unknown scope", keyName: "ci", scopes: []string{"users:delete"}, expiresAt: expiresAt, expectedError: utils.ErrUnknownScope},
+		{name: "scope not held by owner", keyName: "ci", scopes: []string{model.PermissionRolesManage}, expiresAt: expiresAt, checkScopes: []string{model.PermissionRolesManage}, permissionErr: utils.ErrForbidden, expectedError: utils.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, apiKeyRepository, _, accessService := newTestService(t)
+
+			if tt.checkScopes != nil {
+				args := []any{ctx, "user-1"}
+				for _, scope := range tt.checkScopes {
+					args = append(args, scope)
+				}
+				accessService.On("CheckPermission", args...).Return(tt.permissionErr).Once()
+			}
+			if tt.expectedError == nil {
+				apiKeyRepository.On("Create", ctx, mock.MatchedBy(func(key queries.ApiKey) bool {
+					return key.UserID == "user-1" && key.Name == "ci" && strings.HasPrefix(key.Prefix, KeyPrefix) &&
+						assert.ObjectsAreEqual(tt.checkScopes, key.Scopes)
+				})).Return(nil).Once()
+			}
+
+			key, err := service.Create(ctx, "user-1", tt.keyName, tt.scopes, tt.expiresAt)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, IsAPIKey(key.Key))
+			assert.Equal(t, key.Key[:prefixLength], key.Prefix)
+			assert.Equal(t, tt.checkScopes, key.Scopes)
+			assert.Nil(t, key.LastUsedAt)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	const key = "wtk_Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E"
+
+	active := queries.ApiKey{
+		ID:        "key-1",
+		UserID:    "user-1",
+		Scopes:    []string{model.PermissionUsersRead},
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+	revoked := active
+	revoked.RevokedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	expired := active
+	expired.ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+
+	tests := []struct {
+		name          string
+		key           string
+		stored        queries.ApiKey
+		storedErr     error
+		expectedError error
+	}{
+		{name: "valid key", key: key, stored: active},
+		{name: "unknown key", key: key, storedErr: pgx.ErrNoRows, expectedError: utils.ErrInvalidToken},
+		{name: "revoked key", key: key, stored: revoked, expectedError: utils.ErrInvalidToken},
+		{name: "expired key", key: key, stored: expired, expectedError: utils.ErrInvalidToken},
+		{name: "not an api key", key: "eyJhbGciOiJIUzI1NiJ9", expectedError: utils.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, apiKeyRepository, roleRepository, _ := newTestService(t)
+
+			if IsAPIKey(tt.key) {
+				apiKeyRepository.On("GetByHash", ctx, utils.HashToken(tt.key)).Return(tt.stored, tt.storedErr).Once()
+			}
+			if tt.expectedError == nil {
+				roleRepository.On("GetUserRoles", ctx, "user-1").Return([]string{model.RoleUser}, nil).Once()
+				apiKeyRepository.On("Touch", ctx, "key-1", mock.MatchedBy(func(usedBefore time.Time) bool {
+					return (time.Since(usedBefore) - touchInterval).Abs() < time.Second
+				})).Return(nil).Once()
+			}
+
+			principal, err := service.Authenticate(ctx, tt.key)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, model.Principal{
+				UserID:   "user-1",
+				Roles:    []string{model.RoleUser},
+				APIKeyID: "key-1",
+				Scopes:   []string{model.PermissionUsersRead},
+			}, principal)
+			assert.True(t, principal.Allows(model.PermissionUsersRead))
+			assert.False(t, principal.Allows(model.PermissionUsersWrite))
+		})
+	}
+}
+
+func TestExporter(t *testing.T) {
+	ctx := context.Background()
+	service, apiKeyRepository, _, _ := newTestService(t)
+
+	now := time.Now()
+	apiKeyRepository.On("GetUserKeys", ctx, "user-1").Return([]queries.ApiKey{{
+		ID:        "key-1",
+		UserID:    "user-1",
+		Name:      "ci",
+		Prefix:    KeyPrefix + "abcd",
+		KeyHash:   "hash",
+		Scopes:    []string{model.PermissionUsersRead},
+		ExpiresAt: pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	}}, nil).Once()
+
+	data, err := NewExporter(service).Export(ctx, "user-1")
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	var keys []map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &keys))
+	require.Len(t, keys, 1)
+
+	// the archive format is public: snake_case keys, no secret, nothing unused
+	fields := make([]string, 0, len(keys[0]))
+	for field := range keys[0] {
+		fields = append(fields, field)
+	}
+	assert.ElementsMatch(t, []string{"id", "name", "prefix", "scopes", "expires_at", "created_at"}, fields)
+}
@@ -0,0 +1,43 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+// Exporter - раздел "api_keys" выгрузки персональных данных: API ключи пользователя без самих ключей
+type Exporter struct {
+	service *Service
+}
+
+// NewExporter - выгрузить API ключи: имя, префикс, scopes и сроки, сам ключ не хранится
+func NewExporter(service *Service) *Exporter {
+	return &Exporter{service: service}
+}
+
+func (e *Exporter) Name() string {
+	return "api_keys"
+}
+
+func (e *Exporter) Export(ctx context.Context, userID string) (any, error) {
+	keys, err := e.service.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ExportAPIKey, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, model.ExportAPIKey{
+			ID:         key.ID,
+			Name:       key.Name,
+			Prefix:     key.Prefix,
+			Scopes:     key.Scopes,
+			ExpiresAt:  key.ExpiresAt,
+			CreatedAt:  key.CreatedAt,
+			LastUsedAt: key.LastUsedAt,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,27 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/repository"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/access"
+)
+
+type Service struct {
+	maxTTL         time.Duration
+	repository     repository.APIKeyRepository
+	roleRepository repository.RoleRepository
+	accessService  service.AccessService
+}
+
+// NewService - создать новый экземпляр сервиса API ключей
+func NewService(cfg *infra.Config, apiKeyRepository repository.APIKeyRepository, roleRepository repository.RoleRepository, accessService *access.Service) *Service {
+	return &Service{
+		maxTTL:         cfg.APIKeyMaxTTL,
+		repository:     apiKeyRepository,
+		roleRepository: roleRepository,
+		accessService:  accessService,
+	}
+}
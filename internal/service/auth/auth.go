@@ -3,91 +3,296 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
-	"github.com/alexedwards/argon2id"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5"
 
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
 	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
 )
 
-func (s *Service) Login(ctx context.Context, email, password string) (string, error) {
-	user, err := s.repository.GetUserByEmail(ctx, email)
+// Login - войти по паролю, пароль проверяют провайдеры из AUTH_PROVIDERS. Если у пользователя включена 2FA,
+// вместо токенов возвращается challenge, который обменивается на токены через LoginTOTP.
+func (s *Service) Login(ctx context.Context, email, password, ip string) (model.LoginResult, error) {
+	limits := s.ipLimits(ip)
+
+	// known accounts are limited whichever provider checks the password,
+	// users provisioned by a directory on this login only by IP
+	known, err := s.repository.GetUserByEmail(ctx, email)
+	switch {
+	case err == nil:
+		limits = append(limits, s.accountLimit(known.ID))
+	case !errors.Is(err, pgx.ErrNoRows):
+		return model.LoginResult{}, err
+	}
+
+	// locked requests are rejected before the expensive password check
+	if err = s.checkLockout(ctx, limits...); err != nil {
+		return model.LoginResult{}, err
+	}
+
+	user, err := s.authenticate(ctx, email, password)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return "", utils.ErrInvalidUser
+		if errors.Is(err, utils.ErrInvalidUser) || errors.Is(err, utils.ErrInvalidPassword) {
+			return model.LoginResult{}, s.loginFailed(ctx, err, limits...)
 		}
-		return "", err
+		return model.LoginResult{}, err
 	}
 
-	err = s.VerifyPassword(user, password)
+	enabled, err := s.twoFactorEnabled(ctx, user.ID)
 	if err != nil {
-		return "", err
+		return model.LoginResult{}, err
+	}
+
+	if enabled {
+		challenge, err := s.generateChallenge(user.ID)
+		if err != nil {
+			return model.LoginResult{}, err
+		}
+		return model.LoginResult{ChallengeToken: challenge}, nil
+	}
+
+	// with 2FA the counter is reset only after the second factor
+	if err = s.attemptRepository.Reset(ctx, accountSubject(user.ID)); err != nil {
+		return model.LoginResult{}, err
 	}
 
-	token, err := s.GenerateToken(user.ID)
+	tokens, err := s.IssueTokens(ctx, user.ID)
 	if err != nil {
-		return "", err
+		return model.LoginResult{}, err
 	}
 
-	return token, nil
+	return model.LoginResult{Tokens: tokens}, nil
 }
 
-// VerifyToken - проверить токен на подлинность
-func (s *Service) VerifyToken(authHeader string) (string, error) {
-	tokenStr := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
-	if tokenStr == "" {
-		return "", utils.ErrInvalidToken
+// CompleteLogin - войти пользователем, которого уже подтвердил внешний провайдер.
+// Как и в Login, при включённой 2FA вместо токенов возвращается challenge.
+// Удалённый аккаунт хранит привязанные провайдеры до очистки, но войти в него нельзя: utils.ErrInvalidUser.
+func (s *Service) CompleteLogin(ctx context.Context, userID string) (model.LoginResult, error) {
+	if _, err := s.repository.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.LoginResult{}, utils.ErrInvalidUser
+		}
+		return model.LoginResult{}, err
 	}
 
-	token, err := jwt.Parse(tokenStr, func(_ *jwt.Token) (interface{}, error) {
-		return []byte(s.secret), nil
-	})
+	return s.completeLogin(ctx, userID)
+}
+
+// completeLogin - выдать токены или challenge пользователю, которого вызывающий уже загрузил
+func (s *Service) completeLogin(ctx context.Context, userID string) (model.LoginResult, error) {
+	enabled, err := s.twoFactorEnabled(ctx, userID)
+	if err != nil {
+		return model.LoginResult{}, err
+	}
+
+	if enabled {
+		challenge, err := s.generateChallenge(userID)
+		if err != nil {
+			return model.LoginResult{}, err
+		}
+		return model.LoginResult{ChallengeToken: challenge}, nil
+	}
+
+	tokens, err := s.IssueTokens(ctx, userID)
+	if err != nil {
+		return model.LoginResult{}, err
+	}
+
+	return model.LoginResult{Tokens: tokens}, nil
+}
+
+// VerifyToken - проверить токен на подлинность и что он не был отозван
+func (s *Service) VerifyToken(ctx context.Context, authHeader string) (string, error) {
+	principal, err := s.Authenticate(ctx, authHeader)
 	if err != nil {
 		return "", err
 	}
 
-	if !token.Valid || token.Method != jwt.SigningMethodHS256 {
-		return "", utils.ErrInvalidToken
+	return principal.UserID, nil
+}
+
+// Authenticate - проверить токен и вернуть пользователя вместе с ролями из токена
+func (s *Service) Authenticate(ctx context.Context, authHeader string) (model.Principal, error) {
+	tokenClaims, err := s.parseToken(authHeader)
+	if err != nil {
+		return model.Principal{}, err
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return "", utils.ErrInvalidToken
+	if err = s.checkRevoked(ctx, tokenClaims); err != nil {
+		return model.Principal{}, err
 	}
 
-	userID, ok := claims["sub"].(string)
-	if !ok {
-		return "", utils.ErrInvalidToken
+	if tokenClaims.Actor != nil {
+		if err = s.checkActor(ctx, tokenClaims); err != nil {
+			return model.Principal{}, err
+		}
 	}
 
-	return userID, nil
+	if tokenClaims.SessionID != "" {
+		if err = s.tokenRepository.TouchSession(ctx, tokenClaims.SessionID, time.Now().Add(-touchInterval)); err != nil {
+			return model.Principal{}, err
+		}
+	}
+
+	principal := model.Principal{
+		UserID:    tokenClaims.Subject,
+		Roles:     tokenClaims.Roles,
+		ClientID:  tokenClaims.ClientID,
+		SessionID: tokenClaims.SessionID,
+	}
+	if tokenClaims.Actor != nil {
+		principal.ActorID = tokenClaims.Actor.Subject
+	}
+	if tokenClaims.Scope != "" {
+		principal.Scopes = strings.Fields(tokenClaims.Scope)
+	}
+
+	// client credentials tokens are issued to the client itself, there is no user behind them
+	if tokenClaims.ClientID != "" && tokenClaims.Subject == tokenClaims.ClientID {
+		principal.UserID = ""
+	}
+
+	return principal, nil
 }
 
+// VerifyPassword - сверить пароль с хешем пользователя
 func (s *Service) VerifyPassword(user queries.User, password string) error {
-	valid, err := argon2id.ComparePasswordAndHash(password, user.PasswordHash)
+	_, err := s.verifyPassword(user, password)
+	return err
+}
+
+// verifyPassword - сверить пароль, needsRehash сообщает что хеш устарел
+func (s *Service) verifyPassword(user queries.User, password string) (needsRehash bool, err error) {
+	return s.passwordHasher.Verify(password, user.PasswordHash)
+}
+
+// rehashPassword - пересчитать устаревший хеш (старые параметры argon2id, bcrypt, PBKDF2) в текущий argon2id
+func (s *Service) rehashPassword(ctx context.Context, user queries.User, password string) error {
+	passwordHash, err := s.passwordHasher.Hash(password)
 	if err != nil {
 		return err
 	}
 
-	if !valid {
-		return utils.ErrInvalidPassword
+	return s.repository.UpgradePasswordHash(ctx, user.ID, user.PasswordHash, passwordHash)
+}
+
+// GenerateToken - создать новый JWT токен, роли попадают в claim roles
+func (s *Service) GenerateToken(userID string, roles ...string) (string, error) {
+	return s.signAccessToken(userID, roles, "", grant{})
+}
+
+// signAccessToken - подписать access токен, выданный напрямую или клиенту OAuth, в рамках сессии sessionID
+func (s *Service) signAccessToken(subject string, roles []string, sessionID string, g grant) (string, error) {
+	tokenClaims := newClaims(subject, s.expires)
+	tokenClaims.Roles = roles
+	tokenClaims.ClientID = g.clientID
+	tokenClaims.SessionID = sessionID
+
+	if g.scopes != nil {
+		// an empty scope claim would turn the token into an unrestricted one
+		if len(g.scopes) == 0 {
+			return "", errEmptyScope
+		}
+		tokenClaims.Scope = strings.Join(g.scopes, " ")
+	}
+
+	return s.keys.sign(tokenClaims)
+}
+
+// PublicKeys - публичные ключи для проверки токенов другими сервисами (JWKS)
+func (s *Service) PublicKeys() []model.JWK {
+	return s.keys.publicKeys()
+}
+
+// parseToken - проверить подпись и срок действия access токена без обращения к хранилищу отзывов
+func (s *Service) parseToken(authHeader string) (*claims, error) {
+	tokenClaims, err := s.parseClaims(strings.TrimPrefix(authHeader, "Bearer "))
+	if err != nil {
+		return nil, err
+	}
+
+	// challenge and other special purpose tokens never grant access
+	if tokenClaims.Purpose != "" {
+		return nil, utils.ErrInvalidToken
 	}
 
-	return nil
+	return tokenClaims, nil
 }
 
-// GenerateToken - создать новый JWT токен
-func (s *Service) GenerateToken(userID string) (string, error) {
-	claims := jwt.MapClaims{
-		"sub": userID,
-		"iat": time.Now().Unix(),
-		"exp": time.Now().Add(s.expires).Unix(),
+// parseClaims - проверить подпись, срок действия и обязательные claims токена любого назначения
+func (s *Service) parseClaims(tokenStr string) (*claims, error) {
+	tokenStr = strings.TrimSpace(tokenStr)
+	if tokenStr == "" {
+		return nil, utils.ErrInvalidToken
+	}
+
+	tokenClaims := &claims{}
+	token, err := jwt.ParseWithClaims(tokenStr, tokenClaims, s.keys.keyFunc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", utils.ErrInvalidToken, err)
+	}
+
+	if !token.Valid {
+		return nil, utils.ErrInvalidToken
+	}
+
+	if tokenClaims.Subject == "" || tokenClaims.ID == "" || tokenClaims.IssuedAt == nil || tokenClaims.ExpiresAt == nil {
+		return nil, utils.ErrInvalidToken
+	}
+
+	return tokenClaims, nil
+}
+
+// signPurposeToken - подписать токен специального назначения, он не принимается как access
+func (s *Service) signPurposeToken(tokenClaims claims, purpose string) (string, error) {
+	tokenClaims.Purpose = purpose
+	return s.keys.sign(tokenClaims)
+}
+
+// consumePurposeToken - проверить токен специального назначения и сразу отозвать его, токен одноразовый
+func (s *Service) consumePurposeToken(ctx context.Context, token, purpose string) (*claims, error) {
+	tokenClaims, err := s.parsePurposeToken(token, purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.consumeToken(ctx, tokenClaims); err != nil {
+		return nil, err
+	}
+
+	return tokenClaims, nil
+}
+
+// parsePurposeToken - проверить подпись, срок и назначение токена, не отзывая его
+func (s *Service) parsePurposeToken(token, purpose string) (*claims, error) {
+	tokenClaims, err := s.parseClaims(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenClaims.Purpose != purpose {
+		return nil, utils.ErrInvalidToken
+	}
+
+	return tokenClaims, nil
+}
+
+// consumeToken - отозвать ещё не использованный токен
+func (s *Service) consumeToken(ctx context.Context, tokenClaims *claims) error {
+	// the token itself is checked by the revocation below, which succeeds only once
+	revoked, err := s.tokenRepository.IsAccessTokenRevoked(ctx, "", tokenClaims.Subject, tokenClaims.SessionID, tokenClaims.IssuedAt.Time)
+	if err != nil {
+		return err
+	}
+
+	if revoked {
+		return utils.ErrInvalidToken
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.secret))
+	return s.tokenRepository.RevokeAccessToken(ctx, tokenClaims.ID, tokenClaims.Subject, tokenClaims.ExpiresAt.Time)
 }
@@ -2,6 +2,9 @@ package auth
 
 import (
 	"context"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"testing"
 	"time"
@@ -10,16 +13,26 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
 	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/hasher"
 	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
 )
 
+// testHasher - хеширование с argon2id.DefaultParams, которыми созданы хеши в тестах
+var testHasher = hasher.NewService(&infra.Config{})
+
+var testLogger = &infra.Logger{Zap: zap.NewNop(), SugaredLogger: zap.NewNop().Sugar()}
+
 func TestLogin(t *testing.T) {
-	cfg := &infra.Config{JwtSecret: "test-secret"}
+	cfg := &infra.Config{JwtSecret: "test-secret", RefreshTokenTTL: time.Hour}
 
 	ctx := context.Background()
 
@@ -39,7 +52,7 @@ func TestLogin(t *testing.T) {
 		name          string
 		email         string
 		password      string
-		mockSetup     func(*repositoryMocks.MockUserRepository)
+		mockSetup     func(*repositoryMocks.MockUserRepository, *repositoryMocks.MockTokenRepository, *repositoryMocks.MockAttemptRepository)
 		expectedError error
 		checkToken    bool
 	}{
@@ -47,9 +60,20 @@ func TestLogin(t *testing.T) {
 			name:     "successful login",
 			email:    "test@example.com",
 			password: password,
-			mockSetup: func(mockRepo *repositoryMocks.MockUserRepository) {
+			mockSetup: func(mockRepo *repositoryMocks.MockUserRepository, mockTokenRepo *repositoryMocks.MockTokenRepository, mockAttemptRepo *repositoryMocks.MockAttemptRepository) {
 				mockRepo.On("GetUserByEmail", ctx, "test@example.com").
-					Return(testUser, nil).Once()
+					Return(testUser, nil).Twice()
+				mockAttemptRepo.On("GetLockedUntil", ctx, []string{"ip:203.0.113.7", "account:" + userID}).
+					Return(time.Time{}, nil).Once()
+				mockAttemptRepo.On("Reset", ctx, "account:"+userID).Return(nil).Once()
+				mockTokenRepo.On("CreateSession", ctx, mock.MatchedBy(func(session queries.Session) bool {
+					return session.UserID == userID && session.ID != ""
+				}), mock.MatchedBy(func(token queries.RefreshToken) bool {
+					return token.UserID == userID && token.FamilyID != "" && token.TokenHash != ""
+				})).Return(nil).Once()
+				mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).
+					Return(false, nil).Once()
+				mockTokenRepo.On("TouchSession", ctx, mock.Anything, mock.Anything).Return(nil).Once()
 			},
 			expectedError: nil,
 			checkToken:    true,
@@ -59,9 +83,14 @@ func TestLogin(t *testing.T) {
 			name:     "user not found",
 			email:    "nonexistent@example.com",
 			password: password,
-			mockSetup: func(mockRepo *repositoryMocks.MockUserRepository) {
+			mockSetup: func(mockRepo *repositoryMocks.MockUserRepository, _ *repositoryMocks.MockTokenRepository, mockAttemptRepo *repositoryMocks.MockAttemptRepository) {
 				mockRepo.On("GetUserByEmail", ctx, "nonexistent@example.com").
-					Return(queries.User{}, pgx.ErrNoRows).Once()
+					Return(queries.User{}, pgx.ErrNoRows).Twice()
+				// unknown emails are counted only against the client IP
+				mockAttemptRepo.On("GetLockedUntil", ctx, []string{"ip:203.0.113.7"}).
+					Return(time.Time{}, nil).Once()
+				mockAttemptRepo.On("RegisterFailure", ctx, "ip:203.0.113.7", mock.Anything).
+					Return(int32(1), nil).Once()
 			},
 			expectedError: utils.ErrInvalidUser,
 			checkToken:    false,
@@ -71,7 +100,7 @@ func TestLogin(t *testing.T) {
 			name:     "database error",
 			email:    "test@example.com",
 			password: password,
-			mockSetup: func(mockRepo *repositoryMocks.MockUserRepository) {
+			mockSetup: func(mockRepo *repositoryMocks.MockUserRepository, _ *repositoryMocks.MockTokenRepository, _ *repositoryMocks.MockAttemptRepository) {
 				mockRepo.On("GetUserByEmail", ctx, "test@example.com").
 					Return(queries.User{}, errors.New("database connection error")).Once()
 			},
@@ -83,9 +112,15 @@ func TestLogin(t *testing.T) {
 			name:     "invalid password",
 			email:    "test@example.com",
 			password: "WrongPassword123",
-			mockSetup: func(mockRepo *repositoryMocks.MockUserRepository) {
+			mockSetup: func(mockRepo *repositoryMocks.MockUserRepository, _ *repositoryMocks.MockTokenRepository, mockAttemptRepo *repositoryMocks.MockAttemptRepository) {
 				mockRepo.On("GetUserByEmail", ctx, "test@example.com").
-					Return(testUser, nil).Once()
+					Return(testUser, nil).Twice()
+				mockAttemptRepo.On("GetLockedUntil", ctx, []string{"ip:203.0.113.7", "account:" + userID}).
+					Return(time.Time{}, nil).Once()
+				mockAttemptRepo.On("RegisterFailure", ctx, "ip:203.0.113.7", mock.Anything).
+					Return(int32(1), nil).Once()
+				mockAttemptRepo.On("RegisterFailure", ctx, "account:"+userID, mock.Anything).
+					Return(int32(1), nil).Once()
 			},
 			expectedError: utils.ErrInvalidPassword,
 			checkToken:    false,
@@ -95,36 +130,149 @@ func TestLogin(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := repositoryMocks.NewMockUserRepository(t)
-			tt.mockSetup(mockRepo)
-			service := NewService(cfg, mockRepo)
+			mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+			mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+			mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+			mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+			tt.mockSetup(mockRepo, mockTokenRepo, mockAttemptRepo)
+			if tt.expectedError == nil {
+				mockTwoFactorRepo.On("GetTOTP", ctx, userID).Return(queries.UserTotp{}, pgx.ErrNoRows).Once()
+				mockRoleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleUser}, nil).Once()
+			}
+			service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, mockAttemptRepo, nil, nil, testHasher, nil, testLogger)
+			require.NoError(t, err)
 
-			token, err := service.Login(ctx, tt.email, tt.password)
+			result, err := service.Login(ctx, tt.email, tt.password, "203.0.113.7")
+			tokens := result.Tokens
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
 				assert.Equal(t, tt.expectedError.Error(), err.Error())
-				assert.Empty(t, token)
+				assert.Empty(t, tokens.AccessToken)
+				assert.Empty(t, tokens.RefreshToken)
 			} else {
 				require.NoError(t, err)
+				assert.Empty(t, result.ChallengeToken)
 				if tt.checkToken {
-					assert.NotEmpty(t, token)
-					// Verify the token is valid
-					extractedUserID, verifyErr := service.VerifyToken("Bearer " + token)
+					assert.NotEmpty(t, tokens.AccessToken)
+					assert.NotEmpty(t, tokens.RefreshToken)
+					// Verify the token is valid and carries the user roles
+					principal, verifyErr := service.Authenticate(ctx, "Bearer "+tokens.AccessToken)
 					require.NoError(t, verifyErr)
-					assert.Equal(t, userID, extractedUserID)
+					assert.Equal(t, userID, principal.UserID)
+					assert.Equal(t, []string{model.RoleUser}, principal.Roles)
+					assert.NotEmpty(t, principal.SessionID)
 				}
 			}
 
 			mockRepo.AssertExpectations(t)
+			mockTokenRepo.AssertExpectations(t)
 		})
 	}
 }
 
+func TestLoginUpgradesPasswordHash(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", RefreshTokenTTL: time.Hour}
+	ctx := context.Background()
+	password := "SecurePassword123"
+	userID := "test-user-123"
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	pbkdf2Key, err := pbkdf2.Key(sha256.New, password, []byte("legacysalt"), 1000, sha256.Size)
+	require.NoError(t, err)
+
+	weakArgon2id, err := argon2id.CreateHash(password, &argon2id.Params{Memory: 8 * 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{name: "bcrypt", hash: string(bcryptHash)},
+		{name: "pbkdf2", hash: "pbkdf2_sha256$1000$legacysalt$" + base64.StdEncoding.EncodeToString(pbkdf2Key)},
+		{name: "argon2id with old params", hash: weakArgon2id},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repositoryMocks.NewMockUserRepository(t)
+			mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+			mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+			mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+			mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+			service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, mockAttemptRepo, nil, nil, testHasher, nil, testLogger)
+			require.NoError(t, err)
+
+			user := queries.User{ID: userID, Email: "test@example.com", PasswordHash: tt.hash}
+			mockRepo.On("GetUserByEmail", ctx, user.Email).Return(user, nil).Twice()
+			mockAttemptRepo.On("GetLockedUntil", ctx, []string{"account:" + userID}).Return(time.Time{}, nil).Once()
+			mockRepo.On("UpgradePasswordHash", ctx, userID, tt.hash, mock.MatchedBy(func(newHash string) bool {
+				params, _, _, err := argon2id.DecodeHash(newHash)
+				if err != nil || *params != *argon2id.DefaultParams {
+					return false
+				}
+				match, err := argon2id.ComparePasswordAndHash(password, newHash)
+				return err == nil && match
+			})).Return(nil).Once()
+			mockTwoFactorRepo.On("GetTOTP", ctx, userID).Return(queries.UserTotp{}, pgx.ErrNoRows).Once()
+			mockAttemptRepo.On("Reset", ctx, "account:"+userID).Return(nil).Once()
+			mockRoleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleUser}, nil).Once()
+			mockTokenRepo.On("CreateSession", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+			result, err := service.Login(ctx, user.Email, password, "")
+			require.NoError(t, err)
+			assert.NotEmpty(t, result.Tokens.AccessToken)
+		})
+	}
+}
+
+func TestLoginIgnoresRehashFailure(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", RefreshTokenTTL: time.Hour}
+	ctx := context.Background()
+	password := "SecurePassword123"
+	userID := "test-user-123"
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	mockRepo := repositoryMocks.NewMockUserRepository(t)
+	mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+	mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+	mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+	service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, mockAttemptRepo, nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+
+	user := queries.User{ID: userID, Email: "test@example.com", PasswordHash: string(bcryptHash)}
+	mockRepo.On("GetUserByEmail", ctx, user.Email).Return(user, nil).Twice()
+	mockAttemptRepo.On("GetLockedUntil", ctx, []string{"account:" + userID}).Return(time.Time{}, nil).Once()
+	mockRepo.On("UpgradePasswordHash", ctx, userID, user.PasswordHash, mock.Anything).Return(errors.New("connection reset")).Once()
+	mockTwoFactorRepo.On("GetTOTP", ctx, userID).Return(queries.UserTotp{}, pgx.ErrNoRows).Once()
+	mockAttemptRepo.On("Reset", ctx, "account:"+userID).Return(nil).Once()
+	mockRoleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleUser}, nil).Once()
+	mockTokenRepo.On("CreateSession", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// the upgrade is best effort, the correct password still logs in
+	result, err := service.Login(ctx, user.Email, password, "")
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Tokens.AccessToken)
+}
+
 func TestGenerateToken(t *testing.T) {
 	cfg := &infra.Config{JwtSecret: "test-secret"}
 	mockRepo := repositoryMocks.NewMockUserRepository(t)
-	service := NewService(cfg, mockRepo)
+	mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+	mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+	service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
 	userID := "test-user-123"
+	ctx := context.Background()
+
+	mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).
+		Return(false, nil).Once()
 
 	token, err := service.GenerateToken(userID)
 
@@ -132,7 +280,7 @@ func TestGenerateToken(t *testing.T) {
 	assert.NotEmpty(t, token)
 
 	// Verify token contains correct user ID
-	extractedUserID, verifyErr := service.VerifyToken("Bearer " + token)
+	extractedUserID, verifyErr := service.VerifyToken(ctx, "Bearer "+token)
 	require.NoError(t, verifyErr)
 	assert.Equal(t, userID, extractedUserID)
 }
@@ -140,8 +288,16 @@ func TestGenerateToken(t *testing.T) {
 func TestVerifyToken(t *testing.T) {
 	cfg := &infra.Config{JwtSecret: "test-secret"}
 	mockRepo := repositoryMocks.NewMockUserRepository(t)
-	service := NewService(cfg, mockRepo)
+	mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+	mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+	service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
 	userID := "test-user-123"
+	ctx := context.Background()
+
+	mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).
+		Return(false, nil).Maybe()
 
 	tests := []struct {
 		name          string
@@ -189,7 +345,10 @@ func TestVerifyToken(t *testing.T) {
 				// Create a token with a different secret
 				differentCfg := &infra.Config{JwtSecret: "different-secret"}
 				differentRepo := repositoryMocks.NewMockUserRepository(t)
-				differentService := NewService(differentCfg, differentRepo)
+				differentTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+				differentRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+				differentTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+				differentService, _ := NewService(differentCfg, differentRepo, differentTokenRepo, differentRoleRepo, differentTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
 				token, _ := differentService.GenerateToken(userID)
 				return "Bearer " + token
 			},
@@ -201,7 +360,7 @@ func TestVerifyToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tokenStr := tt.setupToken()
-			extractedID, err := service.VerifyToken(tokenStr)
+			extractedID, err := service.VerifyToken(ctx, tokenStr)
 
 			if tt.expectedError != nil {
 				require.Error(t, err)
@@ -218,7 +377,11 @@ func TestVerifyToken(t *testing.T) {
 func TestVerifyPassword(t *testing.T) {
 	cfg := &infra.Config{JwtSecret: "test-secret"}
 	mockRepo := repositoryMocks.NewMockUserRepository(t)
-	service := NewService(cfg, mockRepo)
+	mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+	mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+	service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
 
 	password := "SecurePassword123"
 	passwordHash, err := argon2id.CreateHash(password, argon2id.DefaultParams)
@@ -268,7 +431,7 @@ func TestVerifyPassword(t *testing.T) {
 				PasswordHash: "invalid-hash",
 			},
 			password:      password,
-			expectedError: errors.New("argon2id"),
+			expectedError: errors.New("unsupported password hash format"),
 		},
 	}
 
@@ -289,26 +452,39 @@ func TestVerifyPassword(t *testing.T) {
 func TestNewService(t *testing.T) {
 	cfg := &infra.Config{JwtSecret: "test-secret"}
 	mockRepo := repositoryMocks.NewMockUserRepository(t)
-	service := NewService(cfg, mockRepo)
+	mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+	mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+	service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
 
 	assert.NotNil(t, service)
-	assert.Equal(t, "test-secret", service.secret)
+	assert.Equal(t, []byte("test-secret"), service.keys.secret)
 	assert.Equal(t, time.Hour, service.expires)
 	assert.NotNil(t, service.repository)
+	assert.NotNil(t, service.tokenRepository)
 }
 
 func TestTokenExpiration(t *testing.T) {
 	cfg := &infra.Config{JwtSecret: "test-secret"}
 	mockRepo := repositoryMocks.NewMockUserRepository(t)
-	service := NewService(cfg, mockRepo)
+	mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+	mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+	service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
 	userID := "test-user-123"
+	ctx := context.Background()
+
+	mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).
+		Return(false, nil).Once()
 
 	// Generate a token
 	token, err := service.GenerateToken(userID)
 	require.NoError(t, err)
 
 	// Verify the token immediately (should be valid)
-	extractedID, err := service.VerifyToken("Bearer " + token)
+	extractedID, err := service.VerifyToken(ctx, "Bearer "+token)
 	require.NoError(t, err)
 	assert.Equal(t, userID, extractedID)
 
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// purposeMFA - токен подтверждает только пароль и обменивается на пару токенов после второго фактора
+	purposeMFA = "mfa"
+	// purposeVerifyEmail - токен из письма, подтверждает владение адресом из claim email
+	purposeVerifyEmail = "verify_email"
+	// purposeMagicLink - токен из письма для входа без пароля
+	purposeMagicLink = "magic_link"
+	// purposeChangeEmail - токен из письма на новый адрес, подтверждает смену почты на адрес из claim email
+	purposeChangeEmail = "change_email"
+	// purposeRevertEmail - токен из письма на старый адрес, возвращает почту из claim email
+	purposeRevertEmail = "revert_email"
+	// purposeRestoreAccount - токен из письма об удалении аккаунта, отменяет удаление
+	purposeRestoreAccount = "restore_account"
+	// purposeDownload - многоразовая ссылка на скачивание ресурса из claim res, например архива персональных данных
+	purposeDownload = "download"
+)
+
+// claims - содержимое access токена
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"`
+	// Purpose - пустой у access токенов, иначе токен не принимается как access
+	Purpose string `json:"purpose,omitempty"`
+	// Email - адрес, к которому привязан токен из письма
+	Email string `json:"email,omitempty"`
+	// ClientID - клиент OAuth, которому выдан токен, пустой при входе в само приложение
+	ClientID string `json:"client_id,omitempty"`
+	// Scope - scope через пробел, непустой scope ограничивает токен как API ключ
+	Scope string `json:"scope,omitempty"`
+	// Device - хеш секрета устройства, на котором запрошена ссылка для входа
+	Device string `json:"device,omitempty"`
+	// Resource - ресурс пользователя, который можно скачать по токену
+	Resource string `json:"res,omitempty"`
+	// SessionID - сессия (семейство refresh токенов), её отзыв отзывает и токен
+	SessionID string `json:"sid,omitempty"`
+	// Actor - кто на самом деле действует от имени Subject (RFC 8693, claim act)
+	Actor *actor `json:"act,omitempty"`
+}
+
+// actor - содержимое claim act
+type actor struct {
+	Subject string `json:"sub"`
+	// SessionID - сессия администратора, из которой выдан токен, её отзыв отзывает и токен
+	SessionID string `json:"sid,omitempty"`
+}
+
+func newClaims(userID string, ttl time.Duration) claims {
+	now := time.Now()
+	return claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        ulid.Make().String(),
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+var errEmptyScope = errors.New("restricted token requires at least one scope")
+
+// grant - клиент OAuth, которому выдаются токены, и его scope.
+// Пустой при входе в само приложение, scopes == nil - токен не ограничен scope.
+type grant struct {
+	clientID string
+	scopes   []string
+}
+
+// IssueClientTokens - выдать пару токенов пользователя клиенту OAuth.
+// С непустыми scope токены ограничены ими так же, как API ключ.
+func (s *Service) IssueClientTokens(ctx context.Context, userID, clientID string, scopes []string) (model.TokenPair, error) {
+	return s.issueTokens(ctx, userID, grant{clientID: clientID, scopes: scopes})
+}
+
+// GenerateClientToken - access токен клиента OAuth от его собственного имени (client credentials).
+// Пользователя и ролей за токеном нет, доступ определяется только scope, refresh токен не выдаётся.
+func (s *Service) GenerateClientToken(clientID string, scopes []string) (model.TokenPair, error) {
+	if len(scopes) == 0 {
+		return model.TokenPair{}, errEmptyScope
+	}
+
+	accessToken, err := s.signAccessToken(clientID, nil, "", grant{clientID: clientID, scopes: scopes})
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	return model.TokenPair{
+		AccessToken: accessToken,
+		ExpiresIn:   s.expires,
+	}, nil
+}
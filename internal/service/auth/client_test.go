@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func newClientTestService(t *testing.T) (*Service, *repositoryMocks.MockTokenRepository, *repositoryMocks.MockRoleRepository) {
+	t.Helper()
+
+	cfg := &infra.Config{JwtSecret: "test-secret", RefreshTokenTTL: time.Hour}
+	tokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	roleRepo := repositoryMocks.NewMockRoleRepository(t)
+
+	service, err := NewService(cfg, repositoryMocks.NewMockUserRepository(t), tokenRepo, roleRepo,
+		repositoryMocks.NewMockTwoFactorRepository(t), repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+
+	return service, tokenRepo, roleRepo
+}
+
+func TestIssueClientTokens(t *testing.T) {
+	ctx := context.Background()
+	userID := "test-user-123"
+
+	tests := []struct {
+		name           string
+		scopes         []string
+		expectedScopes []string
+		restricted     bool
+	}{
+		{
+			name:           "third-party client is limited by scopes",
+			scopes:         []string{model.PermissionUsersRead},
+			expectedScopes: []string{model.PermissionUsersRead},
+			restricted:     true,
+		},
+		{
+			name:       "first-party client without scopes is unrestricted",
+			scopes:     nil,
+			restricted: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, tokenRepo, roleRepo := newClientTestService(t)
+
+			roleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleAdmin}, nil).Once()
+			tokenRepo.On("CreateSession", ctx, mock.MatchedBy(func(session queries.Session) bool {
+				return session.ClientID.String == "client-1"
+			}), mock.MatchedBy(func(token queries.RefreshToken) bool {
+				return token.UserID == userID && token.ClientID.String == "client-1" && assert.ObjectsAreEqual(tt.scopes, token.Scopes)
+			})).Return(nil).Once()
+			tokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).Return(false, nil).Once()
+			tokenRepo.On("TouchSession", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+			tokens, err := service.IssueClientTokens(ctx, userID, "client-1", tt.scopes)
+			require.NoError(t, err)
+			assert.NotEmpty(t, tokens.RefreshToken)
+			assert.Equal(t, time.Hour, tokens.ExpiresIn)
+
+			principal, err := service.Authenticate(ctx, "Bearer "+tokens.AccessToken)
+			require.NoError(t, err)
+			assert.Equal(t, userID, principal.UserID)
+			assert.Equal(t, "client-1", principal.ClientID)
+			assert.Equal(t, []string{model.RoleAdmin}, principal.Roles)
+			assert.Equal(t, tt.expectedScopes, principal.Scopes)
+			assert.Equal(t, tt.restricted, principal.Restricted())
+		})
+	}
+
+	t.Run("empty scopes are rejected", func(t *testing.T) {
+		service, _, roleRepo := newClientTestService(t)
+		roleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleUser}, nil).Once()
+
+		_, err := service.IssueClientTokens(ctx, userID, "client-1", []string{})
+		assert.ErrorIs(t, err, errEmptyScope)
+	})
+}
+
+func TestGenerateClientToken(t *testing.T) {
+	ctx := context.Background()
+	service, tokenRepo, _ := newClientTestService(t)
+
+	tokens, err := service.GenerateClientToken("client-1", []string{model.PermissionUsersRead})
+	require.NoError(t, err)
+	assert.Empty(t, tokens.RefreshToken)
+
+	tokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, "client-1", mock.Anything, mock.Anything).Return(false, nil).Once()
+	principal, err := service.Authenticate(ctx, "Bearer "+tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Empty(t, principal.UserID)
+	assert.Empty(t, principal.Roles)
+	assert.Equal(t, "client-1", principal.ClientID)
+	assert.True(t, principal.Allows(model.PermissionUsersRead))
+	assert.False(t, principal.Allows(model.PermissionUsersWrite))
+
+	_, err = service.GenerateClientToken("client-1", nil)
+	assert.ErrorIs(t, err, errEmptyScope)
+}
+
+func TestRefreshClient(t *testing.T) {
+	ctx := context.Background()
+	rawToken := "refresh-token"
+	tokenHash := utils.HashToken(rawToken)
+
+	clientToken := queries.RefreshToken{
+		ID:        "token-1",
+		UserID:    "test-user-123",
+		FamilyID:  "family-1",
+		TokenHash: tokenHash,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+		ClientID:  pgtype.Text{String: "client-1", Valid: true},
+		Scopes:    []string{model.PermissionUsersRead},
+	}
+
+	t.Run("scopes are kept on rotation", func(t *testing.T) {
+		service, tokenRepo, roleRepo := newClientTestService(t)
+
+		tokenRepo.On("GetRefreshTokenByHash", ctx, tokenHash).Return(clientToken, nil).Once()
+		tokenRepo.On("RotateRefreshToken", ctx, "token-1", mock.MatchedBy(func(next queries.RefreshToken) bool {
+			return next.ClientID == clientToken.ClientID && assert.ObjectsAreEqual(clientToken.Scopes, next.Scopes)
+		})).Return(nil).Once()
+		roleRepo.On("GetUserRoles", ctx, clientToken.UserID).Return([]string{model.RoleUser}, nil).Once()
+		tokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, clientToken.UserID, mock.Anything, mock.Anything).Return(false, nil).Once()
+		tokenRepo.On("TouchSession", ctx, clientToken.FamilyID, mock.Anything).Return(nil).Once()
+
+		tokens, err := service.RefreshClient(ctx, rawToken, "client-1")
+		require.NoError(t, err)
+
+		principal, err := service.Authenticate(ctx, "Bearer "+tokens.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, clientToken.Scopes, principal.Scopes)
+		assert.Equal(t, "client-1", principal.ClientID)
+	})
+
+	t.Run("another client cannot use the token", func(t *testing.T) {
+		service, tokenRepo, _ := newClientTestService(t)
+		tokenRepo.On("GetRefreshTokenByHash", ctx, tokenHash).Return(clientToken, nil).Once()
+
+		_, err := service.RefreshClient(ctx, rawToken, "client-2")
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	t.Run("client token is not accepted by the session refresh", func(t *testing.T) {
+		service, tokenRepo, _ := newClientTestService(t)
+		tokenRepo.On("GetRefreshTokenByHash", ctx, tokenHash).Return(clientToken, nil).Once()
+
+		_, err := service.Refresh(ctx, rawToken)
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const deletionMailBody = `Your account was deleted. You cannot log in anymore and all of its sessions were ended.
+
+Changed your mind? Restore the account by opening the link below:
+
+%s
+
+The link is valid for %s. After that the account and all of its data are removed for good.
+`
+
+// DeleteAccount - удалить аккаунт текущего пользователя: вход и все токены сразу перестают работать,
+// на почту уходит ссылка для восстановления. Данные удаляются окончательно после ACCOUNT_DELETION_GRACE.
+// Аккаунт с локальным паролем подтверждает удаление паролем.
+func (s *Service) DeleteAccount(ctx context.Context, userID, password string) error {
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// accounts without a local password have nothing but the session to confirm with
+	if user.PasswordHash != "" {
+		if err = s.checkCurrentPassword(ctx, user, password); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	if err = s.repository.Delete(ctx, user.ID, now, now.Add(s.expires)); err != nil {
+		return err
+	}
+
+	tokenClaims := newClaims(user.ID, s.deletionGrace)
+	token, err := s.signPurposeToken(tokenClaims, purposeRestoreAccount)
+	if err != nil {
+		return err
+	}
+
+	link := s.publicURL + "/restore-account?token=" + url.QueryEscape(token)
+	return s.mailer.Send(ctx, infra.Mail{
+		To:      user.Email,
+		Subject: "Your account was deleted",
+		Body:    fmt.Sprintf(deletionMailBody, link, s.deletionGrace),
+	})
+}
+
+// RestoreAccount - отменить удаление аккаунта токеном из письма, пока не истёк срок ACCOUNT_DELETION_GRACE.
+// Сессии не восстанавливаются, после восстановления нужно войти заново
+func (s *Service) RestoreAccount(ctx context.Context, token string) error {
+	tokenClaims, err := s.parsePurposeToken(token, purposeRestoreAccount)
+	if err != nil {
+		return err
+	}
+
+	// the link is issued right after all tokens of the user are revoked, so only the token itself
+	// is revoked, together with the restore, not checked against the revocations of its user
+	if err = s.repository.Restore(ctx, linkOf(tokenClaims), time.Now().Add(-s.deletionGrace)); err != nil {
+		// restored already or purged
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrInvalidToken
+		}
+		return err
+	}
+
+	return nil
+}
+
+// PurgeDeletedAccounts - окончательно удалить аккаунты, срок восстановления которых истёк
+func (s *Service) PurgeDeletedAccounts(ctx context.Context) error {
+	ids, err := s.repository.PurgeDeleted(ctx, time.Now().Add(-s.deletionGrace))
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err = s.attemptRepository.Reset(ctx, accountSubject(id)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
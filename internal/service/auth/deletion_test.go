@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+var restoreLinkToken = regexp.MustCompile(`/restore-account\?token=(\S+)`)
+
+func TestAccountDeletion(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", AccountDeletionGrace: 30 * 24 * time.Hour, PublicURL: "https://example.com"}
+	ctx := context.Background()
+
+	password := "SecurePassword123"
+	passwordHash, err := testHasher.Hash(password)
+	require.NoError(t, err)
+	testUser := queries.User{ID: "test-user-123", Email: "test@example.com", PasswordHash: passwordHash}
+	subject := []string{"account:" + testUser.ID}
+
+	type mocks struct {
+		user    *repositoryMocks.MockUserRepository
+		token   *repositoryMocks.MockTokenRepository
+		role    *repositoryMocks.MockRoleRepository
+		attempt *repositoryMocks.MockAttemptRepository
+	}
+
+	newTestService := func(t *testing.T) (*Service, mocks, *recordingMailer) {
+		m := mocks{
+			user:    repositoryMocks.NewMockUserRepository(t),
+			token:   repositoryMocks.NewMockTokenRepository(t),
+			role:    repositoryMocks.NewMockRoleRepository(t),
+			attempt: repositoryMocks.NewMockAttemptRepository(t),
+		}
+		mailer := &recordingMailer{}
+		service, err := NewService(cfg, m.user, m.token, m.role, repositoryMocks.NewMockTwoFactorRepository(t), m.attempt, mailer, nil, testHasher, nil, testLogger)
+		require.NoError(t, err)
+		return service, m, mailer
+	}
+
+	t.Run("deleted account can be restored once", func(t *testing.T) {
+		service, m, mailer := newTestService(t)
+		m.user.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil).Once()
+		m.attempt.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+		m.user.On("Delete", ctx, testUser.ID, mock.Anything, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, service.DeleteAccount(ctx, testUser.ID, password))
+		require.Len(t, mailer.sent, 1)
+		assert.Equal(t, testUser.Email, mailer.sent[0].To)
+		token := linkTokenFromMail(t, restoreLinkToken, mailer.sent[0])
+
+		// all tokens of the user are revoked by the deletion, the link is revoked only by the restore itself
+		link := mock.MatchedBy(func(link queries.RevokedToken) bool {
+			return link.Jti != "" && link.UserID == testUser.ID && link.ExpiresAt.Time.After(time.Now())
+		})
+		m.user.On("Restore", ctx, link, mock.MatchedBy(func(deletedAfter time.Time) bool {
+			return deletedAfter.Before(time.Now().Add(-29 * 24 * time.Hour))
+		})).Return(nil).Once()
+		require.NoError(t, service.RestoreAccount(ctx, token))
+
+		m.user.On("Restore", ctx, link, mock.Anything).Return(utils.ErrInvalidToken).Once()
+		require.ErrorIs(t, service.RestoreAccount(ctx, token), utils.ErrInvalidToken)
+	})
+
+	t.Run("purged account cannot be restored", func(t *testing.T) {
+		service, m, _ := newTestService(t)
+		tokenClaims := newClaims(testUser.ID, time.Hour)
+		token, err := service.signPurposeToken(tokenClaims, purposeRestoreAccount)
+		require.NoError(t, err)
+
+		m.user.On("Restore", ctx, mock.Anything, mock.Anything).Return(pgx.ErrNoRows).Once()
+		require.ErrorIs(t, service.RestoreAccount(ctx, token), utils.ErrInvalidToken)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		service, m, mailer := newTestService(t)
+		m.user.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil).Once()
+		m.attempt.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+		m.attempt.On("RegisterFailure", ctx, "account:"+testUser.ID, mock.Anything).Return(int32(1), nil).Once()
+
+		require.ErrorIs(t, service.DeleteAccount(ctx, testUser.ID, "WrongPassword"), utils.ErrInvalidPassword)
+		assert.Empty(t, mailer.sent)
+	})
+
+	t.Run("account without password is confirmed by the session", func(t *testing.T) {
+		service, m, mailer := newTestService(t)
+		social := queries.User{ID: testUser.ID, Email: testUser.Email}
+		m.user.On("GetUserByID", ctx, testUser.ID).Return(social, nil).Once()
+		m.user.On("Delete", ctx, testUser.ID, mock.Anything, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, service.DeleteAccount(ctx, testUser.ID, ""))
+		assert.Len(t, mailer.sent, 1)
+	})
+
+	t.Run("last admin cannot be deleted", func(t *testing.T) {
+		service, m, mailer := newTestService(t)
+		m.user.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil).Once()
+		m.attempt.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+		m.user.On("Delete", ctx, testUser.ID, mock.Anything, mock.Anything).Return(utils.ErrLastAdmin).Once()
+
+		require.ErrorIs(t, service.DeleteAccount(ctx, testUser.ID, password), utils.ErrLastAdmin)
+		assert.Empty(t, mailer.sent)
+	})
+
+	t.Run("restore link is not an access token", func(t *testing.T) {
+		service, _, _ := newTestService(t)
+		accessToken, err := service.GenerateToken(testUser.ID)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, service.RestoreAccount(ctx, accessToken), utils.ErrInvalidToken)
+	})
+
+	t.Run("purge resets login attempts of purged accounts", func(t *testing.T) {
+		service, m, _ := newTestService(t)
+		m.user.On("PurgeDeleted", ctx, mock.MatchedBy(func(deletedBefore time.Time) bool {
+			return deletedBefore.Before(time.Now().Add(-29 * 24 * time.Hour))
+		})).Return([]string{"purged-1", "purged-2"}, nil).Once()
+		m.attempt.On("Reset", ctx, "account:purged-1").Return(nil).Once()
+		m.attempt.On("Reset", ctx, "account:purged-2").Return(nil).Once()
+
+		require.NoError(t, service.PurgeDeletedAccounts(ctx))
+	})
+}
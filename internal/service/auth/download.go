@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// GenerateDownloadToken - подписанная ссылка на скачивание ресурса resource пользователя userID, действует до expiresAt.
+// Ссылка не одноразовая: скачивание можно повторить, но выход на всех устройствах и смена пароля её отзывают
+func (s *Service) GenerateDownloadToken(userID, resource string, expiresAt time.Time) (string, error) {
+	tokenClaims := newClaims(userID, time.Until(expiresAt))
+	tokenClaims.Resource = resource
+	return s.signPurposeToken(tokenClaims, purposeDownload)
+}
+
+// VerifyDownloadToken - проверить ссылку на скачивание, вернуть пользователя и ресурс из неё
+func (s *Service) VerifyDownloadToken(ctx context.Context, token string) (string, string, error) {
+	tokenClaims, err := s.parsePurposeToken(token, purposeDownload)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err = s.checkRevoked(ctx, tokenClaims); err != nil {
+		return "", "", err
+	}
+
+	return tokenClaims.Subject, tokenClaims.Resource, nil
+}
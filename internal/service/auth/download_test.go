@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func TestDownloadToken(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret"}
+	ctx := context.Background()
+
+	mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	service, err := NewService(cfg, repositoryMocks.NewMockUserRepository(t), mockTokenRepo, repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), repositoryMocks.NewMockAttemptRepository(t), &recordingMailer{}, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+
+	token, err := service.GenerateDownloadToken("user-1", "export-1", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	t.Run("link can be used again", func(t *testing.T) {
+		mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, "user-1", "", mock.Anything).Return(false, nil).Twice()
+
+		for range 2 {
+			userID, resource, err := service.VerifyDownloadToken(ctx, token)
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", userID)
+			assert.Equal(t, "export-1", resource)
+		}
+	})
+
+	t.Run("logout everywhere revokes the link", func(t *testing.T) {
+		mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, "user-1", "", mock.Anything).Return(true, nil).Once()
+
+		_, _, err := service.VerifyDownloadToken(ctx, token)
+		require.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	t.Run("link is not an access token", func(t *testing.T) {
+		_, err := service.VerifyToken(ctx, "Bearer "+token)
+		require.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	t.Run("access token is not a link", func(t *testing.T) {
+		accessToken, err := service.GenerateToken("user-1")
+		require.NoError(t, err)
+
+		_, _, err = service.VerifyDownloadToken(ctx, accessToken)
+		require.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		expired, err := service.GenerateDownloadToken("user-1", "export-1", time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+
+		_, _, err = service.VerifyDownloadToken(ctx, expired)
+		require.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const confirmEmailChangeMailBody = `Someone asked to use this address for their account. Confirm the change by opening the link below:
+
+%s
+
+The link is valid for %s. Until then the account keeps its current address. If it was not you, ignore this email.
+`
+
+const revertEmailChangeMailBody = `Someone asked to change the email of your account to %s. The change takes effect once the new address is confirmed.
+
+If it was not you, open the link below to keep this address and log out every session of the account:
+
+%s
+
+The link is valid for %s, also after the change is confirmed. Reset your password after that.
+`
+
+// RequestEmailChange - начать смену почты: новый адрес резервируется и получает ссылку для подтверждения,
+// на старый уходит ссылка для отмены. До подтверждения пользователь входит со старой почтой.
+func (s *Service) RequestEmailChange(ctx context.Context, userID, newEmail, password string) error {
+	if address, err := mail.ParseAddress(newEmail); err != nil || address.Address != newEmail {
+		return utils.ErrInvalidEmail
+	}
+
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(user.Email, newEmail) {
+		return utils.ErrInvalidEmail
+	}
+
+	if err = s.checkCurrentPassword(ctx, user, password); err != nil {
+		return err
+	}
+
+	if err = s.repository.CreateEmailChange(ctx, queries.EmailChange{
+		UserID:    user.ID,
+		NewEmail:  newEmail,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(s.verificationExpires), Valid: true},
+	}); err != nil {
+		return err
+	}
+
+	confirmClaims := newClaims(user.ID, s.verificationExpires)
+	confirmClaims.Email = newEmail
+	confirmToken, err := s.signPurposeToken(confirmClaims, purposeChangeEmail)
+	if err != nil {
+		return err
+	}
+
+	revertClaims := newClaims(user.ID, s.revertExpires)
+	revertClaims.Email = user.Email
+	revertToken, err := s.signPurposeToken(revertClaims, purposeRevertEmail)
+	if err != nil {
+		return err
+	}
+
+	// the old address hears about the change first, whatever happens to the new one
+	revertLink := s.publicURL + "/revert-email?token=" + url.QueryEscape(revertToken)
+	if err = s.mailer.Send(ctx, infra.Mail{
+		To:      user.Email,
+		Subject: "Your email is being changed",
+		Body:    fmt.Sprintf(revertEmailChangeMailBody, newEmail, revertLink, s.revertExpires),
+	}); err != nil {
+		return err
+	}
+
+	confirmLink := s.publicURL + "/confirm-email?token=" + url.QueryEscape(confirmToken)
+	return s.mailer.Send(ctx, infra.Mail{
+		To:      newEmail,
+		Subject: "Confirm your new email",
+		Body:    fmt.Sprintf(confirmEmailChangeMailBody, confirmLink, s.verificationExpires),
+	})
+}
+
+// ConfirmEmailChange - подтвердить новую почту токеном из письма, токен одноразовый.
+// Ссылка из прошлой, отменённой или истёкшей заявки не принимается
+func (s *Service) ConfirmEmailChange(ctx context.Context, token string) error {
+	tokenClaims, err := s.consumePurposeToken(ctx, token, purposeChangeEmail)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.ConfirmEmailChange(ctx, tokenClaims.Subject, tokenClaims.Email)
+}
+
+// RevertEmailChange - вернуть прежнюю почту токеном из письма на старый адрес и завершить все сессии пользователя
+func (s *Service) RevertEmailChange(ctx context.Context, token string) error {
+	tokenClaims, err := s.parsePurposeToken(token, purposeRevertEmail)
+	if err != nil {
+		return err
+	}
+
+	// whoever took over the account must not void the link with logout-all or a password reset,
+	// so only the token itself is revoked, together with the revert, not checked against the revocations of its user
+	now := time.Now()
+	return s.repository.RevertEmailChange(ctx, linkOf(tokenClaims), tokenClaims.Email, now, now.Add(s.expires))
+}
+
+// linkOf - запись об отзыве одноразовой ссылки из письма
+func linkOf(tokenClaims *claims) queries.RevokedToken {
+	return queries.RevokedToken{
+		Jti:       tokenClaims.ID,
+		UserID:    tokenClaims.Subject,
+		ExpiresAt: pgtype.Timestamptz{Time: tokenClaims.ExpiresAt.Time, Valid: true},
+	}
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+var (
+	confirmLinkToken = regexp.MustCompile(`/confirm-email\?token=(\S+)`)
+	revertLinkToken  = regexp.MustCompile(`/revert-email\?token=(\S+)`)
+)
+
+func linkTokenFromMail(t *testing.T, link *regexp.Regexp, message infra.Mail) string {
+	t.Helper()
+	match := link.FindStringSubmatch(message.Body)
+	require.Len(t, match, 2)
+
+	token, err := url.QueryUnescape(match[1])
+	require.NoError(t, err)
+	return token
+}
+
+func TestEmailChange(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", EmailVerificationTTL: time.Hour, EmailRevertTTL: 24 * time.Hour, PublicURL: "https://example.com"}
+	ctx := context.Background()
+
+	password := "SecurePassword123"
+	passwordHash, err := testHasher.Hash(password)
+	require.NoError(t, err)
+	testUser := queries.User{ID: "test-user-123", Email: "old@example.com", PasswordHash: passwordHash}
+	subject := []string{"account:" + testUser.ID}
+
+	newTestService := func(t *testing.T) (*Service, *repositoryMocks.MockUserRepository, *repositoryMocks.MockTokenRepository, *repositoryMocks.MockAttemptRepository, *recordingMailer) {
+		mockRepo := repositoryMocks.NewMockUserRepository(t)
+		mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+		mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+		mailer := &recordingMailer{}
+		service, err := NewService(cfg, mockRepo, mockTokenRepo, repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), mockAttemptRepo, mailer, nil, testHasher, nil, testLogger)
+		require.NoError(t, err)
+		return service, mockRepo, mockTokenRepo, mockAttemptRepo, mailer
+	}
+
+	request := func(t *testing.T, service *Service, mockRepo *repositoryMocks.MockUserRepository, mockAttemptRepo *repositoryMocks.MockAttemptRepository) {
+		t.Helper()
+		mockRepo.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil).Once()
+		mockAttemptRepo.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+		mockRepo.On("CreateEmailChange", ctx, mock.MatchedBy(func(change queries.EmailChange) bool {
+			return change.UserID == testUser.ID && change.NewEmail == "new@example.com" && change.ExpiresAt.Time.After(time.Now())
+		})).Return(nil).Once()
+
+		require.NoError(t, service.RequestEmailChange(ctx, testUser.ID, "new@example.com", password))
+	}
+
+	t.Run("both addresses get a link", func(t *testing.T) {
+		service, mockRepo, mockTokenRepo, mockAttemptRepo, mailer := newTestService(t)
+		request(t, service, mockRepo, mockAttemptRepo)
+
+		require.Len(t, mailer.sent, 2)
+		assert.Equal(t, "old@example.com", mailer.sent[0].To)
+		assert.Contains(t, mailer.sent[0].Body, "new@example.com")
+		assert.Equal(t, "new@example.com", mailer.sent[1].To)
+
+		mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, testUser.ID, mock.Anything, mock.Anything).Return(false, nil).Once()
+		mockTokenRepo.On("RevokeAccessToken", ctx, mock.Anything, testUser.ID, mock.Anything).Return(nil).Once()
+		mockRepo.On("ConfirmEmailChange", ctx, testUser.ID, "new@example.com").Return(nil).Once()
+		require.NoError(t, service.ConfirmEmailChange(ctx, linkTokenFromMail(t, confirmLinkToken, mailer.sent[1])))
+	})
+
+	t.Run("revert link restores the old address", func(t *testing.T) {
+		service, mockRepo, _, mockAttemptRepo, mailer := newTestService(t)
+		request(t, service, mockRepo, mockAttemptRepo)
+		revertToken := linkTokenFromMail(t, revertLinkToken, mailer.sent[0])
+
+		// revocations of the user must not void the link, the link is revoked by the revert itself
+		link := mock.MatchedBy(func(link queries.RevokedToken) bool {
+			return link.Jti != "" && link.UserID == testUser.ID && link.ExpiresAt.Time.After(time.Now())
+		})
+		mockRepo.On("RevertEmailChange", ctx, link, "old@example.com", mock.Anything, mock.Anything).Return(nil).Once()
+		require.NoError(t, service.RevertEmailChange(ctx, revertToken))
+
+		mockRepo.On("RevertEmailChange", ctx, link, "old@example.com", mock.Anything, mock.Anything).Return(utils.ErrInvalidToken).Once()
+		require.ErrorIs(t, service.RevertEmailChange(ctx, revertToken), utils.ErrInvalidToken)
+	})
+
+	t.Run("links are not interchangeable", func(t *testing.T) {
+		service, mockRepo, _, mockAttemptRepo, mailer := newTestService(t)
+		request(t, service, mockRepo, mockAttemptRepo)
+
+		require.ErrorIs(t, service.ConfirmEmailChange(ctx, linkTokenFromMail(t, revertLinkToken, mailer.sent[0])), utils.ErrInvalidToken)
+		require.ErrorIs(t, service.RevertEmailChange(ctx, linkTokenFromMail(t, confirmLinkToken, mailer.sent[1])), utils.ErrInvalidToken)
+	})
+
+	t.Run("address taken", func(t *testing.T) {
+		service, mockRepo, _, mockAttemptRepo, mailer := newTestService(t)
+		mockRepo.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil).Once()
+		mockAttemptRepo.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+		mockRepo.On("CreateEmailChange", ctx, mock.Anything).Return(utils.ErrEmailAlreadySignup).Once()
+
+		require.ErrorIs(t, service.RequestEmailChange(ctx, testUser.ID, "new@example.com", password), utils.ErrEmailAlreadySignup)
+		assert.Empty(t, mailer.sent)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		service, mockRepo, _, mockAttemptRepo, mailer := newTestService(t)
+		mockRepo.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil).Once()
+		mockAttemptRepo.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+		mockAttemptRepo.On("RegisterFailure", ctx, "account:"+testUser.ID, mock.Anything).Return(int32(1), nil).Once()
+
+		require.ErrorIs(t, service.RequestEmailChange(ctx, testUser.ID, "new@example.com", "WrongPassword"), utils.ErrInvalidPassword)
+		assert.Empty(t, mailer.sent)
+	})
+
+	t.Run("same address in another case", func(t *testing.T) {
+		service, mockRepo, _, _, _ := newTestService(t)
+		mockRepo.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil).Once()
+
+		require.ErrorIs(t, service.RequestEmailChange(ctx, testUser.ID, "Old@Example.com", password), utils.ErrInvalidEmail)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		service, _, _, _, _ := newTestService(t)
+		require.ErrorIs(t, service.RequestEmailChange(ctx, testUser.ID, "Jane <new@example.com>", password), utils.ErrInvalidEmail)
+	})
+}
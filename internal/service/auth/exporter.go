@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+// Exporter - раздел "sessions" выгрузки персональных данных: сессии пользователя с устройством и IP
+type Exporter struct {
+	service *Service
+}
+
+// NewExporter - выгрузить активные сессии, устройство и IP каждого входа
+func NewExporter(service *Service) *Exporter {
+	return &Exporter{service: service}
+}
+
+func (e *Exporter) Name() string {
+	return "sessions"
+}
+
+func (e *Exporter) Export(ctx context.Context, userID string) (any, error) {
+	sessions, err := e.service.Sessions(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ExportSession, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, model.ExportSession{
+			ID:           session.ID,
+			ClientID:     session.ClientID,
+			UserAgent:    session.UserAgent,
+			IP:           session.IP,
+			CreatedAt:    session.CreatedAt,
+			LastActiveAt: session.LastActiveAt,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// GenerateImpersonationToken - access токен пользователя userID с его ролями, в claim act которого указан администратор actorID
+// и его сессия actorSessionID. Токен живёт ttl, не продлевается и не привязан к сессии пользователя:
+// он действует, пока действуют токены администратора и у того есть право имперсонации.
+func (s *Service) GenerateImpersonationToken(ctx context.Context, userID, actorID, actorSessionID string, ttl time.Duration) (model.Impersonation, error) {
+	roles, err := s.roleRepository.GetUserRoles(ctx, userID)
+	if err != nil {
+		return model.Impersonation{}, err
+	}
+
+	tokenClaims := newClaims(userID, ttl)
+	tokenClaims.Roles = roles
+	tokenClaims.Actor = &actor{Subject: actorID, SessionID: actorSessionID}
+
+	accessToken, err := s.keys.sign(tokenClaims)
+	if err != nil {
+		return model.Impersonation{}, err
+	}
+
+	return model.Impersonation{
+		AccessToken: accessToken,
+		ExpiresAt:   tokenClaims.ExpiresAt.Time,
+	}, nil
+}
+
+// checkActor - отклонить токен имперсонации, если администратор вышел из сессии, в которой его выдал,
+// его токены отозваны целиком или у него больше нет права имперсонации
+func (s *Service) checkActor(ctx context.Context, tokenClaims *claims) error {
+	revoked, err := s.tokenRepository.IsAccessTokenRevoked(ctx, "", tokenClaims.Actor.Subject, tokenClaims.Actor.SessionID, tokenClaims.IssuedAt.Time)
+	if err != nil {
+		return err
+	}
+
+	if revoked {
+		return utils.ErrInvalidToken
+	}
+
+	roles, err := s.roleRepository.GetUserRoles(ctx, tokenClaims.Actor.Subject)
+	if err != nil {
+		return err
+	}
+
+	permissions, err := s.roleRepository.GetPermissions(ctx, roles)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(permissions, model.PermissionUsersImpersonate) {
+		return utils.ErrInvalidToken
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func TestGenerateImpersonationToken(t *testing.T) {
+	ctx := context.Background()
+	userID := "test-user-123"
+	actorID := "test-admin-456"
+	actorSessionID := "admin-session"
+
+	newToken := func(t *testing.T) (*Service, string, *repositoryMocks.MockTokenRepository, *repositoryMocks.MockRoleRepository) {
+		service, tokenRepo, roleRepo := newClientTestService(t)
+		roleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleUser}, nil).Once()
+
+		impersonation, err := service.GenerateImpersonationToken(ctx, userID, actorID, actorSessionID, 15*time.Minute)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), impersonation.ExpiresAt, time.Minute)
+
+		// no session of the user: nothing to touch and the user's session revocation does not end it
+		tokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, "", mock.Anything).Return(false, nil).Once()
+		return service, impersonation.AccessToken, tokenRepo, roleRepo
+	}
+
+	t.Run("valid while the admin session is", func(t *testing.T) {
+		service, token, tokenRepo, roleRepo := newToken(t)
+		tokenRepo.On("IsAccessTokenRevoked", ctx, "", actorID, actorSessionID, mock.Anything).Return(false, nil).Once()
+		roleRepo.On("GetUserRoles", ctx, actorID).Return([]string{model.RoleAdmin}, nil).Once()
+		roleRepo.On("GetPermissions", ctx, []string{model.RoleAdmin}).Return([]string{model.PermissionUsersImpersonate}, nil).Once()
+
+		principal, err := service.Authenticate(ctx, "Bearer "+token)
+		require.NoError(t, err)
+		assert.Equal(t, userID, principal.UserID)
+		assert.Equal(t, actorID, principal.ActorID)
+		assert.Equal(t, []string{model.RoleUser}, principal.Roles)
+		assert.Empty(t, principal.SessionID)
+	})
+
+	t.Run("admin session revoked", func(t *testing.T) {
+		service, token, tokenRepo, _ := newToken(t)
+		tokenRepo.On("IsAccessTokenRevoked", ctx, "", actorID, actorSessionID, mock.Anything).Return(true, nil).Once()
+
+		_, err := service.Authenticate(ctx, "Bearer "+token)
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	t.Run("admin lost the permission", func(t *testing.T) {
+		service, token, tokenRepo, roleRepo := newToken(t)
+		tokenRepo.On("IsAccessTokenRevoked", ctx, "", actorID, actorSessionID, mock.Anything).Return(false, nil).Once()
+		roleRepo.On("GetUserRoles", ctx, actorID).Return([]string{model.RoleUser}, nil).Once()
+		roleRepo.On("GetPermissions", ctx, []string{model.RoleUser}).Return([]string{model.PermissionUsersRead}, nil).Once()
+
+		_, err := service.Authenticate(ctx, "Bearer "+token)
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+}
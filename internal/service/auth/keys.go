@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    interface{}
+	jwk    model.JWK
+}
+
+// keySet - ключ подписи и все ключи, которыми ещё принимаются токены.
+// Асимметричные ключи идентифицируются заголовком kid, токены без kid
+// принимаются только если задан общий секрет HS256.
+type keySet struct {
+	kid          string
+	method       jwt.SigningMethod
+	signing      interface{}
+	secret       []byte
+	verification map[string]verificationKey
+}
+
+func newKeySet(cfg *infra.Config) (*keySet, error) {
+	keys := &keySet{
+		verification: make(map[string]verificationKey),
+	}
+
+	if cfg.JwtSecret != "" {
+		keys.secret = []byte(cfg.JwtSecret)
+		keys.method = jwt.SigningMethodHS256
+		keys.signing = keys.secret
+	}
+
+	if cfg.JwtPrivateKeyPath != "" {
+		signer, err := readPrivateKey(cfg.JwtPrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := newVerificationKey(signer.Public())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cfg.JwtPrivateKeyPath, err)
+		}
+
+		keys.kid = key.jwk.Kid
+		keys.method = key.method
+		keys.signing = signer
+		keys.verification[key.jwk.Kid] = key
+	}
+
+	for _, path := range cfg.JwtPublicKeyPaths {
+		public, err := readPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := newVerificationKey(public)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		keys.verification[key.jwk.Kid] = key
+	}
+
+	if keys.method == nil {
+		return nil, errors.New("either JWT_SECRET or JWT_PRIVATE_KEY_PATH is required")
+	}
+
+	return keys, nil
+}
+
+// sign - подписать claims текущим ключом, kid указывается для асимметричных ключей
+func (k *keySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.method, claims)
+	if k.kid != "" {
+		token.Header["kid"] = k.kid
+	}
+	return token.SignedString(k.signing)
+}
+
+// keyFunc - выбрать ключ проверки по kid и убедиться, что алгоритм токена ему соответствует
+func (k *keySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		if k.secret == nil || token.Method != jwt.SigningMethodHS256 {
+			return nil, utils.ErrInvalidToken
+		}
+		return k.secret, nil
+	}
+
+	key, ok := k.verification[kid]
+	if !ok || token.Method.Alg() != key.method.Alg() {
+		return nil, utils.ErrInvalidToken
+	}
+
+	return key.key, nil
+}
+
+func (k *keySet) publicKeys() []model.JWK {
+	result := make([]model.JWK, 0, len(k.verification))
+	// signing key goes first so clients that pick the first key get the current one
+	if key, ok := k.verification[k.kid]; ok {
+		result = append(result, key.jwk)
+	}
+	for kid, key := range k.verification {
+		if kid != k.kid {
+			result = append(result, key.jwk)
+		}
+	}
+	return result
+}
+
+func newVerificationKey(public crypto.PublicKey) (verificationKey, error) {
+	var key verificationKey
+	var thumbprintInput interface{}
+
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < 2048 {
+			return key, errors.New("RSA key must be at least 2048 bits")
+		}
+		key.method = jwt.SigningMethodRS256
+		key.jwk = model.JWK{
+			Kty: "RSA",
+			N:   encodeSegment(pub.N.Bytes()),
+			E:   encodeSegment(big.NewInt(int64(pub.E)).Bytes()),
+		}
+		thumbprintInput = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{key.jwk.E, key.jwk.Kty, key.jwk.N}
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return key, errors.New("only P-256 ECDSA keys are supported")
+		}
+		ecdh, err := pub.ECDH()
+		if err != nil {
+			return key, err
+		}
+		// uncompressed point: 0x04 || X || Y
+		point := ecdh.Bytes()
+		key.method = jwt.SigningMethodES256
+		key.jwk = model.JWK{
+			Kty: "EC",
+			Crv: "P-256",
+			X:   encodeSegment(point[1:33]),
+			Y:   encodeSegment(point[33:]),
+		}
+		thumbprintInput = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{key.jwk.Crv, key.jwk.Kty, key.jwk.X, key.jwk.Y}
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+		key.jwk = model.JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   encodeSegment(pub),
+		}
+		thumbprintInput = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{key.jwk.Crv, key.jwk.Kty, key.jwk.X}
+	default:
+		return key, fmt.Errorf("unsupported key type %T", public)
+	}
+
+	// kid is the RFC 7638 thumbprint, stable for the same key across restarts
+	thumbprint, err := json.Marshal(thumbprintInput)
+	if err != nil {
+		return key, err
+	}
+	sum := sha256.Sum256(thumbprint)
+
+	key.key = public
+	key.jwk.Kid = encodeSegment(sum[:])
+	key.jwk.Alg = key.method.Alg()
+	key.jwk.Use = "sig"
+	return key, nil
+}
+
+func readPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unexpected PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: unsupported private key", path)
+	}
+	return signer, nil
+}
+
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("%s: unexpected PEM block %q", path, block.Type)
+	}
+
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return public, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+	return block, nil
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
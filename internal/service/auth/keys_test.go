@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// writeKeyPair - сохранить приватный и публичный ключи в PEM файлы и вернуть их пути
+func writeKeyPair(t *testing.T, signer crypto.Signer) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(signer)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(signer.Public())
+	require.NoError(t, err)
+
+	privatePath := filepath.Join(dir, "private.pem")
+	publicPath := filepath.Join(dir, "public.pem")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0o600))
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600))
+
+	return privatePath, publicPath
+}
+
+func newKeyService(t *testing.T, cfg *infra.Config) *Service {
+	t.Helper()
+	mockRepo := repositoryMocks.NewMockUserRepository(t)
+	mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+	mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+	mockTokenRepo.On("IsAccessTokenRevoked", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(false, nil).Maybe()
+
+	service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+	return service
+}
+
+func TestAsymmetricSigning(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		signer crypto.Signer
+		alg    string
+	}{
+		{name: "RS256", signer: rsaKey, alg: "RS256"},
+		{name: "ES256", signer: ecKey, alg: "ES256"},
+		{name: "EdDSA", signer: edKey, alg: "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privatePath, _ := writeKeyPair(t, tt.signer)
+			service := newKeyService(t, &infra.Config{JwtPrivateKeyPath: privatePath})
+			ctx := context.Background()
+
+			token, err := service.GenerateToken("user-123")
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.alg, parsed.Method.Alg())
+
+			keys := service.PublicKeys()
+			require.Len(t, keys, 1)
+			assert.Equal(t, keys[0].Kid, parsed.Header["kid"])
+			assert.Equal(t, tt.alg, keys[0].Alg)
+			assert.Equal(t, "sig", keys[0].Use)
+
+			userID, err := service.VerifyToken(ctx, "Bearer "+token)
+			require.NoError(t, err)
+			assert.Equal(t, "user-123", userID)
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	ctx := context.Background()
+
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	oldPrivate, oldPublic := writeKeyPair(t, oldKey)
+	newPrivate, _ := writeKeyPair(t, newKey)
+
+	oldService := newKeyService(t, &infra.Config{JwtPrivateKeyPath: oldPrivate})
+	rotatedService := newKeyService(t, &infra.Config{
+		JwtPrivateKeyPath: newPrivate,
+		JwtPublicKeyPaths: []string{oldPublic},
+	})
+	newOnlyService := newKeyService(t, &infra.Config{JwtPrivateKeyPath: newPrivate})
+
+	oldToken, err := oldService.GenerateToken("user-123")
+	require.NoError(t, err)
+
+	// token signed with the previous key is still accepted during rotation
+	userID, err := rotatedService.VerifyToken(ctx, oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", userID)
+
+	// once the old key is dropped its tokens are rejected
+	_, err = newOnlyService.VerifyToken(ctx, oldToken)
+	require.ErrorIs(t, err, utils.ErrInvalidToken)
+
+	keys := rotatedService.PublicKeys()
+	require.Len(t, keys, 2)
+	assert.Equal(t, "ES256", keys[0].Alg, "signing key must be listed first")
+	assert.Equal(t, oldService.PublicKeys()[0].Kid, keys[1].Kid)
+}
+
+func TestRejectsAlgorithmConfusion(t *testing.T) {
+	ctx := context.Background()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privatePath, publicPath := writeKeyPair(t, rsaKey)
+	service := newKeyService(t, &infra.Config{JwtPrivateKeyPath: privatePath})
+	kid := service.PublicKeys()[0].Kid
+
+	publicPEM, err := os.ReadFile(publicPath)
+	require.NoError(t, err)
+
+	// HS256 token "signed" with the public key and pointing at the RSA kid
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ID:        "forged",
+		Subject:   "admin",
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	forged.Header["kid"] = kid
+	forgedToken, err := forged.SignedString(publicPEM)
+	require.NoError(t, err)
+
+	_, err = service.VerifyToken(ctx, forgedToken)
+	require.ErrorIs(t, err, utils.ErrInvalidToken)
+
+	// token without kid is not accepted when no shared secret is configured
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ID:        "forged",
+		Subject:   "admin",
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	unsignedToken, err := unsigned.SignedString([]byte("guess"))
+	require.NoError(t, err)
+
+	_, err = service.VerifyToken(ctx, unsignedToken)
+	require.ErrorIs(t, err, utils.ErrInvalidToken)
+}
+
+func TestNewServiceKeyErrors(t *testing.T) {
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	weakPrivate, _ := writeKeyPair(t, weakKey)
+
+	tests := []struct {
+		name string
+		cfg  *infra.Config
+	}{
+		{name: "no keys configured", cfg: &infra.Config{}},
+		{name: "missing key file", cfg: &infra.Config{JwtPrivateKeyPath: filepath.Join(t.TempDir(), "missing.pem")}},
+		{name: "weak RSA key", cfg: &infra.Config{JwtPrivateKeyPath: weakPrivate}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := NewService(tt.cfg, repositoryMocks.NewMockUserRepository(t), repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+			require.Error(t, err)
+			assert.Nil(t, service)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// maxLockoutShift - ограничение степени двойки, чтобы сдвиг не переполнился
+const maxLockoutShift = 16
+
+// lockoutPolicy - пороги и длительность блокировок входа
+type lockoutPolicy struct {
+	maxFailures      int32
+	maxFailuresPerIP int32
+	window           time.Duration
+	base             time.Duration
+	max              time.Duration
+}
+
+// limit - счётчик неудачных попыток и порог, после которого он блокируется
+type limit struct {
+	subject     string
+	maxFailures int32
+}
+
+func accountSubject(userID string) string {
+	return "account:" + userID
+}
+
+func ipSubject(ip string) string {
+	return "ip:" + ip
+}
+
+// UnlockAccount - снять блокировку входа и сбросить счётчик неудач пользователя
+func (s *Service) UnlockAccount(ctx context.Context, userID string) error {
+	if _, err := s.repository.GetUserByID(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.attemptRepository.Reset(ctx, accountSubject(userID))
+}
+
+// PurgeLoginAttempts - удалить забытые счётчики неудачных попыток и отправленных писем
+func (s *Service) PurgeLoginAttempts(ctx context.Context) error {
+	_, err := s.attemptRepository.DeleteStale(ctx, time.Now().Add(-s.lockout.window))
+	return err
+}
+
+func (s *Service) accountLimit(userID string) limit {
+	return limit{subject: accountSubject(userID), maxFailures: s.lockout.maxFailures}
+}
+
+// ipLimits - счётчик клиентского IP, пустой если IP неизвестен
+func (s *Service) ipLimits(ip string) []limit {
+	if ip == "" {
+		return nil
+	}
+	return []limit{{subject: ipSubject(ip), maxFailures: s.lockout.maxFailuresPerIP}}
+}
+
+// checkLockout - вернуть LockoutError, если хотя бы один из счётчиков заблокирован
+func (s *Service) checkLockout(ctx context.Context, limits ...limit) error {
+	if len(limits) == 0 {
+		return nil
+	}
+
+	subjects := make([]string, 0, len(limits))
+	for _, l := range limits {
+		subjects = append(subjects, l.subject)
+	}
+
+	lockedUntil, err := s.attemptRepository.GetLockedUntil(ctx, subjects)
+	if err != nil {
+		return err
+	}
+
+	if retryAfter := time.Until(lockedUntil); retryAfter > 0 {
+		return &utils.LockoutError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// loginFailed - учесть неудачную попытку и вернуть причину отказа
+func (s *Service) loginFailed(ctx context.Context, reason error, limits ...limit) error {
+	if err := s.registerFailure(ctx, limits...); err != nil {
+		return err
+	}
+	return reason
+}
+
+// registerFailure - учесть неудачную попытку. Начиная с порога каждая следующая неудача
+// блокирует счётчик вдвое дольше предыдущей, но не дольше lockout.max.
+func (s *Service) registerFailure(ctx context.Context, limits ...limit) error {
+	now := time.Now()
+	for _, l := range limits {
+		failures, err := s.attemptRepository.RegisterFailure(ctx, l.subject, now.Add(-s.lockout.window))
+		if err != nil {
+			return err
+		}
+
+		// zero threshold disables the lockout
+		if l.maxFailures <= 0 || failures < l.maxFailures {
+			continue
+		}
+
+		duration := s.lockout.base << min(failures-l.maxFailures, maxLockoutShift)
+		if duration <= 0 || duration > s.lockout.max {
+			duration = s.lockout.max
+		}
+
+		if err = s.attemptRepository.Lock(ctx, l.subject, now.Add(duration)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mailSubject - счётчик писем kind на адрес, почта хранится только хешем
+func mailSubject(kind, email string) string {
+	return kind + ":" + utils.HashToken(strings.ToLower(strings.TrimSpace(email)))
+}
+
+// limitMails - не больше mailLimits[kind] писем kind на адрес за окно счётчика неудачных входов.
+// Вызывается до поиска пользователя, чтобы лимит не выдавал зарегистрированные адреса
+func (s *Service) limitMails(ctx context.Context, kind, email string) error {
+	maxSent := s.mailLimits[kind]
+	if maxSent <= 0 {
+		return nil
+	}
+
+	sent, windowStart, err := s.attemptRepository.RegisterSend(ctx, mailSubject(kind, email), time.Now().Add(-s.lockout.window))
+	if err != nil {
+		return err
+	}
+
+	if sent > maxSent {
+		return &utils.LockoutError{RetryAfter: time.Until(windowStart.Add(s.lockout.window))}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/argon2id"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func newLockoutConfig() *infra.Config {
+	return &infra.Config{
+		JwtSecret:             "test-secret",
+		RefreshTokenTTL:       time.Hour,
+		LoginMaxFailures:      5,
+		LoginMaxFailuresPerIP: 20,
+		LoginFailureWindow:    time.Hour,
+		LoginLockoutBase:      time.Minute,
+		LoginLockoutMax:       30 * time.Minute,
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	ctx := context.Background()
+	userID := "test-user-123"
+	ip := "203.0.113.7"
+	subjects := []string{"ip:" + ip, "account:" + userID}
+
+	passwordHash, err := argon2id.CreateHash("SecurePassword123", argon2id.DefaultParams)
+	require.NoError(t, err)
+	testUser := queries.User{ID: userID, Email: "test@example.com", PasswordHash: passwordHash}
+
+	t.Run("locked account skips password check", func(t *testing.T) {
+		mockRepo := repositoryMocks.NewMockUserRepository(t)
+		mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+		service, err := NewService(newLockoutConfig(), mockRepo, repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), mockAttemptRepo, nil, nil, testHasher, nil, testLogger)
+		require.NoError(t, err)
+
+		mockRepo.On("GetUserByEmail", ctx, testUser.Email).Return(testUser, nil).Once()
+		mockAttemptRepo.On("GetLockedUntil", ctx, subjects).Return(time.Now().Add(10*time.Minute), nil).Once()
+
+		_, err = service.Login(ctx, testUser.Email, "SecurePassword123", ip)
+		require.ErrorIs(t, err, utils.ErrTooManyAttempts)
+
+		var lockout *utils.LockoutError
+		require.ErrorAs(t, err, &lockout)
+		assert.InDelta(t, 10*time.Minute, lockout.RetryAfter, float64(time.Minute))
+	})
+
+	t.Run("unknown email counts against ip only", func(t *testing.T) {
+		mockRepo := repositoryMocks.NewMockUserRepository(t)
+		mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+		service, err := NewService(newLockoutConfig(), mockRepo, repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), mockAttemptRepo, nil, nil, testHasher, nil, testLogger)
+		require.NoError(t, err)
+
+		mockRepo.On("GetUserByEmail", ctx, "missing@example.com").Return(queries.User{}, pgx.ErrNoRows).Twice()
+		mockAttemptRepo.On("GetLockedUntil", ctx, []string{"ip:" + ip}).Return(time.Time{}, nil).Once()
+		mockAttemptRepo.On("RegisterFailure", ctx, "ip:"+ip, mock.Anything).Return(int32(1), nil).Once()
+
+		_, err = service.Login(ctx, "missing@example.com", "whatever", ip)
+		assert.ErrorIs(t, err, utils.ErrInvalidUser)
+	})
+
+	tests := []struct {
+		name             string
+		failures         int32
+		expectedDuration time.Duration
+	}{
+		{name: "below threshold", failures: 4},
+		{name: "threshold reached", failures: 5, expectedDuration: time.Minute},
+		{name: "lockout doubles", failures: 7, expectedDuration: 4 * time.Minute},
+		{name: "lockout capped", failures: 40, expectedDuration: 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repositoryMocks.NewMockUserRepository(t)
+			mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+			service, err := NewService(newLockoutConfig(), mockRepo, repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), mockAttemptRepo, nil, nil, testHasher, nil, testLogger)
+			require.NoError(t, err)
+
+			mockRepo.On("GetUserByEmail", ctx, testUser.Email).Return(testUser, nil).Twice()
+			mockAttemptRepo.On("GetLockedUntil", ctx, subjects).Return(time.Time{}, nil).Once()
+			mockAttemptRepo.On("RegisterFailure", ctx, "ip:"+ip, mock.Anything).Return(int32(1), nil).Once()
+			mockAttemptRepo.On("RegisterFailure", ctx, "account:"+userID, mock.Anything).Return(tt.failures, nil).Once()
+			if tt.expectedDuration > 0 {
+				mockAttemptRepo.On("Lock", ctx, "account:"+userID, mock.MatchedBy(func(lockedUntil time.Time) bool {
+					return (time.Until(lockedUntil) - tt.expectedDuration).Abs() < time.Second
+				})).Return(nil).Once()
+			}
+
+			_, err = service.Login(ctx, testUser.Email, "WrongPassword", ip)
+			assert.ErrorIs(t, err, utils.ErrInvalidPassword)
+		})
+	}
+}
+
+func TestUnlockAccount(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		userErr       error
+		expectedError error
+	}{
+		{name: "existing user"},
+		{name: "unknown user", userErr: pgx.ErrNoRows, expectedError: pgx.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repositoryMocks.NewMockUserRepository(t)
+			mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+			service, err := NewService(newLockoutConfig(), mockRepo, repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), mockAttemptRepo, nil, nil, testHasher, nil, testLogger)
+			require.NoError(t, err)
+
+			mockRepo.On("GetUserByID", ctx, "user-1").Return(queries.User{ID: "user-1"}, tt.userErr).Once()
+			if tt.userErr == nil {
+				mockAttemptRepo.On("Reset", ctx, "account:user-1").Return(nil).Once()
+			}
+
+			err = service.UnlockAccount(ctx, "user-1")
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
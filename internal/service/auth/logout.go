@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// Logout - отозвать текущий access токен и, если передан, refresh токен этого входа
+func (s *Service) Logout(ctx context.Context, authHeader, refreshToken string) error {
+	tokenClaims, err := s.parseToken(authHeader)
+	if err != nil {
+		return err
+	}
+
+	if err = s.checkRevoked(ctx, tokenClaims); err != nil {
+		return err
+	}
+
+	if err = s.tokenRepository.RevokeAccessToken(ctx, tokenClaims.ID, tokenClaims.Subject, tokenClaims.ExpiresAt.Time); err != nil {
+		return err
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := s.tokenRepository.GetRefreshTokenByHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	// refresh token of another user must not be touched
+	if stored.UserID != tokenClaims.Subject {
+		return utils.ErrInvalidToken
+	}
+
+	return s.tokenRepository.RevokeRefreshTokenFamily(ctx, stored.FamilyID)
+}
+
+// LogoutAll - отозвать все выданные пользователю access и refresh токены
+func (s *Service) LogoutAll(ctx context.Context, userID string) error {
+	now := time.Now()
+
+	// revocation record is needed only while tokens issued before it are still alive
+	return s.tokenRepository.RevokeUserTokens(ctx, userID, now, now.Add(s.expires))
+}
+
+// PurgeExpiredTokens - удалить истёкшие записи об отзыве, чтобы хранилище не росло бесконечно
+func (s *Service) PurgeExpiredTokens(ctx context.Context) error {
+	_, err := s.tokenRepository.DeleteExpired(ctx)
+	return err
+}
+
+func (s *Service) checkRevoked(ctx context.Context, tokenClaims *claims) error {
+	revoked, err := s.tokenRepository.IsAccessTokenRevoked(ctx, tokenClaims.ID, tokenClaims.Subject, tokenClaims.SessionID, tokenClaims.IssuedAt.Time)
+	if err != nil {
+		return err
+	}
+
+	if revoked {
+		return utils.ErrInvalidToken
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func TestLogout(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", RefreshTokenTTL: time.Hour}
+	ctx := context.Background()
+	userID := "test-user-123"
+
+	rawRefresh := "refresh-token"
+	storedRefresh := queries.RefreshToken{
+		ID:        "token-1",
+		UserID:    userID,
+		FamilyID:  "family-1",
+		TokenHash: utils.HashToken(rawRefresh),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+	foreignRefresh := storedRefresh
+	foreignRefresh.UserID = "another-user"
+
+	tests := []struct {
+		name          string
+		refreshToken  string
+		mockSetup     func(*repositoryMocks.MockTokenRepository)
+		expectedError error
+	}{
+		{
+			name:         "access token only",
+			refreshToken: "",
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).
+					Return(false, nil).Once()
+				mockTokenRepo.On("RevokeAccessToken", ctx, mock.Anything, userID, mock.Anything).
+					Return(nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name:         "access and refresh tokens",
+			refreshToken: rawRefresh,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).
+					Return(false, nil).Once()
+				mockTokenRepo.On("RevokeAccessToken", ctx, mock.Anything, userID, mock.Anything).
+					Return(nil).Once()
+				mockTokenRepo.On("GetRefreshTokenByHash", ctx, storedRefresh.TokenHash).
+					Return(storedRefresh, nil).Once()
+				mockTokenRepo.On("RevokeRefreshTokenFamily", ctx, "family-1").
+					Return(nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name:         "unknown refresh token is ignored",
+			refreshToken: rawRefresh,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).
+					Return(false, nil).Once()
+				mockTokenRepo.On("RevokeAccessToken", ctx, mock.Anything, userID, mock.Anything).
+					Return(nil).Once()
+				mockTokenRepo.On("GetRefreshTokenByHash", ctx, storedRefresh.TokenHash).
+					Return(queries.RefreshToken{}, pgx.ErrNoRows).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name:         "refresh token of another user",
+			refreshToken: rawRefresh,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).
+					Return(false, nil).Once()
+				mockTokenRepo.On("RevokeAccessToken", ctx, mock.Anything, userID, mock.Anything).
+					Return(nil).Once()
+				mockTokenRepo.On("GetRefreshTokenByHash", ctx, storedRefresh.TokenHash).
+					Return(foreignRefresh, nil).Once()
+			},
+			expectedError: utils.ErrInvalidToken,
+		},
+		{
+			name:         "already revoked access token",
+			refreshToken: "",
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).
+					Return(true, nil).Once()
+			},
+			expectedError: utils.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repositoryMocks.NewMockUserRepository(t)
+			mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+			mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+			mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+			tt.mockSetup(mockTokenRepo)
+			service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+			require.NoError(t, err)
+
+			token, err := service.GenerateToken(userID)
+			require.NoError(t, err)
+
+			err = service.Logout(ctx, "Bearer "+token, tt.refreshToken)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			mockTokenRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret"}
+	ctx := context.Background()
+	mockRepo := repositoryMocks.NewMockUserRepository(t)
+	mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+	mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+	service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+	userID := "test-user-123"
+
+	mockTokenRepo.On("RevokeUserTokens", ctx, userID, mock.Anything, mock.MatchedBy(func(expiresAt time.Time) bool {
+		// revocation must outlive every access token issued before it
+		return expiresAt.After(time.Now().Add(service.expires - time.Minute))
+	})).Return(nil).Once()
+
+	require.NoError(t, service.LogoutAll(ctx, userID))
+}
+
+func TestVerifyRevokedToken(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret"}
+	ctx := context.Background()
+	mockRepo := repositoryMocks.NewMockUserRepository(t)
+	mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+	mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+	service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+	userID := "test-user-123"
+
+	token, err := service.GenerateToken(userID)
+	require.NoError(t, err)
+
+	mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).
+		Return(true, nil).Once()
+
+	extractedID, err := service.VerifyToken(ctx, "Bearer "+token)
+	require.ErrorIs(t, err, utils.ErrInvalidToken)
+	assert.Empty(t, extractedID)
+}
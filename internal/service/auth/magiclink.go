@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const magicLinkMailBody = `Log in to your account by opening the link below on the same device where you asked for it:
+
+%s
+
+The link is valid for %s and can be used once. If it was not you, ignore this email.
+`
+
+// SendMagicLink - отправить ссылку для входа без пароля.
+// Возвращается секрет устройства: ссылка принимается только вместе с ним, поэтому пересланное
+// письмо не сработает на другом устройстве. Для неизвестных адресов секрет тоже возвращается,
+// а письмо не отправляется, чтобы нельзя было перебирать зарегистрированные почты.
+func (s *Service) SendMagicLink(ctx context.Context, email string) (string, error) {
+	// counted before the lookup, so the limit does not reveal registered addresses either
+	if err := s.limitMails(ctx, purposeMagicLink, email); err != nil {
+		return "", err
+	}
+
+	device := rand.Text()
+
+	user, err := s.repository.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return device, nil
+		}
+		return "", err
+	}
+
+	tokenClaims := newClaims(user.ID, s.magicLinkExpires)
+	tokenClaims.Email = user.Email
+	tokenClaims.Device = utils.HashToken(device)
+
+	token, err := s.signPurposeToken(tokenClaims, purposeMagicLink)
+	if err != nil {
+		return "", err
+	}
+
+	link := s.publicURL + "/magic-link?token=" + url.QueryEscape(token)
+	if err = s.mailer.Send(ctx, infra.Mail{
+		To:      user.Email,
+		Subject: "Your login link",
+		Body:    fmt.Sprintf(magicLinkMailBody, link, s.magicLinkExpires),
+	}); err != nil {
+		return "", err
+	}
+
+	return device, nil
+}
+
+// LoginMagicLink - войти по ссылке из письма с устройства, на котором она запрошена.
+// Ссылка одноразовая и подтверждает почту. Как и в Login, при включённой 2FA вместо токенов возвращается challenge.
+func (s *Service) LoginMagicLink(ctx context.Context, token, device string) (model.LoginResult, error) {
+	tokenClaims, err := s.parsePurposeToken(token, purposeMagicLink)
+	if err != nil {
+		return model.LoginResult{}, err
+	}
+
+	// a forwarded link is rejected before it is spent, so the owner can still use it
+	if device == "" || subtle.ConstantTimeCompare([]byte(utils.HashToken(device)), []byte(tokenClaims.Device)) != 1 {
+		return model.LoginResult{}, utils.ErrInvalidToken
+	}
+
+	if err = s.consumeToken(ctx, tokenClaims); err != nil {
+		return model.LoginResult{}, err
+	}
+
+	user, err := s.repository.GetUserByID(ctx, tokenClaims.Subject)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.LoginResult{}, utils.ErrInvalidToken
+		}
+		return model.LoginResult{}, err
+	}
+
+	// the address changed since the link was sent
+	if user.Email != tokenClaims.Email {
+		return model.LoginResult{}, utils.ErrInvalidToken
+	}
+
+	if !user.EmailVerifiedAt.Valid {
+		if _, err = s.repository.VerifyEmail(ctx, user.ID, user.Email); err != nil {
+			return model.LoginResult{}, err
+		}
+	}
+
+	return s.completeLogin(ctx, user.ID)
+}
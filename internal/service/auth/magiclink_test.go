@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+var magicLinkToken = regexp.MustCompile(`/magic-link\?token=(\S+)`)
+
+type magicLinkEnv struct {
+	service    *Service
+	repository *repositoryMocks.MockUserRepository
+	tokens     *repositoryMocks.MockTokenRepository
+	roles      *repositoryMocks.MockRoleRepository
+	twoFactor  *repositoryMocks.MockTwoFactorRepository
+	attempts   *repositoryMocks.MockAttemptRepository
+	mailer     *recordingMailer
+	magicLimit int32
+	failWindow time.Duration
+	testUser   queries.User
+}
+
+func newMagicLinkEnv(t *testing.T) *magicLinkEnv {
+	t.Helper()
+
+	env := &magicLinkEnv{
+		repository: repositoryMocks.NewMockUserRepository(t),
+		tokens:     repositoryMocks.NewMockTokenRepository(t),
+		roles:      repositoryMocks.NewMockRoleRepository(t),
+		twoFactor:  repositoryMocks.NewMockTwoFactorRepository(t),
+		attempts:   repositoryMocks.NewMockAttemptRepository(t),
+		mailer:     &recordingMailer{},
+		magicLimit: 3,
+		failWindow: time.Hour,
+		testUser:   queries.User{ID: "test-user-123", Email: "test@example.com"},
+	}
+
+	cfg := &infra.Config{
+		JwtSecret:            "test-secret",
+		PublicURL:            "https://example.com",
+		MagicLinkTTL:         15 * time.Minute,
+		MagicLinkMaxPerEmail: env.magicLimit,
+		LoginFailureWindow:   env.failWindow,
+	}
+
+	service, err := NewService(cfg, env.repository, env.tokens, env.roles, env.twoFactor, env.attempts, env.mailer, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+	env.service = service
+
+	return env
+}
+
+// send - запросить ссылку и вернуть секрет устройства и токен из письма
+func (env *magicLinkEnv) send(t *testing.T) (string, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	env.attempts.On("RegisterSend", ctx, "magic_link:"+utils.HashToken("test@example.com"), mock.Anything).Return(int32(1), time.Now(), nil).Once()
+	env.repository.On("GetUserByEmail", ctx, "Test@Example.com").Return(env.testUser, nil).Once()
+
+	device, err := env.service.SendMagicLink(ctx, "Test@Example.com")
+	require.NoError(t, err)
+	require.NotEmpty(t, device)
+	require.Len(t, env.mailer.sent, 1)
+	assert.Equal(t, env.testUser.Email, env.mailer.sent[0].To)
+
+	match := magicLinkToken.FindStringSubmatch(env.mailer.sent[0].Body)
+	require.Len(t, match, 2)
+	token, err := url.QueryUnescape(match[1])
+	require.NoError(t, err)
+
+	// the link alone does not reveal the device secret
+	assert.NotContains(t, token, device)
+
+	return device, token
+}
+
+func TestSendMagicLink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown email is not reported", func(t *testing.T) {
+		env := newMagicLinkEnv(t)
+		env.attempts.On("RegisterSend", ctx, "magic_link:"+utils.HashToken("nobody@example.com"), mock.Anything).Return(int32(1), time.Now(), nil).Once()
+		env.repository.On("GetUserByEmail", ctx, "nobody@example.com").Return(queries.User{}, pgx.ErrNoRows).Once()
+
+		device, err := env.service.SendMagicLink(ctx, "nobody@example.com")
+		require.NoError(t, err)
+		assert.NotEmpty(t, device)
+		assert.Empty(t, env.mailer.sent)
+	})
+
+	t.Run("last allowed link is sent", func(t *testing.T) {
+		env := newMagicLinkEnv(t)
+		env.attempts.On("RegisterSend", ctx, "magic_link:"+utils.HashToken("test@example.com"), mock.MatchedBy(func(windowStart time.Time) bool {
+			return windowStart.Before(time.Now().Add(-env.failWindow + time.Minute))
+		})).Return(env.magicLimit, time.Now().Add(-time.Minute), nil).Once()
+		env.repository.On("GetUserByEmail", ctx, "test@example.com").Return(env.testUser, nil).Once()
+
+		_, err := env.service.SendMagicLink(ctx, "test@example.com")
+		require.NoError(t, err)
+		assert.Len(t, env.mailer.sent, 1)
+	})
+
+	t.Run("address over the limit", func(t *testing.T) {
+		env := newMagicLinkEnv(t)
+		env.attempts.On("RegisterSend", ctx, "magic_link:"+utils.HashToken("test@example.com"), mock.Anything).Return(env.magicLimit+1, time.Now().Add(-time.Minute), nil).Once()
+
+		_, err := env.service.SendMagicLink(ctx, "test@example.com")
+		var lockout *utils.LockoutError
+		require.ErrorAs(t, err, &lockout)
+		// the window runs from the first link, repeated requests do not extend it
+		assert.InDelta(t, env.failWindow-time.Minute, lockout.RetryAfter, float64(time.Second))
+		assert.Empty(t, env.mailer.sent)
+	})
+}
+
+func TestLoginMagicLink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful login verifies the email", func(t *testing.T) {
+		env := newMagicLinkEnv(t)
+		device, token := env.send(t)
+
+		env.tokens.On("IsAccessTokenRevoked", ctx, mock.Anything, env.testUser.ID, mock.Anything, mock.Anything).Return(false, nil).Once()
+		env.tokens.On("RevokeAccessToken", ctx, mock.Anything, env.testUser.ID, mock.Anything).Return(nil).Once()
+		env.repository.On("GetUserByID", ctx, env.testUser.ID).Return(env.testUser, nil).Once()
+		env.repository.On("VerifyEmail", ctx, env.testUser.ID, env.testUser.Email).Return(true, nil).Once()
+		env.twoFactor.On("GetTOTP", ctx, env.testUser.ID).Return(queries.UserTotp{}, pgx.ErrNoRows).Once()
+		env.roles.On("GetUserRoles", ctx, env.testUser.ID).Return([]string{model.RoleUser}, nil).Once()
+		env.tokens.On("CreateSession", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := env.service.LoginMagicLink(ctx, token, device)
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.Tokens.AccessToken)
+		assert.Empty(t, result.ChallengeToken)
+	})
+
+	t.Run("two-factor users get a challenge", func(t *testing.T) {
+		env := newMagicLinkEnv(t)
+		env.testUser.EmailVerifiedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		device, token := env.send(t)
+
+		env.tokens.On("IsAccessTokenRevoked", ctx, mock.Anything, env.testUser.ID, mock.Anything, mock.Anything).Return(false, nil).Once()
+		env.tokens.On("RevokeAccessToken", ctx, mock.Anything, env.testUser.ID, mock.Anything).Return(nil).Once()
+		env.repository.On("GetUserByID", ctx, env.testUser.ID).Return(env.testUser, nil).Once()
+		env.twoFactor.On("GetTOTP", ctx, env.testUser.ID).Return(queries.UserTotp{
+			ConfirmedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		}, nil).Once()
+
+		result, err := env.service.LoginMagicLink(ctx, token, device)
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.ChallengeToken)
+		assert.Empty(t, result.Tokens.AccessToken)
+	})
+
+	t.Run("forwarded link is rejected without spending it", func(t *testing.T) {
+		env := newMagicLinkEnv(t)
+		_, token := env.send(t)
+
+		_, err := env.service.LoginMagicLink(ctx, token, "another-device")
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+
+		_, err = env.service.LoginMagicLink(ctx, token, "")
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	t.Run("used link", func(t *testing.T) {
+		env := newMagicLinkEnv(t)
+		device, token := env.send(t)
+
+		env.tokens.On("IsAccessTokenRevoked", ctx, mock.Anything, env.testUser.ID, mock.Anything, mock.Anything).Return(true, nil).Once()
+
+		_, err := env.service.LoginMagicLink(ctx, token, device)
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	t.Run("email changed since the link was sent", func(t *testing.T) {
+		env := newMagicLinkEnv(t)
+		device, token := env.send(t)
+
+		env.tokens.On("IsAccessTokenRevoked", ctx, mock.Anything, env.testUser.ID, mock.Anything, mock.Anything).Return(false, nil).Once()
+		env.tokens.On("RevokeAccessToken", ctx, mock.Anything, env.testUser.ID, mock.Anything).Return(nil).Once()
+		env.repository.On("GetUserByID", ctx, env.testUser.ID).Return(queries.User{ID: env.testUser.ID, Email: "new@example.com"}, nil).Once()
+
+		_, err := env.service.LoginMagicLink(ctx, token, device)
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	t.Run("token of another purpose", func(t *testing.T) {
+		env := newMagicLinkEnv(t)
+		challenge, err := env.service.generateChallenge(env.testUser.ID)
+		require.NoError(t, err)
+
+		_, err = env.service.LoginMagicLink(ctx, challenge, "device")
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const resetMailBody = `Someone asked to reset the password of your account. Set a new password by opening the link below:
+
+%s
+
+The link is valid for %s and can be used once. If it was not you, ignore this email, your password stays the same.
+`
+
+const passwordChangedMailBody = `The password of your account was changed at %s.
+
+Device: %s
+IP address: %s
+
+If it was not you, reset your password right away and review the active sessions of your account.
+`
+
+// mailPasswordReset - вид писем со ссылкой сброса пароля в счётчике писем на адрес
+const mailPasswordReset = "password_reset"
+
+// ForgotPassword - отправить ссылку для сброса пароля.
+// Для неизвестных адресов ошибка не возвращается, чтобы нельзя было перебирать зарегистрированные почты.
+func (s *Service) ForgotPassword(ctx context.Context, email string) error {
+	// counted before the lookup, so the limit does not reveal registered addresses either
+	if err := s.limitMails(ctx, mailPasswordReset, email); err != nil {
+		return err
+	}
+
+	user, err := s.repository.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	rawToken := rand.Text()
+	if err = s.tokenRepository.CreatePasswordResetToken(ctx, queries.PasswordResetToken{
+		ID:        ulid.Make().String(),
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(rawToken),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(s.resetExpires), Valid: true},
+	}); err != nil {
+		return err
+	}
+
+	link := s.publicURL + "/reset-password?token=" + url.QueryEscape(rawToken)
+	return s.mailer.Send(ctx, infra.Mail{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    fmt.Sprintf(resetMailBody, link, s.resetExpires),
+	})
+}
+
+// ResetPassword - установить новый пароль по токену из письма.
+// Токен одноразовый, после сброса все сессии пользователя завершаются.
+func (s *Service) ResetPassword(ctx context.Context, token, password string) error {
+	if token == "" {
+		return utils.ErrInvalidToken
+	}
+
+	stored, err := s.tokenRepository.GetPasswordResetTokenByHash(ctx, utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrInvalidToken
+		}
+		return err
+	}
+
+	now := time.Now()
+	if stored.UsedAt.Valid || !stored.ExpiresAt.Time.After(now) {
+		return utils.ErrInvalidToken
+	}
+
+	user, err := s.repository.GetUserByID(ctx, stored.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.passwordPolicy.Check(password, user.Email); err != nil {
+		return err
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	return s.tokenRepository.ResetPassword(ctx, stored, passwordHash, now, now.Add(s.expires))
+}
+
+// ChangePassword - сменить пароль, зная текущий. Неверный текущий пароль учитывается в блокировке аккаунта,
+// новый проверяется парольной политикой. Ссылки сброса пароля перестают действовать.
+func (s *Service) ChangePassword(ctx context.Context, userID, sessionID string, change model.PasswordChange) error {
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.checkCurrentPassword(ctx, user, change.CurrentPassword); err != nil {
+		return err
+	}
+
+	if err = s.passwordPolicy.Check(change.NewPassword, user.Email); err != nil {
+		return err
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(change.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return s.tokenRepository.ChangePassword(ctx, user.ID, user.PasswordHash, passwordHash, sessionID, change.RevokeOtherSessions)
+}
+
+// NotifyPasswordChanged - сообщить на почту пользователя о смене пароля и устройстве, с которого он сменён
+func (s *Service) NotifyPasswordChanged(ctx context.Context, userID string) error {
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	client := model.ClientInfoFromContext(ctx)
+	return s.mailer.Send(ctx, infra.Mail{
+		To:      user.Email,
+		Subject: "Your password was changed",
+		Body:    fmt.Sprintf(passwordChangedMailBody, time.Now().UTC().Format(time.RFC1123), orUnknown(client.UserAgent), orUnknown(client.IP)),
+	})
+}
+
+// checkCurrentPassword - повторно проверить пароль вошедшего пользователя перед изменением учётных данных.
+// Неверный пароль учитывается в блокировке аккаунта, как неудачный вход
+func (s *Service) checkCurrentPassword(ctx context.Context, user queries.User, password string) error {
+	limit := s.accountLimit(user.ID)
+	if err := s.checkLockout(ctx, limit); err != nil {
+		return err
+	}
+
+	// users without a local password (directory, social login) cannot confirm anything with it
+	if user.PasswordHash == "" {
+		return utils.ErrInvalidPassword
+	}
+
+	if err := s.VerifyPassword(user, password); err != nil {
+		if errors.Is(err, utils.ErrInvalidPassword) {
+			return s.loginFailed(ctx, err, limit)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
@@ -0,0 +1,343 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/argon2id"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/policy"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+var resetLinkToken = regexp.MustCompile(`/reset-password\?token=(\S+)`)
+
+func TestForgotPassword(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", PasswordResetTTL: time.Hour, PublicURL: "https://example.com"}
+	ctx := context.Background()
+	testUser := queries.User{ID: "test-user-123", Email: "test@example.com"}
+
+	t.Run("link sent", func(t *testing.T) {
+		mockRepo := repositoryMocks.NewMockUserRepository(t)
+		mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+		mailer := &recordingMailer{}
+		service, err := NewService(cfg, mockRepo, mockTokenRepo, repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), repositoryMocks.NewMockAttemptRepository(t), mailer, nil, testHasher, nil, testLogger)
+		require.NoError(t, err)
+
+		var stored queries.PasswordResetToken
+		mockRepo.On("GetUserByEmail", ctx, "test@example.com").Return(testUser, nil).Once()
+		mockTokenRepo.On("CreatePasswordResetToken", ctx, mock.MatchedBy(func(token queries.PasswordResetToken) bool {
+			return token.UserID == testUser.ID && token.ExpiresAt.Time.After(time.Now())
+		})).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(queries.PasswordResetToken)
+		}).Return(nil).Once()
+
+		require.NoError(t, service.ForgotPassword(ctx, "test@example.com"))
+		require.Len(t, mailer.sent, 1)
+		assert.Equal(t, testUser.Email, mailer.sent[0].To)
+
+		match := resetLinkToken.FindStringSubmatch(mailer.sent[0].Body)
+		require.Len(t, match, 2)
+		rawToken, err := url.QueryUnescape(match[1])
+		require.NoError(t, err)
+
+		// only the hash is stored
+		assert.Equal(t, utils.HashToken(rawToken), stored.TokenHash)
+		assert.NotEqual(t, rawToken, stored.TokenHash)
+	})
+
+	t.Run("unknown email is not reported", func(t *testing.T) {
+		mockRepo := repositoryMocks.NewMockUserRepository(t)
+		mailer := &recordingMailer{}
+		service, err := NewService(cfg, mockRepo, repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), repositoryMocks.NewMockAttemptRepository(t), mailer, nil, testHasher, nil, testLogger)
+		require.NoError(t, err)
+
+		mockRepo.On("GetUserByEmail", ctx, "nobody@example.com").Return(queries.User{}, pgx.ErrNoRows).Once()
+
+		require.NoError(t, service.ForgotPassword(ctx, "nobody@example.com"))
+		assert.Empty(t, mailer.sent)
+	})
+
+	t.Run("address over the limit", func(t *testing.T) {
+		limited := *cfg
+		limited.PasswordResetMailMaxPerEmail = 2
+		limited.LoginFailureWindow = time.Hour
+		mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+		mailer := &recordingMailer{}
+		service, err := NewService(&limited, repositoryMocks.NewMockUserRepository(t), repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), mockAttemptRepo, mailer, nil, testHasher, nil, testLogger)
+		require.NoError(t, err)
+
+		// counted separately from login links and verification emails
+		mockAttemptRepo.On("RegisterSend", ctx, "password_reset:"+utils.HashToken("test@example.com"), mock.Anything).Return(int32(3), time.Now(), nil).Once()
+
+		var lockout *utils.LockoutError
+		require.ErrorAs(t, service.ForgotPassword(ctx, "test@example.com"), &lockout)
+		assert.Empty(t, mailer.sent)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret"}
+	ctx := context.Background()
+
+	rawToken := "reset-token"
+	tokenHash := utils.HashToken(rawToken)
+	newPassword := "NewSecurePassword456"
+
+	active := queries.PasswordResetToken{
+		ID:        "reset-1",
+		UserID:    "test-user-123",
+		TokenHash: tokenHash,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	testUser := queries.User{ID: "test-user-123", Email: "test@example.com"}
+
+	used := active
+	used.UsedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	expired := active
+	expired.ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+
+	tests := []struct {
+		name          string
+		token         string
+		password      string
+		mockSetup     func(*repositoryMocks.MockTokenRepository, *repositoryMocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name:  "successful reset",
+			token: rawToken,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository, mockRepo *repositoryMocks.MockUserRepository) {
+				mockTokenRepo.On("GetPasswordResetTokenByHash", ctx, tokenHash).Return(active, nil).Once()
+				mockRepo.On("GetUserByID", ctx, "test-user-123").Return(testUser, nil).Once()
+				mockTokenRepo.On("ResetPassword", ctx, active, mock.MatchedBy(func(passwordHash string) bool {
+					valid, err := argon2id.ComparePasswordAndHash(newPassword, passwordHash)
+					return err == nil && valid
+				}), mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:          "empty token",
+			token:         "",
+			mockSetup:     func(_ *repositoryMocks.MockTokenRepository, _ *repositoryMocks.MockUserRepository) {},
+			expectedError: utils.ErrInvalidToken,
+		},
+		{
+			name:  "unknown token",
+			token: rawToken,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository, _ *repositoryMocks.MockUserRepository) {
+				mockTokenRepo.On("GetPasswordResetTokenByHash", ctx, tokenHash).Return(queries.PasswordResetToken{}, pgx.ErrNoRows).Once()
+			},
+			expectedError: utils.ErrInvalidToken,
+		},
+		{
+			name:  "used token",
+			token: rawToken,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository, _ *repositoryMocks.MockUserRepository) {
+				mockTokenRepo.On("GetPasswordResetTokenByHash", ctx, tokenHash).Return(used, nil).Once()
+			},
+			expectedError: utils.ErrInvalidToken,
+		},
+		{
+			name:  "expired token",
+			token: rawToken,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository, _ *repositoryMocks.MockUserRepository) {
+				mockTokenRepo.On("GetPasswordResetTokenByHash", ctx, tokenHash).Return(expired, nil).Once()
+			},
+			expectedError: utils.ErrInvalidToken,
+		},
+		{
+			name:  "token used concurrently",
+			token: rawToken,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository, mockRepo *repositoryMocks.MockUserRepository) {
+				mockTokenRepo.On("GetPasswordResetTokenByHash", ctx, tokenHash).Return(active, nil).Once()
+				mockRepo.On("GetUserByID", ctx, "test-user-123").Return(testUser, nil).Once()
+				mockTokenRepo.On("ResetPassword", ctx, active, mock.Anything, mock.Anything, mock.Anything).
+					Return(utils.ErrInvalidToken).Once()
+			},
+			expectedError: utils.ErrInvalidToken,
+		},
+		{
+			name:     "password rejected by policy",
+			token:    rawToken,
+			password: "test1234",
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository, mockRepo *repositoryMocks.MockUserRepository) {
+				mockTokenRepo.On("GetPasswordResetTokenByHash", ctx, tokenHash).Return(active, nil).Once()
+				mockRepo.On("GetUserByID", ctx, "test-user-123").Return(testUser, nil).Once()
+			},
+			expectedError: utils.ErrWeakPassword,
+		},
+	}
+
+	passwordPolicy, err := policy.NewService(&infra.Config{PasswordMinLength: 8, PasswordMinCharClasses: 3})
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+			mockRepo := repositoryMocks.NewMockUserRepository(t)
+			tt.mockSetup(mockTokenRepo, mockRepo)
+			service, err := NewService(cfg, mockRepo, mockTokenRepo, repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), repositoryMocks.NewMockAttemptRepository(t), nil, passwordPolicy, testHasher, nil, testLogger)
+			require.NoError(t, err)
+
+			password := newPassword
+			if tt.password != "" {
+				password = tt.password
+			}
+			err = service.ResetPassword(ctx, tt.token, password)
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", LoginMaxFailures: 5, LoginLockoutBase: time.Minute, LoginLockoutMax: time.Hour}
+	ctx := context.Background()
+
+	currentPassword := "CurrentSecurePassword123"
+	newPassword := "NewSecurePassword456"
+	currentHash, err := testHasher.Hash(currentPassword)
+	require.NoError(t, err)
+
+	testUser := queries.User{ID: "test-user-123", Email: "test@example.com", PasswordHash: currentHash}
+	subject := []string{"account:" + testUser.ID}
+	newHashMatches := mock.MatchedBy(func(passwordHash string) bool {
+		valid, err := argon2id.ComparePasswordAndHash(newPassword, passwordHash)
+		return err == nil && valid
+	})
+
+	tests := []struct {
+		name          string
+		change        model.PasswordChange
+		user          queries.User
+		mockSetup     func(*repositoryMocks.MockTokenRepository, *repositoryMocks.MockAttemptRepository)
+		expectedError error
+	}{
+		{
+			name:   "successful change",
+			change: model.PasswordChange{CurrentPassword: currentPassword, NewPassword: newPassword},
+			user:   testUser,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository, mockAttemptRepo *repositoryMocks.MockAttemptRepository) {
+				mockAttemptRepo.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+				mockTokenRepo.On("ChangePassword", ctx, testUser.ID, currentHash, newHashMatches, "session-1", false).Return(nil).Once()
+			},
+		},
+		{
+			name:   "other sessions revoked",
+			change: model.PasswordChange{CurrentPassword: currentPassword, NewPassword: newPassword, RevokeOtherSessions: true},
+			user:   testUser,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository, mockAttemptRepo *repositoryMocks.MockAttemptRepository) {
+				mockAttemptRepo.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+				mockTokenRepo.On("ChangePassword", ctx, testUser.ID, currentHash, newHashMatches, "session-1", true).Return(nil).Once()
+			},
+		},
+		{
+			name:   "wrong current password counts as a failure",
+			change: model.PasswordChange{CurrentPassword: "WrongPassword", NewPassword: newPassword},
+			user:   testUser,
+			mockSetup: func(_ *repositoryMocks.MockTokenRepository, mockAttemptRepo *repositoryMocks.MockAttemptRepository) {
+				mockAttemptRepo.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+				mockAttemptRepo.On("RegisterFailure", ctx, "account:"+testUser.ID, mock.Anything).Return(int32(1), nil).Once()
+			},
+			expectedError: utils.ErrInvalidPassword,
+		},
+		{
+			name:   "locked account",
+			change: model.PasswordChange{CurrentPassword: currentPassword, NewPassword: newPassword},
+			user:   testUser,
+			mockSetup: func(_ *repositoryMocks.MockTokenRepository, mockAttemptRepo *repositoryMocks.MockAttemptRepository) {
+				mockAttemptRepo.On("GetLockedUntil", ctx, subject).Return(time.Now().Add(time.Minute), nil).Once()
+			},
+			expectedError: utils.ErrTooManyAttempts,
+		},
+		{
+			name:   "account without local password",
+			change: model.PasswordChange{CurrentPassword: "", NewPassword: newPassword},
+			user:   queries.User{ID: testUser.ID, Email: testUser.Email},
+			mockSetup: func(_ *repositoryMocks.MockTokenRepository, mockAttemptRepo *repositoryMocks.MockAttemptRepository) {
+				mockAttemptRepo.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+			},
+			expectedError: utils.ErrInvalidPassword,
+		},
+		{
+			name:   "new password rejected by policy",
+			change: model.PasswordChange{CurrentPassword: currentPassword, NewPassword: "test1234"},
+			user:   testUser,
+			mockSetup: func(_ *repositoryMocks.MockTokenRepository, mockAttemptRepo *repositoryMocks.MockAttemptRepository) {
+				mockAttemptRepo.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+			},
+			expectedError: utils.ErrWeakPassword,
+		},
+		{
+			name:   "password changed concurrently",
+			change: model.PasswordChange{CurrentPassword: currentPassword, NewPassword: newPassword},
+			user:   testUser,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository, mockAttemptRepo *repositoryMocks.MockAttemptRepository) {
+				mockAttemptRepo.On("GetLockedUntil", ctx, subject).Return(time.Time{}, nil).Once()
+				mockTokenRepo.On("ChangePassword", ctx, testUser.ID, currentHash, mock.Anything, "session-1", false).
+					Return(utils.ErrInvalidPassword).Once()
+			},
+			expectedError: utils.ErrInvalidPassword,
+		},
+	}
+
+	passwordPolicy, err := policy.NewService(&infra.Config{PasswordMinLength: 8, PasswordMinCharClasses: 3})
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repositoryMocks.NewMockUserRepository(t)
+			mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+			mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+			mockRepo.On("GetUserByID", ctx, testUser.ID).Return(tt.user, nil).Once()
+			tt.mockSetup(mockTokenRepo, mockAttemptRepo)
+			service, err := NewService(cfg, mockRepo, mockTokenRepo, repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), mockAttemptRepo, nil, passwordPolicy, testHasher, nil, testLogger)
+			require.NoError(t, err)
+
+			err = service.ChangePassword(ctx, testUser.ID, "session-1", tt.change)
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotifyPasswordChanged(t *testing.T) {
+	ctx := model.WithClientInfo(context.Background(), model.ClientInfo{IP: "203.0.113.7", UserAgent: "e2e-laptop"})
+	testUser := queries.User{ID: "test-user-123", Email: "test@example.com"}
+
+	mockRepo := repositoryMocks.NewMockUserRepository(t)
+	mailer := &recordingMailer{}
+	service, err := NewService(&infra.Config{JwtSecret: "test-secret"}, mockRepo, repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), repositoryMocks.NewMockAttemptRepository(t), mailer, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+
+	mockRepo.On("GetUserByID", ctx, testUser.ID).Return(testUser, nil).Once()
+
+	require.NoError(t, service.NotifyPasswordChanged(ctx, testUser.ID))
+	require.Len(t, mailer.sent, 1)
+	assert.Equal(t, testUser.Email, mailer.sent[0].To)
+	assert.Contains(t, mailer.sent[0].Body, "203.0.113.7")
+	assert.Contains(t, mailer.sent[0].Body, "e2e-laptop")
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// localProvider - проверка пароля по хешу из таблицы users
+type localProvider struct {
+	*Service
+}
+
+func (p localProvider) Name() string {
+	return model.AuthProviderLocal
+}
+
+// Authenticate - сравнить пароль с хешем пользователя и обновить устаревший хеш
+func (p localProvider) Authenticate(ctx context.Context, email, password string) (queries.User, error) {
+	user, err := p.repository.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return queries.User{}, utils.ErrInvalidUser
+		}
+		return queries.User{}, err
+	}
+
+	// users provisioned by OIDC or a directory have no password here
+	if user.PasswordHash == "" {
+		return queries.User{}, utils.ErrInvalidUser
+	}
+
+	needsRehash, err := p.verifyPassword(user, password)
+	if err != nil {
+		return queries.User{}, err
+	}
+
+	// the plain password is known only here, so outdated hashes are upgraded right after the check.
+	// The old hash keeps working, a failed upgrade is retried on the next login
+	if needsRehash {
+		if err = p.rehashPassword(ctx, user, password); err != nil {
+			p.logger.Warnw("password rehash failed", zap.String("user", user.ID), zap.Error(err))
+		}
+	}
+
+	// checked after the password so the endpoint does not reveal which addresses are unverified,
+	// a directory vouches for the email itself
+	if p.requireVerified && !user.EmailVerifiedAt.Valid {
+		return queries.User{}, utils.ErrEmailNotVerified
+	}
+
+	return user, nil
+}
+
+// newProviders - собрать провайдеров в порядке AUTH_PROVIDERS, по умолчанию только локальные пароли
+func newProviders(names []string, available ...service.AuthProvider) ([]service.AuthProvider, error) {
+	if len(names) == 0 {
+		names = []string{model.AuthProviderLocal}
+	}
+
+	byName := make(map[string]service.AuthProvider, len(available))
+	for _, provider := range available {
+		byName[provider.Name()] = provider
+	}
+
+	providers := make([]service.AuthProvider, 0, len(names))
+	for _, name := range names {
+		provider, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown auth provider %q", name)
+		}
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
+// authenticate - спросить провайдеров по порядку, решает первый, которому известен пользователь
+func (s *Service) authenticate(ctx context.Context, email, password string) (queries.User, error) {
+	for _, provider := range s.providers {
+		user, err := provider.Authenticate(ctx, email, password)
+		if errors.Is(err, utils.ErrInvalidUser) {
+			continue
+		}
+		return user, err
+	}
+
+	return queries.User{}, utils.ErrInvalidUser
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// stubProvider - провайдер с заранее заданным ответом
+type stubProvider struct {
+	name  string
+	user  queries.User
+	err   error
+	calls int
+}
+
+func (p *stubProvider) Name() string {
+	return p.name
+}
+
+func (p *stubProvider) Authenticate(_ context.Context, _, _ string) (queries.User, error) {
+	p.calls++
+	return p.user, p.err
+}
+
+func TestNewServiceUnknownProvider(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", AuthProviders: []string{"local", "ldap"}}
+
+	// ldap is listed, but no directory is configured
+	_, err := NewService(cfg, nil, nil, nil, nil, nil, nil, nil, testHasher, nil, testLogger)
+	assert.ErrorContains(t, err, "ldap")
+}
+
+func TestNewProviders(t *testing.T) {
+	local := &stubProvider{name: model.AuthProviderLocal}
+	directory := &stubProvider{name: model.AuthProviderLDAP}
+
+	tests := []struct {
+		name     string
+		names    []string
+		expected []service.AuthProvider
+		wantErr  bool
+	}{
+		{name: "local by default", expected: []service.AuthProvider{local}},
+		{name: "configured order", names: []string{"ldap", "local"}, expected: []service.AuthProvider{directory, local}},
+		{name: "unknown provider", names: []string{"local", "kerberos"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers, err := newProviders(tt.names, local, directory)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "kerberos")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, providers)
+		})
+	}
+}
+
+func TestAuthenticateProviderOrder(t *testing.T) {
+	ctx := context.Background()
+	user := queries.User{ID: "test-user-123", Email: "test@example.com"}
+
+	tests := []struct {
+		name          string
+		first         *stubProvider
+		second        *stubProvider
+		expectedCalls int
+		expectedError error
+	}{
+		{
+			name:          "unknown user falls through",
+			first:         &stubProvider{err: utils.ErrInvalidUser},
+			second:        &stubProvider{user: user},
+			expectedCalls: 1,
+		},
+		{
+			name:          "wrong password stops",
+			first:         &stubProvider{err: utils.ErrInvalidPassword},
+			second:        &stubProvider{user: user},
+			expectedError: utils.ErrInvalidPassword,
+		},
+		{
+			name:          "unavailable provider stops",
+			first:         &stubProvider{err: pgx.ErrTxClosed},
+			second:        &stubProvider{user: user},
+			expectedError: pgx.ErrTxClosed,
+		},
+		{
+			name:          "nobody knows the user",
+			first:         &stubProvider{err: utils.ErrInvalidUser},
+			second:        &stubProvider{err: utils.ErrInvalidUser},
+			expectedCalls: 1,
+			expectedError: utils.ErrInvalidUser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{providers: []service.AuthProvider{tt.first, tt.second}}
+
+			authenticated, err := s.authenticate(ctx, user.Email, "password")
+			assert.Equal(t, tt.expectedCalls, tt.second.calls)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, user, authenticated)
+		})
+	}
+}
+
+func TestLoginProvisionedByDirectory(t *testing.T) {
+	ctx := context.Background()
+	cfg := &infra.Config{JwtSecret: "test-secret", RefreshTokenTTL: time.Hour}
+	provisioned := queries.User{ID: "test-user-123", Email: "jdoe@example.com"}
+
+	mockRepo := repositoryMocks.NewMockUserRepository(t)
+	mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+	mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+	mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+	mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+	service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, mockAttemptRepo, nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+
+	directory := &stubProvider{name: model.AuthProviderLDAP, user: provisioned}
+	service.providers, err = newProviders([]string{"local", "ldap"}, localProvider{service}, directory)
+	require.NoError(t, err)
+
+	// the account does not exist before the first bind
+	mockRepo.On("GetUserByEmail", ctx, provisioned.Email).Return(queries.User{}, pgx.ErrNoRows).Twice()
+	mockAttemptRepo.On("GetLockedUntil", ctx, []string{"ip:203.0.113.7"}).Return(time.Time{}, nil).Once()
+	mockTwoFactorRepo.On("GetTOTP", ctx, provisioned.ID).Return(queries.UserTotp{}, pgx.ErrNoRows).Once()
+	mockAttemptRepo.On("Reset", ctx, "account:"+provisioned.ID).Return(nil).Once()
+	mockRoleRepo.On("GetUserRoles", ctx, provisioned.ID).Return([]string{model.RoleUser}, nil).Once()
+	mockTokenRepo.On("CreateSession", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+	result, err := service.Login(ctx, provisioned.Email, "directory-password", "203.0.113.7")
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Tokens.AccessToken)
+	assert.Equal(t, 1, directory.calls)
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// Refresh - обменять refresh токен на новую пару токенов.
+// Каждый refresh токен одноразовый: повторное предъявление уже использованного
+// токена отзывает всё семейство, выданное от того же входа.
+// Токены, выданные клиентам OAuth, обновляются только через RefreshClient.
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (model.TokenPair, error) {
+	return s.refresh(ctx, refreshToken, "")
+}
+
+// RefreshClient - как Refresh, но для refresh токена, выданного клиенту OAuth clientID.
+// Новая пара сохраняет scope исходной.
+func (s *Service) RefreshClient(ctx context.Context, refreshToken, clientID string) (model.TokenPair, error) {
+	if clientID == "" {
+		return model.TokenPair{}, utils.ErrInvalidToken
+	}
+
+	return s.refresh(ctx, refreshToken, clientID)
+}
+
+func (s *Service) refresh(ctx context.Context, refreshToken, clientID string) (model.TokenPair, error) {
+	if refreshToken == "" {
+		return model.TokenPair{}, utils.ErrInvalidToken
+	}
+
+	stored, err := s.tokenRepository.GetRefreshTokenByHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.TokenPair{}, utils.ErrInvalidToken
+		}
+		return model.TokenPair{}, err
+	}
+
+	// a token presented by another client is not a reuse of the family, just a wrong token
+	if stored.ClientID.String != clientID {
+		return model.TokenPair{}, utils.ErrInvalidToken
+	}
+
+	if stored.RevokedAt.Valid {
+		return model.TokenPair{}, s.revokeFamily(ctx, stored.FamilyID)
+	}
+
+	if !stored.ExpiresAt.Time.After(time.Now()) {
+		return model.TokenPair{}, utils.ErrInvalidToken
+	}
+
+	g := grant{clientID: stored.ClientID.String, scopes: stored.Scopes}
+	next, rawToken := s.newRefreshToken(stored.UserID, stored.FamilyID, g)
+	if err = s.tokenRepository.RotateRefreshToken(ctx, stored.ID, next); err != nil {
+		if errors.Is(err, utils.ErrRefreshTokenReused) {
+			return model.TokenPair{}, s.revokeFamily(ctx, stored.FamilyID)
+		}
+		return model.TokenPair{}, err
+	}
+
+	// roles are reloaded so that role changes reach the token on the next refresh
+	accessToken, err := s.generateUserToken(ctx, stored.UserID, stored.FamilyID, g)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	return model.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: rawToken,
+		ExpiresIn:    s.expires,
+	}, nil
+}
+
+// IssueTokens - начать новую сессию: выдать access токен и refresh токен нового семейства.
+// Используется всеми способами входа после того, как пользователь подтвердил личность.
+// Устройство для сессии берётся из контекста запроса.
+func (s *Service) IssueTokens(ctx context.Context, userID string) (model.TokenPair, error) {
+	return s.issueTokens(ctx, userID, grant{})
+}
+
+func (s *Service) issueTokens(ctx context.Context, userID string, g grant) (model.TokenPair, error) {
+	// the session is the refresh token family, so its ID doubles as the family ID
+	sessionID := ulid.Make().String()
+
+	accessToken, err := s.generateUserToken(ctx, userID, sessionID, g)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	client := model.ClientInfoFromContext(ctx)
+	session := queries.Session{
+		ID:        sessionID,
+		UserID:    userID,
+		ClientID:  pgtype.Text{String: g.clientID, Valid: g.clientID != ""},
+		UserAgent: client.UserAgent,
+		Ip:        client.IP,
+	}
+
+	next, rawToken := s.newRefreshToken(userID, sessionID, g)
+	if err = s.tokenRepository.CreateSession(ctx, session, next); err != nil {
+		return model.TokenPair{}, err
+	}
+
+	return model.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: rawToken,
+		ExpiresIn:    s.expires,
+	}, nil
+}
+
+// generateUserToken - создать access токен сессии sessionID с текущими ролями пользователя
+func (s *Service) generateUserToken(ctx context.Context, userID, sessionID string, g grant) (string, error) {
+	roles, err := s.roleRepository.GetUserRoles(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return s.signAccessToken(userID, roles, sessionID, g)
+}
+
+// newRefreshToken - сгенерировать refresh токен, в БД хранится только его хеш
+func (s *Service) newRefreshToken(userID, familyID string, g grant) (queries.RefreshToken, string) {
+	rawToken := rand.Text()
+
+	return queries.RefreshToken{
+		ID:        ulid.Make().String(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(rawToken),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(s.refreshExpires), Valid: true},
+		ClientID:  pgtype.Text{String: g.clientID, Valid: g.clientID != ""},
+		Scopes:    g.scopes,
+	}, rawToken
+}
+
+func (s *Service) revokeFamily(ctx context.Context, familyID string) error {
+	if err := s.tokenRepository.RevokeRefreshTokenFamily(ctx, familyID); err != nil {
+		return err
+	}
+	return utils.ErrRefreshTokenReused
+}
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func TestRefresh(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", RefreshTokenTTL: time.Hour}
+	ctx := context.Background()
+
+	rawToken := "refresh-token"
+	tokenHash := utils.HashToken(rawToken)
+	userID := "test-user-123"
+
+	activeToken := queries.RefreshToken{
+		ID:        "token-1",
+		UserID:    userID,
+		FamilyID:  "family-1",
+		TokenHash: tokenHash,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	rotatedToken := activeToken
+	rotatedToken.RevokedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	expiredToken := activeToken
+	expiredToken.ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+
+	tests := []struct {
+		name          string
+		refreshToken  string
+		mockSetup     func(*repositoryMocks.MockTokenRepository)
+		expectedError error
+	}{
+		{
+			name:         "successful rotation",
+			refreshToken: rawToken,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTokenRepo.On("GetRefreshTokenByHash", ctx, tokenHash).
+					Return(activeToken, nil).Once()
+				mockTokenRepo.On("RotateRefreshToken", ctx, "token-1", mock.MatchedBy(func(next queries.RefreshToken) bool {
+					return next.UserID == userID && next.FamilyID == "family-1" && next.TokenHash != tokenHash
+				})).Return(nil).Once()
+				mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, "family-1", mock.Anything).
+					Return(false, nil).Once()
+				mockTokenRepo.On("TouchSession", ctx, "family-1", mock.Anything).Return(nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "empty token",
+			refreshToken:  "",
+			mockSetup:     func(_ *repositoryMocks.MockTokenRepository) {},
+			expectedError: utils.ErrInvalidToken,
+		},
+		{
+			name:         "unknown token",
+			refreshToken: rawToken,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTokenRepo.On("GetRefreshTokenByHash", ctx, tokenHash).
+					Return(queries.RefreshToken{}, pgx.ErrNoRows).Once()
+			},
+			expectedError: utils.ErrInvalidToken,
+		},
+		{
+			name:         "expired token",
+			refreshToken: rawToken,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTokenRepo.On("GetRefreshTokenByHash", ctx, tokenHash).
+					Return(expiredToken, nil).Once()
+			},
+			expectedError: utils.ErrInvalidToken,
+		},
+		{
+			name:         "reused token revokes family",
+			refreshToken: rawToken,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTokenRepo.On("GetRefreshTokenByHash", ctx, tokenHash).
+					Return(rotatedToken, nil).Once()
+				mockTokenRepo.On("RevokeRefreshTokenFamily", ctx, "family-1").
+					Return(nil).Once()
+			},
+			expectedError: utils.ErrRefreshTokenReused,
+		},
+		{
+			name:         "concurrent rotation revokes family",
+			refreshToken: rawToken,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTokenRepo.On("GetRefreshTokenByHash", ctx, tokenHash).
+					Return(activeToken, nil).Once()
+				mockTokenRepo.On("RotateRefreshToken", ctx, "token-1", mock.Anything).
+					Return(utils.ErrRefreshTokenReused).Once()
+				mockTokenRepo.On("RevokeRefreshTokenFamily", ctx, "family-1").
+					Return(nil).Once()
+			},
+			expectedError: utils.ErrRefreshTokenReused,
+		},
+		{
+			name:         "database error",
+			refreshToken: rawToken,
+			mockSetup: func(mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTokenRepo.On("GetRefreshTokenByHash", ctx, tokenHash).
+					Return(queries.RefreshToken{}, errors.New("database connection error")).Once()
+			},
+			expectedError: errors.New("database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repositoryMocks.NewMockUserRepository(t)
+			mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+			mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+			mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+			tt.mockSetup(mockTokenRepo)
+			if tt.expectedError == nil {
+				mockRoleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleUser}, nil).Once()
+			}
+			service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+			require.NoError(t, err)
+
+			tokens, err := service.Refresh(ctx, tt.refreshToken)
+
+			if tt.expectedError != nil {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+				assert.Empty(t, tokens.AccessToken)
+			} else {
+				require.NoError(t, err)
+				assert.NotEqual(t, rawToken, tokens.RefreshToken)
+
+				principal, verifyErr := service.Authenticate(ctx, "Bearer "+tokens.AccessToken)
+				require.NoError(t, verifyErr)
+				assert.Equal(t, userID, principal.UserID)
+				assert.Equal(t, []string{model.RoleUser}, principal.Roles)
+				// the rotated token stays in the session of the family
+				assert.Equal(t, "family-1", principal.SessionID)
+			}
+
+			mockTokenRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -1,23 +1,96 @@
 package auth
 
 import (
+	"strings"
 	"time"
 
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/repository"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/hasher"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/ldap"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/policy"
 )
 
 type Service struct {
-	secret     string
-	expires    time.Duration
-	repository repository.UserRepository
+	keys                *keySet
+	expires             time.Duration
+	refreshExpires      time.Duration
+	challengeExpires    time.Duration
+	totpIssuer          string
+	verificationExpires time.Duration
+	revertExpires       time.Duration
+	deletionGrace       time.Duration
+	resetExpires        time.Duration
+	magicLinkExpires    time.Duration
+	mailLimits          map[string]int32
+	requireVerified     bool
+	publicURL           string
+	mailer              infra.Mailer
+	repository          repository.UserRepository
+	tokenRepository     repository.TokenRepository
+	roleRepository      repository.RoleRepository
+	twoFactorRepository repository.TwoFactorRepository
+	attemptRepository   repository.AttemptRepository
+	lockout             lockoutPolicy
+	passwordPolicy      service.PolicyService
+	passwordHasher      service.HasherService
+	providers           []service.AuthProvider
+	logger              *infra.Logger
 }
 
-// NewService - создать новый экземпляр сервиса авторизации
-func NewService(cfg *infra.Config, userRepository repository.UserRepository) *Service {
-	return &Service{
-		secret:     cfg.JwtSecret,
-		expires:    time.Hour,
-		repository: userRepository,
+// NewService - создать новый экземпляр сервиса авторизации, пароль при входе проверяют провайдеры из AUTH_PROVIDERS
+func NewService(cfg *infra.Config, userRepository repository.UserRepository, tokenRepository repository.TokenRepository, roleRepository repository.RoleRepository, twoFactorRepository repository.TwoFactorRepository, attemptRepository repository.AttemptRepository, mailer infra.Mailer, passwordPolicy *policy.Service, passwordHasher *hasher.Service, ldapProvider *ldap.Service, logger *infra.Logger) (*Service, error) {
+	keys, err := newKeySet(cfg)
+	if err != nil {
+		return nil, err
 	}
+
+	result := &Service{
+		keys:                keys,
+		expires:             time.Hour,
+		refreshExpires:      cfg.RefreshTokenTTL,
+		challengeExpires:    cfg.MfaChallengeTTL,
+		totpIssuer:          cfg.TotpIssuer,
+		verificationExpires: cfg.EmailVerificationTTL,
+		revertExpires:       cfg.EmailRevertTTL,
+		deletionGrace:       cfg.AccountDeletionGrace,
+		resetExpires:        cfg.PasswordResetTTL,
+		magicLinkExpires:    cfg.MagicLinkTTL,
+		mailLimits: map[string]int32{
+			purposeMagicLink:   cfg.MagicLinkMaxPerEmail,
+			purposeVerifyEmail: cfg.VerificationMailMaxPerEmail,
+			mailPasswordReset:  cfg.PasswordResetMailMaxPerEmail,
+		},
+		requireVerified:     cfg.RequireEmailVerification,
+		publicURL:           strings.TrimSuffix(cfg.PublicURL, "/"),
+		mailer:              mailer,
+		repository:          userRepository,
+		tokenRepository:     tokenRepository,
+		roleRepository:      roleRepository,
+		twoFactorRepository: twoFactorRepository,
+		attemptRepository:   attemptRepository,
+		lockout: lockoutPolicy{
+			maxFailures:      cfg.LoginMaxFailures,
+			maxFailuresPerIP: cfg.LoginMaxFailuresPerIP,
+			window:           cfg.LoginFailureWindow,
+			base:             cfg.LoginLockoutBase,
+			max:              cfg.LoginLockoutMax,
+		},
+		passwordPolicy: passwordPolicy,
+		passwordHasher: passwordHasher,
+		logger:         logger,
+	}
+
+	available := []service.AuthProvider{localProvider{result}}
+	if ldapProvider != nil {
+		available = append(available, ldapProvider)
+	}
+
+	result.providers, err = newProviders(cfg.AuthProviders, available...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
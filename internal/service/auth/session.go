@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+// touchInterval - last_active_at сессии обновляется не чаще, чтобы не писать в БД на каждый запрос
+const touchInterval = time.Minute
+
+// Sessions - активные сессии пользователя, сначала недавно использованные.
+// currentID - сессия токена текущего запроса, она отмечается в списке.
+func (s *Service) Sessions(ctx context.Context, userID, currentID string) ([]model.Session, error) {
+	stored, err := s.tokenRepository.GetUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.Session, 0, len(stored))
+	for _, session := range stored {
+		result = append(result, model.Session{
+			ID:           session.ID,
+			ClientID:     session.ClientID.String,
+			UserAgent:    session.UserAgent,
+			IP:           session.Ip,
+			CreatedAt:    session.CreatedAt.Time,
+			LastActiveAt: session.LastActiveAt.Time,
+			Current:      session.ID == currentID,
+		})
+	}
+
+	return result, nil
+}
+
+// RevokeSession - завершить сессию пользователя: её refresh токены отзываются,
+// а access токены перестают приниматься сразу, не дожидаясь истечения.
+// Чужая или уже завершённая сессия даёт pgx.ErrNoRows
+func (s *Service) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	return s.tokenRepository.RevokeSession(ctx, sessionID, userID)
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func TestIssueTokensCreatesSession(t *testing.T) {
+	ctx := model.WithClientInfo(context.Background(), model.ClientInfo{IP: "203.0.113.7", UserAgent: "Mozilla/5.0"})
+	userID := "test-user-123"
+	service, tokenRepo, roleRepo := newClientTestService(t)
+
+	var session queries.Session
+	roleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleUser}, nil).Once()
+	tokenRepo.On("CreateSession", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		session = args.Get(1).(queries.Session)
+		// the session is the refresh token family
+		assert.Equal(t, session.ID, args.Get(2).(queries.RefreshToken).FamilyID)
+	}).Return(nil).Once()
+
+	tokens, err := service.IssueTokens(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, userID, session.UserID)
+	assert.Equal(t, "203.0.113.7", session.Ip)
+	assert.Equal(t, "Mozilla/5.0", session.UserAgent)
+	assert.False(t, session.ClientID.Valid)
+
+	t.Run("token carries the session", func(t *testing.T) {
+		tokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, session.ID, mock.Anything).Return(false, nil).Once()
+		tokenRepo.On("TouchSession", ctx, session.ID, mock.MatchedBy(func(activeBefore time.Time) bool {
+			return activeBefore.Before(time.Now().Add(-touchInterval + time.Second))
+		})).Return(nil).Once()
+
+		principal, err := service.Authenticate(ctx, "Bearer "+tokens.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, session.ID, principal.SessionID)
+	})
+
+	t.Run("revoked session rejects the token", func(t *testing.T) {
+		tokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, session.ID, mock.Anything).Return(true, nil).Once()
+
+		_, err := service.Authenticate(ctx, "Bearer "+tokens.AccessToken)
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+}
+
+func TestSessions(t *testing.T) {
+	ctx := context.Background()
+	userID := "test-user-123"
+	service, tokenRepo, _ := newClientTestService(t)
+
+	now := time.Now()
+	tokenRepo.On("GetUserSessions", ctx, userID).Return([]queries.Session{
+		{
+			ID:           "session-1",
+			UserID:       userID,
+			UserAgent:    "Mozilla/5.0",
+			Ip:           "203.0.113.7",
+			CreatedAt:    pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true},
+			LastActiveAt: pgtype.Timestamptz{Time: now, Valid: true},
+		},
+		{
+			ID:       "session-2",
+			UserID:   userID,
+			ClientID: pgtype.Text{String: "client-1", Valid: true},
+		},
+	}, nil).Once()
+
+	sessions, err := service.Sessions(ctx, userID, "session-2")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "203.0.113.7", sessions[0].IP)
+	assert.Equal(t, now, sessions[0].LastActiveAt)
+	assert.False(t, sessions[0].Current)
+	assert.Equal(t, "client-1", sessions[1].ClientID)
+	assert.True(t, sessions[1].Current)
+}
+
+func TestRevokeSession(t *testing.T) {
+	ctx := context.Background()
+	userID := "test-user-123"
+
+	t.Run("own session", func(t *testing.T) {
+		service, tokenRepo, _ := newClientTestService(t)
+		tokenRepo.On("RevokeSession", ctx, "session-1", userID).Return(nil).Once()
+
+		assert.NoError(t, service.RevokeSession(ctx, userID, "session-1"))
+	})
+
+	t.Run("foreign or revoked session", func(t *testing.T) {
+		service, tokenRepo, _ := newClientTestService(t)
+		tokenRepo.On("RevokeSession", ctx, "session-1", userID).Return(pgx.ErrNoRows).Once()
+
+		assert.ErrorIs(t, service.RevokeSession(ctx, userID, "session-1"), pgx.ErrNoRows)
+	})
+}
@@ -0,0 +1,253 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/oklog/ulid/v2"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const (
+	totpPeriod         = 30
+	totpSkew           = 1
+	qrCodeSize         = 256
+	recoveryCodesCount = 10
+	recoveryCodeLength = 10
+)
+
+var totpOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// EnrollTOTP - сгенерировать новый TOTP секрет, 2FA включается только после ConfirmTOTP
+func (s *Service) EnrollTOTP(ctx context.Context, userID string) (model.TOTPEnrollment, error) {
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return model.TOTPEnrollment{}, err
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.totpIssuer,
+		AccountName: user.Email,
+		Period:      totpPeriod,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
+	})
+	if err != nil {
+		return model.TOTPEnrollment{}, err
+	}
+
+	if err = s.twoFactorRepository.SaveTOTP(ctx, userID, key.Secret()); err != nil {
+		return model.TOTPEnrollment{}, err
+	}
+
+	image, err := key.Image(qrCodeSize, qrCodeSize)
+	if err != nil {
+		return model.TOTPEnrollment{}, err
+	}
+
+	var qrCode bytes.Buffer
+	if err = png.Encode(&qrCode, image); err != nil {
+		return model.TOTPEnrollment{}, err
+	}
+
+	return model.TOTPEnrollment{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+		QRCode: qrCode.Bytes(),
+	}, nil
+}
+
+// ConfirmTOTP - включить 2FA по первому коду из приложения и выдать коды восстановления.
+// Коды восстановления показываются только один раз, в БД хранятся их хеши.
+func (s *Service) ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	secret, err := s.twoFactorRepository.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, utils.ErrTwoFactorDisabled
+		}
+		return nil, err
+	}
+
+	if secret.ConfirmedAt.Valid {
+		return nil, utils.ErrTwoFactorEnabled
+	}
+
+	step, ok := validateTOTP(secret.Secret, code, time.Now())
+	if !ok {
+		return nil, utils.ErrInvalidOTP
+	}
+
+	codes, stored := newRecoveryCodes(userID)
+	if err = s.twoFactorRepository.ConfirmTOTP(ctx, userID, step, stored); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// DisableTOTP - выключить 2FA, требуется действующий код или код восстановления
+func (s *Service) DisableTOTP(ctx context.Context, userID, code string) error {
+	if err := s.verifySecondFactor(ctx, userID, code); err != nil {
+		return err
+	}
+
+	return s.twoFactorRepository.DeleteTOTP(ctx, userID)
+}
+
+// RegenerateRecoveryCodes - заменить коды восстановления новыми, старые перестают действовать
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	if err := s.verifySecondFactor(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, stored := newRecoveryCodes(userID)
+	if err := s.twoFactorRepository.ReplaceRecoveryCodes(ctx, userID, stored); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// LoginTOTP - обменять challenge из Login и код второго фактора на пару токенов.
+// Challenge одноразовый: после любой попытки, удачной или нет, он отзывается.
+func (s *Service) LoginTOTP(ctx context.Context, challengeToken, code string) (model.TokenPair, error) {
+	challenge, err := s.consumePurposeToken(ctx, challengeToken, purposeMFA)
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	// wrong codes count towards the same lockout as wrong passwords
+	limits := []limit{s.accountLimit(challenge.Subject)}
+	if err = s.checkLockout(ctx, limits...); err != nil {
+		return model.TokenPair{}, err
+	}
+
+	if err = s.verifySecondFactor(ctx, challenge.Subject, code); err != nil {
+		if errors.Is(err, utils.ErrInvalidOTP) {
+			return model.TokenPair{}, s.loginFailed(ctx, err, limits...)
+		}
+		return model.TokenPair{}, err
+	}
+
+	if err = s.attemptRepository.Reset(ctx, accountSubject(challenge.Subject)); err != nil {
+		return model.TokenPair{}, err
+	}
+
+	return s.IssueTokens(ctx, challenge.Subject)
+}
+
+func (s *Service) twoFactorEnabled(ctx context.Context, userID string) (bool, error) {
+	secret, err := s.twoFactorRepository.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return secret.ConfirmedAt.Valid, nil
+}
+
+func (s *Service) generateChallenge(userID string) (string, error) {
+	return s.signPurposeToken(newClaims(userID, s.challengeExpires), purposeMFA)
+}
+
+// verifySecondFactor - проверить TOTP код или погасить код восстановления включённой 2FA
+func (s *Service) verifySecondFactor(ctx context.Context, userID, code string) error {
+	secret, err := s.twoFactorRepository.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return utils.ErrTwoFactorDisabled
+		}
+		return err
+	}
+
+	if !secret.ConfirmedAt.Valid {
+		return utils.ErrTwoFactorDisabled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == int(totpOpts.Digits) {
+		step, ok := validateTOTP(secret.Secret, code, time.Now())
+		if !ok {
+			return utils.ErrInvalidOTP
+		}
+
+		// the same code must not be accepted twice within its time window
+		fresh, err := s.twoFactorRepository.UseTOTPStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return utils.ErrInvalidOTP
+		}
+
+		return nil
+	}
+
+	used, err := s.twoFactorRepository.UseRecoveryCode(ctx, userID, utils.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return utils.ErrInvalidOTP
+	}
+
+	return nil
+}
+
+// validateTOTP - проверить код с допуском в один шаг и вернуть шаг, которому он соответствует
+func validateTOTP(secret, code string, now time.Time) (int64, bool) {
+	current := now.Unix() / totpPeriod
+
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0), totpOpts)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// newRecoveryCodes - сгенерировать коды восстановления для показа пользователю и их хеши для БД
+func newRecoveryCodes(userID string) ([]string, []queries.RecoveryCode) {
+	codes := make([]string, 0, recoveryCodesCount)
+	stored := make([]queries.RecoveryCode, 0, recoveryCodesCount)
+
+	for range recoveryCodesCount {
+		raw := strings.ToLower(rand.Text()[:recoveryCodeLength])
+		codes = append(codes, raw[:recoveryCodeLength/2]+"-"+raw[recoveryCodeLength/2:])
+		stored = append(stored, queries.RecoveryCode{
+			ID:       ulid.Make().String(),
+			UserID:   userID,
+			CodeHash: utils.HashToken(raw),
+		})
+	}
+
+	return codes, stored
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
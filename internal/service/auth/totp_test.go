@@ -0,0 +1,326 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/argon2id"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+func currentCode(t *testing.T) string {
+	t.Helper()
+	code, err := totp.GenerateCodeCustom(testTOTPSecret, time.Now(), totpOpts)
+	require.NoError(t, err)
+	return code
+}
+
+func TestLoginWithTwoFactor(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", RefreshTokenTTL: time.Hour, MfaChallengeTTL: time.Minute}
+	ctx := context.Background()
+
+	password := "SecurePassword123"
+	passwordHash, err := argon2id.CreateHash(password, argon2id.DefaultParams)
+	require.NoError(t, err)
+
+	userID := "test-user-123"
+	testUser := queries.User{ID: userID, Email: "test@example.com", PasswordHash: passwordHash}
+	enabled := queries.UserTotp{
+		UserID:      userID,
+		Secret:      testTOTPSecret,
+		ConfirmedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+
+	tests := []struct {
+		name          string
+		code          func(t *testing.T) string
+		mockSetup     func(*repositoryMocks.MockTwoFactorRepository, *repositoryMocks.MockRoleRepository, *repositoryMocks.MockTokenRepository)
+		expectedError error
+	}{
+		{
+			name: "valid totp code",
+			code: currentCode,
+			mockSetup: func(mockTwoFactorRepo *repositoryMocks.MockTwoFactorRepository, mockRoleRepo *repositoryMocks.MockRoleRepository, mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTwoFactorRepo.On("UseTOTPStep", ctx, userID, mock.Anything).Return(true, nil).Once()
+				mockRoleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleUser}, nil).Once()
+				mockTokenRepo.On("CreateSession", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name: "replayed totp code",
+			code: currentCode,
+			mockSetup: func(mockTwoFactorRepo *repositoryMocks.MockTwoFactorRepository, _ *repositoryMocks.MockRoleRepository, _ *repositoryMocks.MockTokenRepository) {
+				mockTwoFactorRepo.On("UseTOTPStep", ctx, userID, mock.Anything).Return(false, nil).Once()
+			},
+			expectedError: utils.ErrInvalidOTP,
+		},
+		{
+			name: "wrong totp code",
+			code: func(_ *testing.T) string { return "000000" },
+			mockSetup: func(_ *repositoryMocks.MockTwoFactorRepository, _ *repositoryMocks.MockRoleRepository, _ *repositoryMocks.MockTokenRepository) {
+			},
+			expectedError: utils.ErrInvalidOTP,
+		},
+		{
+			name: "recovery code",
+			code: func(_ *testing.T) string { return "ABCDE-12345" },
+			mockSetup: func(mockTwoFactorRepo *repositoryMocks.MockTwoFactorRepository, mockRoleRepo *repositoryMocks.MockRoleRepository, mockTokenRepo *repositoryMocks.MockTokenRepository) {
+				mockTwoFactorRepo.On("UseRecoveryCode", ctx, userID, utils.HashToken("abcde12345")).Return(true, nil).Once()
+				mockRoleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleUser}, nil).Once()
+				mockTokenRepo.On("CreateSession", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name: "used recovery code",
+			code: func(_ *testing.T) string { return "abcde-12345" },
+			mockSetup: func(mockTwoFactorRepo *repositoryMocks.MockTwoFactorRepository, _ *repositoryMocks.MockRoleRepository, _ *repositoryMocks.MockTokenRepository) {
+				mockTwoFactorRepo.On("UseRecoveryCode", ctx, userID, utils.HashToken("abcde12345")).Return(false, nil).Once()
+			},
+			expectedError: utils.ErrInvalidOTP,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repositoryMocks.NewMockUserRepository(t)
+			mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+			mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+			mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+			mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+			service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, mockAttemptRepo, nil, nil, testHasher, nil, testLogger)
+			require.NoError(t, err)
+
+			mockRepo.On("GetUserByEmail", ctx, testUser.Email).Return(testUser, nil).Twice()
+			mockTwoFactorRepo.On("GetTOTP", ctx, userID).Return(enabled, nil)
+			mockAttemptRepo.On("GetLockedUntil", ctx, []string{"account:" + userID}).Return(time.Time{}, nil).Twice()
+
+			result, err := service.Login(ctx, testUser.Email, password, "")
+			require.NoError(t, err)
+			assert.Empty(t, result.Tokens.AccessToken)
+			require.NotEmpty(t, result.ChallengeToken)
+
+			// challenge proves only the password and must not work as an access token
+			_, err = service.Authenticate(ctx, "Bearer "+result.ChallengeToken)
+			require.ErrorIs(t, err, utils.ErrInvalidToken)
+
+			mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).Return(false, nil).Once()
+			mockTokenRepo.On("RevokeAccessToken", ctx, mock.Anything, userID, mock.Anything).Return(nil).Once()
+			tt.mockSetup(mockTwoFactorRepo, mockRoleRepo, mockTokenRepo)
+			// the failure counter is reset only after the second factor
+			if tt.expectedError == nil {
+				mockAttemptRepo.On("Reset", ctx, "account:"+userID).Return(nil).Once()
+			} else {
+				mockAttemptRepo.On("RegisterFailure", ctx, "account:"+userID, mock.Anything).Return(int32(1), nil).Once()
+			}
+
+			tokens, err := service.LoginTOTP(ctx, result.ChallengeToken, tt.code(t))
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				assert.Empty(t, tokens.AccessToken)
+			} else {
+				require.NoError(t, err)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.NotEmpty(t, tokens.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestCompleteLogin(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", RefreshTokenTTL: time.Hour, MfaChallengeTTL: time.Minute}
+	ctx := context.Background()
+	userID := "test-user-123"
+
+	tests := []struct {
+		name          string
+		userErr       error
+		totp          queries.UserTotp
+		totpErr       error
+		wantChallenge bool
+		expectedError error
+	}{
+		{name: "without 2fa", totpErr: pgx.ErrNoRows},
+		{name: "deleted account", userErr: pgx.ErrNoRows, expectedError: utils.ErrInvalidUser},
+		{name: "unconfirmed 2fa", totp: queries.UserTotp{UserID: userID, Secret: testTOTPSecret}},
+		{
+			name:          "with 2fa",
+			totp:          queries.UserTotp{UserID: userID, Secret: testTOTPSecret, ConfirmedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}},
+			wantChallenge: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repositoryMocks.NewMockUserRepository(t)
+			mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+			mockRoleRepo := repositoryMocks.NewMockRoleRepository(t)
+			mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+			service, err := NewService(cfg, mockRepo, mockTokenRepo, mockRoleRepo, mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+			require.NoError(t, err)
+
+			// a deleted account is not returned by GetUserByID
+			mockRepo.On("GetUserByID", ctx, userID).Return(queries.User{ID: userID}, tt.userErr).Once()
+			if tt.expectedError == nil {
+				mockTwoFactorRepo.On("GetTOTP", ctx, userID).Return(tt.totp, tt.totpErr).Once()
+			}
+			if tt.expectedError == nil && !tt.wantChallenge {
+				mockRoleRepo.On("GetUserRoles", ctx, userID).Return([]string{model.RoleUser}, nil).Once()
+				mockTokenRepo.On("CreateSession", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			result, err := service.CompleteLogin(ctx, userID)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.wantChallenge {
+				assert.NotEmpty(t, result.ChallengeToken)
+				assert.Empty(t, result.Tokens.AccessToken)
+			} else {
+				assert.Empty(t, result.ChallengeToken)
+				assert.NotEmpty(t, result.Tokens.AccessToken)
+			}
+		})
+	}
+}
+
+func TestLoginTOTPRejectsAccessToken(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret"}
+	service, err := NewService(cfg, repositoryMocks.NewMockUserRepository(t), repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+
+	accessToken, err := service.GenerateToken("test-user-123")
+	require.NoError(t, err)
+
+	_, err = service.LoginTOTP(context.Background(), accessToken, "123456")
+	require.ErrorIs(t, err, utils.ErrInvalidToken)
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret"}
+	ctx := context.Background()
+	userID := "test-user-123"
+	pending := queries.UserTotp{UserID: userID, Secret: testTOTPSecret}
+	confirmed := pending
+	confirmed.ConfirmedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	tests := []struct {
+		name          string
+		code          func(t *testing.T) string
+		mockSetup     func(*repositoryMocks.MockTwoFactorRepository)
+		expectedError error
+	}{
+		{
+			name: "valid code",
+			code: currentCode,
+			mockSetup: func(mockTwoFactorRepo *repositoryMocks.MockTwoFactorRepository) {
+				mockTwoFactorRepo.On("GetTOTP", ctx, userID).Return(pending, nil).Once()
+				mockTwoFactorRepo.On("ConfirmTOTP", ctx, userID, mock.AnythingOfType("int64"), mock.MatchedBy(func(codes []queries.RecoveryCode) bool {
+					return len(codes) == recoveryCodesCount
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "invalid code",
+			code: func(_ *testing.T) string { return "000000" },
+			mockSetup: func(mockTwoFactorRepo *repositoryMocks.MockTwoFactorRepository) {
+				mockTwoFactorRepo.On("GetTOTP", ctx, userID).Return(pending, nil).Once()
+			},
+			expectedError: utils.ErrInvalidOTP,
+		},
+		{
+			name: "already enabled",
+			code: currentCode,
+			mockSetup: func(mockTwoFactorRepo *repositoryMocks.MockTwoFactorRepository) {
+				mockTwoFactorRepo.On("GetTOTP", ctx, userID).Return(confirmed, nil).Once()
+			},
+			expectedError: utils.ErrTwoFactorEnabled,
+		},
+		{
+			name: "not enrolled",
+			code: currentCode,
+			mockSetup: func(mockTwoFactorRepo *repositoryMocks.MockTwoFactorRepository) {
+				mockTwoFactorRepo.On("GetTOTP", ctx, userID).Return(queries.UserTotp{}, pgx.ErrNoRows).Once()
+			},
+			expectedError: utils.ErrTwoFactorDisabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+			tt.mockSetup(mockTwoFactorRepo)
+			service, err := NewService(cfg, repositoryMocks.NewMockUserRepository(t), repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+			require.NoError(t, err)
+
+			codes, err := service.ConfirmTOTP(ctx, userID, tt.code(t))
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				assert.Empty(t, codes)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, codes, recoveryCodesCount)
+				for _, code := range codes {
+					assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+				}
+			}
+		})
+	}
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", TotpIssuer: "webTemplate"}
+	ctx := context.Background()
+	mockRepo := repositoryMocks.NewMockUserRepository(t)
+	mockTwoFactorRepo := repositoryMocks.NewMockTwoFactorRepository(t)
+	service, err := NewService(cfg, mockRepo, repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), mockTwoFactorRepo, repositoryMocks.NewMockAttemptRepository(t), nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+	userID := "test-user-123"
+
+	mockRepo.On("GetUserByID", ctx, userID).Return(queries.User{ID: userID, Email: "test@example.com"}, nil).Once()
+	mockTwoFactorRepo.On("SaveTOTP", ctx, userID, mock.Anything).Return(nil).Once()
+
+	enrollment, err := service.EnrollTOTP(ctx, userID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, enrollment.Secret)
+	assert.Contains(t, enrollment.URI, "otpauth://totp/webTemplate:test@example.com")
+	assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+	assert.Equal(t, []byte("\x89PNG"), enrollment.QRCode[:4])
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Now()
+	step := now.Unix() / totpPeriod
+
+	for _, offset := range []int64{-1, 0, 1} {
+		code, err := totp.GenerateCodeCustom(testTOTPSecret, time.Unix((step+offset)*totpPeriod, 0), totpOpts)
+		require.NoError(t, err)
+
+		matched, ok := validateTOTP(testTOTPSecret, code, now)
+		require.True(t, ok)
+		assert.Equal(t, step+offset, matched)
+	}
+
+	code, err := totp.GenerateCodeCustom(testTOTPSecret, time.Unix((step+3)*totpPeriod, 0), totpOpts)
+	require.NoError(t, err)
+	_, ok := validateTOTP(testTOTPSecret, code, now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const verificationMailBody = `Confirm your email address by opening the link below:
+
+%s
+
+The link is valid for %s. If you did not create an account, ignore this email.
+`
+
+// SendVerificationEmail - отправить ссылку для подтверждения почты, уже подтверждённым пользователям ничего не отправляется
+func (s *Service) SendVerificationEmail(ctx context.Context, userID string) error {
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.EmailVerifiedAt.Valid {
+		return nil
+	}
+
+	tokenClaims := newClaims(user.ID, s.verificationExpires)
+	tokenClaims.Email = user.Email
+
+	token, err := s.signPurposeToken(tokenClaims, purposeVerifyEmail)
+	if err != nil {
+		return err
+	}
+
+	link := s.publicURL + "/verify-email?token=" + url.QueryEscape(token)
+	return s.mailer.Send(ctx, infra.Mail{
+		To:      user.Email,
+		Subject: "Confirm your email",
+		Body:    fmt.Sprintf(verificationMailBody, link, s.verificationExpires),
+	})
+}
+
+// ResendVerificationEmail - повторно отправить ссылку по адресу.
+// Для неизвестных адресов ошибка не возвращается, чтобы нельзя было перебирать зарегистрированные почты.
+func (s *Service) ResendVerificationEmail(ctx context.Context, email string) error {
+	// counted before the lookup, so the limit does not reveal registered addresses either
+	if err := s.limitMails(ctx, purposeVerifyEmail, email); err != nil {
+		return err
+	}
+
+	user, err := s.repository.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	return s.SendVerificationEmail(ctx, user.ID)
+}
+
+// VerifyEmail - подтвердить почту по токену из письма, токен одноразовый
+func (s *Service) VerifyEmail(ctx context.Context, token string) error {
+	tokenClaims, err := s.consumePurposeToken(ctx, token, purposeVerifyEmail)
+	if err != nil {
+		return err
+	}
+
+	// the address changed or was already verified since the email was sent
+	verified, err := s.repository.VerifyEmail(ctx, tokenClaims.Subject, tokenClaims.Email)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return utils.ErrInvalidToken
+	}
+
+	return nil
+}
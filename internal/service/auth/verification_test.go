@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/alexedwards/argon2id"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// recordingMailer - запоминает отправленные письма вместо отправки
+type recordingMailer struct {
+	sent []infra.Mail
+}
+
+func (m *recordingMailer) Send(_ context.Context, message infra.Mail) error {
+	m.sent = append(m.sent, message)
+	return nil
+}
+
+var linkToken = regexp.MustCompile(`/verify-email\?token=(\S+)`)
+
+func tokenFromMail(t *testing.T, message infra.Mail) string {
+	t.Helper()
+	match := linkToken.FindStringSubmatch(message.Body)
+	require.Len(t, match, 2)
+
+	token, err := url.QueryUnescape(match[1])
+	require.NoError(t, err)
+	return token
+}
+
+func TestEmailVerification(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", EmailVerificationTTL: time.Hour, PublicURL: "https://example.com/"}
+	ctx := context.Background()
+	userID := "test-user-123"
+	unverified := queries.User{ID: userID, Email: "test@example.com"}
+
+	newTestService := func(t *testing.T) (*Service, *repositoryMocks.MockUserRepository, *repositoryMocks.MockTokenRepository, *recordingMailer) {
+		mockRepo := repositoryMocks.NewMockUserRepository(t)
+		mockTokenRepo := repositoryMocks.NewMockTokenRepository(t)
+		mailer := &recordingMailer{}
+		service, err := NewService(cfg, mockRepo, mockTokenRepo, repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), repositoryMocks.NewMockAttemptRepository(t), mailer, nil, testHasher, nil, testLogger)
+		require.NoError(t, err)
+		return service, mockRepo, mockTokenRepo, mailer
+	}
+
+	t.Run("link verifies email once", func(t *testing.T) {
+		service, mockRepo, mockTokenRepo, mailer := newTestService(t)
+		mockRepo.On("GetUserByID", ctx, userID).Return(unverified, nil).Once()
+
+		require.NoError(t, service.SendVerificationEmail(ctx, userID))
+		require.Len(t, mailer.sent, 1)
+		assert.Equal(t, "test@example.com", mailer.sent[0].To)
+		assert.Contains(t, mailer.sent[0].Body, "https://example.com/verify-email?token=")
+		token := tokenFromMail(t, mailer.sent[0])
+
+		mockTokenRepo.On("IsAccessTokenRevoked", ctx, "", userID, mock.Anything, mock.Anything).Return(false, nil).Twice()
+		mockTokenRepo.On("RevokeAccessToken", ctx, mock.Anything, userID, mock.Anything).Return(nil).Once()
+		mockRepo.On("VerifyEmail", ctx, userID, "test@example.com").Return(true, nil).Once()
+		require.NoError(t, service.VerifyEmail(ctx, token))
+
+		// the link was revoked by the first use, a second revocation of the same jti inserts nothing
+		mockTokenRepo.On("RevokeAccessToken", ctx, mock.Anything, userID, mock.Anything).Return(utils.ErrInvalidToken).Once()
+		require.ErrorIs(t, service.VerifyEmail(ctx, token), utils.ErrInvalidToken)
+	})
+
+	t.Run("email changed after sending", func(t *testing.T) {
+		service, mockRepo, mockTokenRepo, mailer := newTestService(t)
+		mockRepo.On("GetUserByID", ctx, userID).Return(unverified, nil).Once()
+		require.NoError(t, service.SendVerificationEmail(ctx, userID))
+
+		mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.Anything, userID, mock.Anything, mock.Anything).Return(false, nil).Once()
+		mockTokenRepo.On("RevokeAccessToken", ctx, mock.Anything, userID, mock.Anything).Return(nil).Once()
+		mockRepo.On("VerifyEmail", ctx, userID, "test@example.com").Return(false, nil).Once()
+		require.ErrorIs(t, service.VerifyEmail(ctx, tokenFromMail(t, mailer.sent[0])), utils.ErrInvalidToken)
+	})
+
+	t.Run("access token is not a verification token", func(t *testing.T) {
+		service, _, _, _ := newTestService(t)
+		accessToken, err := service.GenerateToken(userID)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, service.VerifyEmail(ctx, accessToken), utils.ErrInvalidToken)
+	})
+
+	t.Run("already verified user gets no email", func(t *testing.T) {
+		service, mockRepo, _, mailer := newTestService(t)
+		verified := unverified
+		verified.EmailVerifiedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		mockRepo.On("GetUserByEmail", ctx, "test@example.com").Return(verified, nil).Once()
+		mockRepo.On("GetUserByID", ctx, userID).Return(verified, nil).Once()
+
+		require.NoError(t, service.ResendVerificationEmail(ctx, "test@example.com"))
+		assert.Empty(t, mailer.sent)
+	})
+
+	t.Run("unknown email is not reported", func(t *testing.T) {
+		service, mockRepo, _, mailer := newTestService(t)
+		mockRepo.On("GetUserByEmail", ctx, "nobody@example.com").Return(queries.User{}, pgx.ErrNoRows).Once()
+
+		require.NoError(t, service.ResendVerificationEmail(ctx, "nobody@example.com"))
+		assert.Empty(t, mailer.sent)
+	})
+}
+
+func TestResendVerificationEmailLimit(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", VerificationMailMaxPerEmail: 2, MagicLinkMaxPerEmail: 1, LoginFailureWindow: time.Hour}
+	ctx := context.Background()
+	subject := "verify_email:" + utils.HashToken("test@example.com")
+
+	newTestService := func(t *testing.T) (*Service, *repositoryMocks.MockUserRepository, *repositoryMocks.MockAttemptRepository) {
+		mockRepo := repositoryMocks.NewMockUserRepository(t)
+		mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+		service, err := NewService(cfg, mockRepo, repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), mockAttemptRepo, &recordingMailer{}, nil, testHasher, nil, testLogger)
+		require.NoError(t, err)
+		return service, mockRepo, mockAttemptRepo
+	}
+
+	t.Run("within the limit", func(t *testing.T) {
+		service, mockRepo, mockAttemptRepo := newTestService(t)
+		mockAttemptRepo.On("RegisterSend", ctx, subject, mock.Anything).Return(int32(2), time.Now(), nil).Once()
+		mockRepo.On("GetUserByEmail", ctx, "Test@Example.com").Return(queries.User{}, pgx.ErrNoRows).Once()
+
+		require.NoError(t, service.ResendVerificationEmail(ctx, "Test@Example.com"))
+	})
+
+	t.Run("over the limit, known or not", func(t *testing.T) {
+		service, _, mockAttemptRepo := newTestService(t)
+		mockAttemptRepo.On("RegisterSend", ctx, subject, mock.Anything).Return(int32(3), time.Now(), nil).Once()
+
+		var lockout *utils.LockoutError
+		require.ErrorAs(t, service.ResendVerificationEmail(ctx, "test@example.com"), &lockout)
+	})
+}
+
+func TestLoginRequiresVerifiedEmail(t *testing.T) {
+	cfg := &infra.Config{JwtSecret: "test-secret", RequireEmailVerification: true}
+	ctx := context.Background()
+
+	password := "SecurePassword123"
+	passwordHash, err := argon2id.CreateHash(password, argon2id.DefaultParams)
+	require.NoError(t, err)
+	unverified := queries.User{ID: "test-user-123", Email: "test@example.com", PasswordHash: passwordHash}
+
+	mockRepo := repositoryMocks.NewMockUserRepository(t)
+	mockAttemptRepo := repositoryMocks.NewMockAttemptRepository(t)
+	service, err := NewService(cfg, mockRepo, repositoryMocks.NewMockTokenRepository(t), repositoryMocks.NewMockRoleRepository(t), repositoryMocks.NewMockTwoFactorRepository(t), mockAttemptRepo, nil, nil, testHasher, nil, testLogger)
+	require.NoError(t, err)
+
+	mockRepo.On("GetUserByEmail", ctx, "test@example.com").Return(unverified, nil).Times(4)
+	mockAttemptRepo.On("GetLockedUntil", ctx, []string{"account:test-user-123"}).Return(time.Time{}, nil).Twice()
+	mockAttemptRepo.On("RegisterFailure", ctx, "account:test-user-123", mock.Anything).Return(int32(1), nil).Once()
+
+	_, err = service.Login(ctx, "test@example.com", "WrongPassword", "")
+	require.ErrorIs(t, err, utils.ErrInvalidPassword)
+
+	_, err = service.Login(ctx, "test@example.com", password, "")
+	require.ErrorIs(t, err, utils.ErrEmailNotVerified)
+}
@@ -0,0 +1,197 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/oklog/ulid/v2"
+	"go.uber.org/zap"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+const (
+	// listLimit - сколько последних выгрузок показывается пользователю
+	listLimit = 10
+	// runningTimeout - сборка, не завершившаяся за это время, считается прерванной и берётся заново
+	runningTimeout = 15 * time.Minute
+)
+
+const readyMailBody = `The copy of your personal data you requested is ready. Download it by opening the link below:
+
+%s
+
+The link is valid for %s, after that the archive is deleted.
+`
+
+// Request - поставить в очередь выгрузку персональных данных пользователя, архив собирается в фоне.
+// Пока предыдущая выгрузка не собрана, новая даёт utils.ErrExportInProgress
+func (s *Service) Request(ctx context.Context, userID string) (model.DataExport, error) {
+	id := ulid.Make().String()
+	if err := s.repository.Create(ctx, id, userID); err != nil {
+		return model.DataExport{}, err
+	}
+
+	return model.DataExport{
+		ID:        id,
+		Status:    model.DataExportPending,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// List - последние выгрузки пользователя, у готовых есть ссылка на скачивание
+func (s *Service) List(ctx context.Context, userID string) ([]model.DataExport, error) {
+	stored, err := s.repository.GetUserExports(ctx, userID, listLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.DataExport, 0, len(stored))
+	for _, export := range stored {
+		item := model.DataExport{
+			ID:        export.ID,
+			Status:    export.Status,
+			Error:     export.Error,
+			CreatedAt: export.CreatedAt.Time,
+		}
+		if export.CompletedAt.Valid {
+			item.CompletedAt = &export.CompletedAt.Time
+		}
+		if export.ExpiresAt.Valid {
+			item.ExpiresAt = &export.ExpiresAt.Time
+		}
+
+		if export.Status == model.DataExportReady && export.ExpiresAt.Time.After(time.Now()) {
+			item.DownloadToken, err = s.authService.GenerateDownloadToken(userID, export.ID, export.ExpiresAt.Time)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// Download - архив по подписанной ссылке, истёкший или удалённый архив даёт pgx.ErrNoRows
+func (s *Service) Download(ctx context.Context, token string) (model.DataExportArchive, error) {
+	userID, id, err := s.authService.VerifyDownloadToken(ctx, token)
+	if err != nil {
+		return model.DataExportArchive{}, err
+	}
+
+	content, err := s.repository.GetArchive(ctx, id, userID)
+	if err != nil {
+		return model.DataExportArchive{}, err
+	}
+
+	return model.DataExportArchive{
+		Name:    "personal-data-" + id + ".zip",
+		Content: content,
+	}, nil
+}
+
+// ProcessPending - собрать все выгрузки из очереди. Неудачная сборка отмечается у выгрузки
+// и не мешает остальным, о готовом архиве пользователю приходит письмо со ссылкой
+func (s *Service) ProcessPending(ctx context.Context) error {
+	for {
+		export, err := s.repository.Claim(ctx, time.Now().Add(-runningTimeout))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+
+		if err = s.process(ctx, export); err != nil {
+			return err
+		}
+	}
+}
+
+// PurgeExpired - удалить истёкшие архивы
+func (s *Service) PurgeExpired(ctx context.Context) error {
+	_, err := s.repository.DeleteExpired(ctx)
+	return err
+}
+
+func (s *Service) process(ctx context.Context, export queries.ClaimDataExportRow) error {
+	expiresAt := time.Now().Add(s.ttl)
+
+	archive, err := s.build(ctx, export.UserID)
+	if err != nil {
+		// the user sees only that the export failed, the details are for the logs
+		s.logger.Errorw("data export failed", zap.String("export", export.ID), zap.Error(err))
+		return s.repository.Fail(ctx, export.ID, "failed to collect personal data, request a new export", expiresAt)
+	}
+
+	if err = s.repository.Complete(ctx, export.ID, archive, expiresAt); err != nil {
+		return err
+	}
+
+	// the archive is available from the API anyway, a lost mail is not a reason to build it again
+	if err = s.notify(ctx, export, expiresAt); err != nil {
+		s.logger.Warnw("data export mail failed", zap.String("export", export.ID), zap.Error(err))
+	}
+
+	return nil
+}
+
+// build - ZIP архив с JSON файлом на каждый зарегистрированный раздел
+func (s *Service) build(ctx context.Context, userID string) ([]byte, error) {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	for _, exporter := range s.exporters {
+		data, err := exporter.Export(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", exporter.Name(), err)
+		}
+
+		file, err := archive.Create(exporter.Name() + ".json")
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(data); err != nil {
+			return nil, fmt.Errorf("export %s: %w", exporter.Name(), err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (s *Service) notify(ctx context.Context, export queries.ClaimDataExportRow, expiresAt time.Time) error {
+	user, err := s.userRepository.GetUserByID(ctx, export.UserID)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.authService.GenerateDownloadToken(user.ID, export.ID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	link := s.publicURL + "/data-export?token=" + url.QueryEscape(token)
+	return s.mailer.Send(ctx, infra.Mail{
+		To:      user.Email,
+		Subject: "Your personal data export is ready",
+		Body:    fmt.Sprintf(readyMailBody, link, s.ttl),
+	})
+}
@@ -0,0 +1,231 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	serviceMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/service/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// stubExporter - раздел выгрузки с заранее заданными данными
+type stubExporter struct {
+	name string
+	data any
+	err  error
+}
+
+func (e stubExporter) Name() string {
+	return e.name
+}
+
+func (e stubExporter) Export(_ context.Context, _ string) (any, error) {
+	return e.data, e.err
+}
+
+// recordingMailer - запоминает отправленные письма вместо отправки
+type recordingMailer struct {
+	sent []infra.Mail
+}
+
+func (m *recordingMailer) Send(_ context.Context, message infra.Mail) error {
+	m.sent = append(m.sent, message)
+	return nil
+}
+
+type mocks struct {
+	export *repositoryMocks.MockExportRepository
+	user   *repositoryMocks.MockUserRepository
+	auth   *serviceMocks.MockAuthService
+	mailer *recordingMailer
+}
+
+func newTestService(t *testing.T, exporters ...service.DataExporter) (*Service, mocks) {
+	t.Helper()
+
+	m := mocks{
+		export: repositoryMocks.NewMockExportRepository(t),
+		user:   repositoryMocks.NewMockUserRepository(t),
+		auth:   serviceMocks.NewMockAuthService(t),
+		mailer: &recordingMailer{},
+	}
+
+	cfg := &infra.Config{DataExportTTL: 72 * time.Hour, PublicURL: "https://example.com/"}
+	logger := &infra.Logger{Zap: zap.NewNop(), SugaredLogger: zap.NewNop().Sugar()}
+	service, err := NewService(cfg, m.export, m.user, nil, m.mailer, logger, exporters)
+	require.NoError(t, err)
+	service.authService = m.auth
+
+	return service, m
+}
+
+// readArchive - содержимое файлов архива по именам
+func readArchive(t *testing.T, archive []byte) map[string]string {
+	t.Helper()
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	files := make(map[string]string, len(reader.File))
+	for _, file := range reader.File {
+		opened, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(opened)
+		require.NoError(t, err)
+		require.NoError(t, opened.Close())
+		files[file.Name] = string(content)
+	}
+	return files
+}
+
+func TestNewService(t *testing.T) {
+	cfg := &infra.Config{}
+
+	_, err := NewService(cfg, nil, nil, nil, nil, nil, []service.DataExporter{stubExporter{name: "profile"}, stubExporter{name: "profile"}})
+	assert.Error(t, err)
+
+	_, err = NewService(cfg, nil, nil, nil, nil, nil, []service.DataExporter{stubExporter{name: "../profile"}})
+	assert.Error(t, err)
+
+	result, err := NewService(cfg, nil, nil, nil, nil, nil, []service.DataExporter{stubExporter{name: "sessions"}, stubExporter{name: "profile"}})
+	require.NoError(t, err)
+	assert.Equal(t, "profile", result.exporters[0].Name())
+}
+
+func TestProcessPending(t *testing.T) {
+	ctx := context.Background()
+	user := queries.User{ID: "user-1", Email: "user@example.com"}
+
+	t.Run("every section gets its own file", func(t *testing.T) {
+		service, m := newTestService(t,
+			stubExporter{name: "sessions", data: []model.Session{{ID: "session-1", IP: "203.0.113.7"}}},
+			stubExporter{name: "profile", data: model.ExportProfile{ID: user.ID, Email: user.Email}},
+		)
+
+		var archive []byte
+		m.export.On("Claim", ctx, mock.Anything).Return(queries.ClaimDataExportRow{ID: "export-1", UserID: user.ID}, nil).Once()
+		m.export.On("Complete", ctx, "export-1", mock.Anything, mock.MatchedBy(func(expiresAt time.Time) bool {
+			return expiresAt.After(time.Now().Add(71 * time.Hour))
+		})).Run(func(args mock.Arguments) {
+			archive = args.Get(2).([]byte)
+		}).Return(nil).Once()
+		m.export.On("Claim", ctx, mock.Anything).Return(queries.ClaimDataExportRow{}, pgx.ErrNoRows).Once()
+		m.user.On("GetUserByID", ctx, user.ID).Return(user, nil).Once()
+		m.auth.On("GenerateDownloadToken", user.ID, "export-1", mock.Anything).Return("signed", nil).Once()
+
+		require.NoError(t, service.ProcessPending(ctx))
+
+		files := readArchive(t, archive)
+		require.Len(t, files, 2)
+
+		var profile model.ExportProfile
+		require.NoError(t, json.Unmarshal([]byte(files["profile.json"]), &profile))
+		assert.Equal(t, user.Email, profile.Email)
+		assert.Contains(t, files["sessions.json"], "203.0.113.7")
+
+		require.Len(t, m.mailer.sent, 1)
+		assert.Equal(t, user.Email, m.mailer.sent[0].To)
+		assert.Contains(t, m.mailer.sent[0].Body, "https://example.com/data-export?token=signed")
+	})
+
+	t.Run("failed section fails only its export", func(t *testing.T) {
+		service, m := newTestService(t,
+			stubExporter{name: "profile", data: model.ExportProfile{ID: user.ID}},
+			stubExporter{name: "sessions", err: errors.New("connection reset")},
+		)
+
+		m.export.On("Claim", ctx, mock.Anything).Return(queries.ClaimDataExportRow{ID: "export-1", UserID: user.ID}, nil).Once()
+		m.export.On("Fail", ctx, "export-1", mock.MatchedBy(func(reason string) bool {
+			// internal errors are not shown to the user
+			return reason != "" && !strings.Contains(reason, "connection reset")
+		}), mock.Anything).Return(nil).Once()
+		m.export.On("Claim", ctx, mock.Anything).Return(queries.ClaimDataExportRow{ID: "export-2", UserID: "user-2"}, nil).Once()
+		m.export.On("Fail", ctx, "export-2", mock.Anything, mock.Anything).Return(nil).Once()
+		m.export.On("Claim", ctx, mock.Anything).Return(queries.ClaimDataExportRow{}, pgx.ErrNoRows).Once()
+
+		require.NoError(t, service.ProcessPending(ctx))
+		assert.Empty(t, m.mailer.sent)
+	})
+
+	t.Run("stale exports are claimed again", func(t *testing.T) {
+		service, m := newTestService(t)
+
+		m.export.On("Claim", ctx, mock.MatchedBy(func(staleBefore time.Time) bool {
+			return staleBefore.Before(time.Now().Add(-runningTimeout + time.Minute))
+		})).Return(queries.ClaimDataExportRow{}, pgx.ErrNoRows).Once()
+
+		require.NoError(t, service.ProcessPending(ctx))
+	})
+}
+
+func TestRequest(t *testing.T) {
+	ctx := context.Background()
+	service, m := newTestService(t)
+
+	m.export.On("Create", ctx, mock.Anything, "user-1").Return(nil).Once()
+	export, err := service.Request(ctx, "user-1")
+	require.NoError(t, err)
+	assert.NotEmpty(t, export.ID)
+	assert.Equal(t, model.DataExportPending, export.Status)
+
+	m.export.On("Create", ctx, mock.Anything, "user-1").Return(utils.ErrExportInProgress).Once()
+	_, err = service.Request(ctx, "user-1")
+	assert.ErrorIs(t, err, utils.ErrExportInProgress)
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	service, m := newTestService(t)
+
+	now := time.Now()
+	m.export.On("GetUserExports", ctx, "user-1", int32(listLimit)).Return([]queries.GetUserDataExportsRow{
+		{ID: "pending", Status: model.DataExportPending, CreatedAt: pgtype.Timestamptz{Time: now, Valid: true}},
+		{ID: "ready", Status: model.DataExportReady, ExpiresAt: pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true}},
+		{ID: "expired", Status: model.DataExportReady, ExpiresAt: pgtype.Timestamptz{Time: now.Add(-time.Minute), Valid: true}},
+	}, nil).Once()
+	m.auth.On("GenerateDownloadToken", "user-1", "ready", mock.Anything).Return("signed", nil).Once()
+
+	exports, err := service.List(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, exports, 3)
+	assert.Empty(t, exports[0].DownloadToken)
+	assert.Nil(t, exports[0].ExpiresAt)
+	assert.Equal(t, "signed", exports[1].DownloadToken)
+	assert.Empty(t, exports[2].DownloadToken)
+}
+
+func TestDownload(t *testing.T) {
+	ctx := context.Background()
+	service, m := newTestService(t)
+
+	m.auth.On("VerifyDownloadToken", ctx, "signed").Return("user-1", "export-1", nil).Once()
+	m.export.On("GetArchive", ctx, "export-1", "user-1").Return([]byte("zip"), nil).Once()
+
+	archive, err := service.Download(ctx, "signed")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("zip"), archive.Content)
+	assert.Equal(t, "personal-data-export-1.zip", archive.Name)
+
+	m.auth.On("VerifyDownloadToken", ctx, "forged").Return("", "", utils.ErrInvalidToken).Once()
+	_, err = service.Download(ctx, "forged")
+	assert.ErrorIs(t, err, utils.ErrInvalidToken)
+}
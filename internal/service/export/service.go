@@ -0,0 +1,57 @@
+package export
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/repository"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/auth"
+)
+
+type Service struct {
+	ttl            time.Duration
+	publicURL      string
+	repository     repository.ExportRepository
+	userRepository repository.UserRepository
+	authService    service.AuthService
+	mailer         infra.Mailer
+	logger         *infra.Logger
+	exporters      []service.DataExporter
+}
+
+// NewService - создать новый экземпляр сервиса выгрузки персональных данных. Разделы архива собирают exporters,
+// каждый модуль со своими таблицами регистрирует exporter в группе fx "exporters"
+func NewService(cfg *infra.Config, exportRepository repository.ExportRepository, userRepository repository.UserRepository, authService *auth.Service, mailer infra.Mailer, logger *infra.Logger, exporters []service.DataExporter) (*Service, error) {
+	// sections become file names in the archive, so they must not clash
+	seen := make(map[string]bool, len(exporters))
+	for _, exporter := range exporters {
+		name := exporter.Name()
+		if name == "" || strings.ContainsAny(name, `/\.`) {
+			return nil, fmt.Errorf("invalid data exporter name %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("data exporter %q is registered twice", name)
+		}
+		seen[name] = true
+	}
+
+	// fx does not keep the order of a value group, the archive should look the same every time
+	exporters = slices.SortedFunc(slices.Values(exporters), func(a, b service.DataExporter) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	return &Service{
+		ttl:            cfg.DataExportTTL,
+		publicURL:      strings.TrimSuffix(cfg.PublicURL, "/"),
+		repository:     exportRepository,
+		userRepository: userRepository,
+		authService:    authService,
+		mailer:         mailer,
+		logger:         logger,
+		exporters:      exporters,
+	}, nil
+}
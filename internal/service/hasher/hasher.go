@@ -0,0 +1,107 @@
+package hasher
+
+import (
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"github.com/alexedwards/argon2id"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+var errUnsupportedHash = errors.New("unsupported password hash format")
+
+// pbkdf2Digests - хеш-функции PBKDF2 хешей, импортированных из старой системы
+var pbkdf2Digests = map[string]func() hash.Hash{
+	"pbkdf2_sha1":   sha1.New,
+	"pbkdf2_sha256": sha256.New,
+	"pbkdf2_sha512": sha512.New,
+}
+
+// Hash - захешировать пароль argon2id с текущими параметрами
+func (s *Service) Hash(password string) (string, error) {
+	return argon2id.CreateHash(password, s.params)
+}
+
+// Verify - сверить пароль с хешем. Кроме argon2id понимает bcrypt и PBKDF2 хеши старой системы.
+// needsRehash - хеш устарел (другой алгоритм или параметры) и его нужно пересчитать через Hash.
+func (s *Service) Verify(password, encodedHash string) (needsRehash bool, err error) {
+	switch {
+	case encodedHash == "":
+		// accounts created through an identity provider have no password
+		return false, utils.ErrInvalidPassword
+	case strings.HasPrefix(encodedHash, "$argon2id$"):
+		return s.verifyArgon2id(password, encodedHash)
+	case strings.HasPrefix(encodedHash, "$2a$"), strings.HasPrefix(encodedHash, "$2b$"), strings.HasPrefix(encodedHash, "$2y$"):
+		return true, verifyBcrypt(password, encodedHash)
+	case strings.HasPrefix(encodedHash, "pbkdf2_"):
+		return true, verifyPBKDF2(password, encodedHash)
+	default:
+		return false, errUnsupportedHash
+	}
+}
+
+func (s *Service) verifyArgon2id(password, encodedHash string) (bool, error) {
+	match, params, err := argon2id.CheckHash(password, encodedHash)
+	if err != nil {
+		return false, err
+	}
+
+	if !match {
+		return false, utils.ErrInvalidPassword
+	}
+
+	return *params != *s.params, nil
+}
+
+func verifyBcrypt(password, encodedHash string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return utils.ErrInvalidPassword
+	}
+	return err
+}
+
+// verifyPBKDF2 - проверить хеш в формате "pbkdf2_<digest>$<iterations>$<salt>$<base64 key>"
+func verifyPBKDF2(password, encodedHash string) error {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 4 {
+		return errUnsupportedHash
+	}
+
+	digest, ok := pbkdf2Digests[parts[0]]
+	if !ok {
+		return errUnsupportedHash
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return fmt.Errorf("%w: invalid PBKDF2 iterations", errUnsupportedHash)
+	}
+
+	expected, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUnsupportedHash, err)
+	}
+
+	key, err := pbkdf2.Key(digest, password, []byte(parts[2]), iterations, len(expected))
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(key, expected) != 1 {
+		return utils.ErrInvalidPassword
+	}
+
+	return nil
+}
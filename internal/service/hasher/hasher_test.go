@@ -0,0 +1,85 @@
+package hasher
+
+import (
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/base64"
+	"hash"
+	"strconv"
+	"testing"
+
+	"github.com/alexedwards/argon2id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const testPassword = "SecurePassword123"
+
+func pbkdf2Hash(t *testing.T, name string, digest func() hash.Hash, iterations int) string {
+	t.Helper()
+	key, err := pbkdf2.Key(digest, testPassword, []byte("legacysalt"), iterations, 32)
+	require.NoError(t, err)
+	return name + "$" + strconv.Itoa(iterations) + "$legacysalt$" + base64.StdEncoding.EncodeToString(key)
+}
+
+func TestNewServiceParams(t *testing.T) {
+	service := NewService(&infra.Config{})
+	assert.Equal(t, *argon2id.DefaultParams, *service.params)
+
+	service = NewService(&infra.Config{PasswordHashMemory: 128 * 1024, PasswordHashIterations: 3})
+	assert.Equal(t, uint32(128*1024), service.params.Memory)
+	assert.Equal(t, uint32(3), service.params.Iterations)
+	assert.Equal(t, argon2id.DefaultParams.Parallelism, service.params.Parallelism)
+}
+
+func TestVerify(t *testing.T) {
+	service := NewService(&infra.Config{})
+
+	current, err := service.Hash(testPassword)
+	require.NoError(t, err)
+
+	stronger, err := NewService(&infra.Config{PasswordHashIterations: 2}).Hash(testPassword)
+	require.NoError(t, err)
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		hash          string
+		password      string
+		needsRehash   bool
+		expectedError error
+	}{
+		{name: "current argon2id", hash: current, password: testPassword},
+		{name: "argon2id with other params", hash: stronger, password: testPassword, needsRehash: true},
+		{name: "argon2id wrong password", hash: current, password: "WrongPassword", expectedError: utils.ErrInvalidPassword},
+		{name: "bcrypt", hash: string(bcryptHash), password: testPassword, needsRehash: true},
+		{name: "bcrypt wrong password", hash: string(bcryptHash), password: "WrongPassword", needsRehash: true, expectedError: utils.ErrInvalidPassword},
+		{name: "pbkdf2 sha1", hash: pbkdf2Hash(t, "pbkdf2_sha1", sha1.New, 1000), password: testPassword, needsRehash: true},
+		{name: "pbkdf2 sha512", hash: pbkdf2Hash(t, "pbkdf2_sha512", sha512.New, 1000), password: testPassword, needsRehash: true},
+		{name: "pbkdf2 wrong password", hash: pbkdf2Hash(t, "pbkdf2_sha1", sha1.New, 1000), password: "WrongPassword", needsRehash: true, expectedError: utils.ErrInvalidPassword},
+		{name: "pbkdf2 unknown digest", hash: "pbkdf2_md5$1000$salt$AAAA", password: testPassword, needsRehash: true, expectedError: errUnsupportedHash},
+		{name: "pbkdf2 bad iterations", hash: "pbkdf2_sha1$zero$salt$AAAA", password: testPassword, needsRehash: true, expectedError: errUnsupportedHash},
+		{name: "account without password", hash: "", password: "", expectedError: utils.ErrInvalidPassword},
+		{name: "unknown format", hash: "plain-text", password: testPassword, expectedError: errUnsupportedHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			needsRehash, err := service.Verify(tt.password, tt.hash)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.needsRehash, needsRehash)
+		})
+	}
+}
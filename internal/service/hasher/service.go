@@ -0,0 +1,34 @@
+package hasher
+
+import (
+	"github.com/alexedwards/argon2id"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+)
+
+type Service struct {
+	params *argon2id.Params
+}
+
+// NewService - создать новый экземпляр сервиса хеширования паролей.
+// Незаданные параметры берутся из argon2id.DefaultParams.
+func NewService(cfg *infra.Config) *Service {
+	params := *argon2id.DefaultParams
+	if cfg.PasswordHashMemory > 0 {
+		params.Memory = cfg.PasswordHashMemory
+	}
+	if cfg.PasswordHashIterations > 0 {
+		params.Iterations = cfg.PasswordHashIterations
+	}
+	if cfg.PasswordHashParallelism > 0 {
+		params.Parallelism = cfg.PasswordHashParallelism
+	}
+	if cfg.PasswordHashSaltLength > 0 {
+		params.SaltLength = cfg.PasswordHashSaltLength
+	}
+	if cfg.PasswordHashKeyLength > 0 {
+		params.KeyLength = cfg.PasswordHashKeyLength
+	}
+
+	return &Service{params: &params}
+}
@@ -0,0 +1,44 @@
+package impersonation
+
+import (
+	"context"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+// Exporter - раздел "impersonation_events" выгрузки персональных данных: журнал имперсонации, где пользователь был целью или администратором
+type Exporter struct {
+	service *Service
+}
+
+// NewExporter - выгрузить журнал имперсонации, в том числе действия самого пользователя как администратора
+func NewExporter(service *Service) *Exporter {
+	return &Exporter{service: service}
+}
+
+func (e *Exporter) Name() string {
+	return "impersonation_events"
+}
+
+func (e *Exporter) Export(ctx context.Context, userID string) (any, error) {
+	events, err := e.service.Events(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ExportImpersonationEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, model.ExportImpersonationEvent{
+			ID:        event.ID,
+			ActorID:   event.ActorID,
+			UserID:    event.UserID,
+			Action:    event.Action,
+			Status:    event.Status,
+			Reason:    event.Reason,
+			IP:        event.IP,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,98 @@
+package impersonation
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// Start - выдать администратору actor токен для входа от имени пользователя userID.
+// Причина обязательна и попадает в журнал. Нельзя выдать токен самому себе, из-под имперсонации
+// и пользователю, который сам может имперсонировать: иначе один администратор получил бы права другого.
+func (s *Service) Start(ctx context.Context, actor model.Principal, userID, reason string) (model.Impersonation, error) {
+	if actor.ActorID != "" {
+		return model.Impersonation{}, utils.ErrImpersonated
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return model.Impersonation{}, utils.ErrReasonRequired
+	}
+
+	if userID == actor.UserID {
+		return model.Impersonation{}, utils.ErrCannotImpersonate
+	}
+
+	if _, err := s.userRepository.GetUserByID(ctx, userID); err != nil {
+		return model.Impersonation{}, err
+	}
+
+	switch err := s.accessService.CheckPermission(ctx, userID, model.PermissionUsersImpersonate); {
+	case err == nil:
+		return model.Impersonation{}, utils.ErrCannotImpersonate
+	case !errors.Is(err, utils.ErrForbidden):
+		return model.Impersonation{}, err
+	}
+
+	impersonation, err := s.authService.GenerateImpersonationToken(ctx, userID, actor.UserID, actor.SessionID, s.ttl)
+	if err != nil {
+		return model.Impersonation{}, err
+	}
+
+	// the token is handed out only once its issue is in the journal
+	if err = s.Record(ctx, model.ImpersonationEvent{
+		ActorID: actor.UserID,
+		UserID:  userID,
+		Action:  model.ActionImpersonationStarted,
+		Reason:  reason,
+		IP:      model.ClientInfoFromContext(ctx).IP,
+	}); err != nil {
+		return model.Impersonation{}, err
+	}
+
+	return impersonation, nil
+}
+
+// Record - записать событие в журнал имперсонации
+func (s *Service) Record(ctx context.Context, event model.ImpersonationEvent) error {
+	return s.repository.Record(ctx, queries.ImpersonationEvent{
+		ID:      ulid.Make().String(),
+		ActorID: event.ActorID,
+		UserID:  event.UserID,
+		Action:  event.Action,
+		Status:  pgtype.Int4{Int32: int32(event.Status), Valid: event.Status != 0},
+		Reason:  pgtype.Text{String: event.Reason, Valid: event.Reason != ""},
+		Ip:      event.IP,
+	})
+}
+
+// Events - журнал имперсонации пользователя: где он был целью или администратором, сначала новые записи
+func (s *Service) Events(ctx context.Context, userID string) ([]model.ImpersonationEvent, error) {
+	stored, err := s.repository.GetEvents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ImpersonationEvent, 0, len(stored))
+	for _, event := range stored {
+		result = append(result, model.ImpersonationEvent{
+			ID:        event.ID,
+			ActorID:   event.ActorID,
+			UserID:    event.UserID,
+			Action:    event.Action,
+			Status:    int(event.Status.Int32),
+			Reason:    event.Reason.String,
+			IP:        event.Ip,
+			CreatedAt: event.CreatedAt.Time,
+		})
+	}
+
+	return result, nil
+}
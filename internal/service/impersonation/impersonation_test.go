@@ -0,0 +1,205 @@
+package impersonation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	serviceMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/service/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const (
+	testAdminID = "test-admin-456"
+	testUserID  = "test-user-123"
+	testReason  = "ticket #4821"
+)
+
+var (
+	admin = model.Principal{UserID: testAdminID, Roles: []string{model.RoleAdmin}, SessionID: "admin-session"}
+	errDB = errors.New("db is down")
+)
+
+type testEnv struct {
+	service        *Service
+	repository     *repositoryMocks.MockImpersonationRepository
+	userRepository *repositoryMocks.MockUserRepository
+	authService    *serviceMocks.MockAuthService
+	accessService  *serviceMocks.MockAccessService
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		repository:     repositoryMocks.NewMockImpersonationRepository(t),
+		userRepository: repositoryMocks.NewMockUserRepository(t),
+		authService:    serviceMocks.NewMockAuthService(t),
+		accessService:  serviceMocks.NewMockAccessService(t),
+	}
+	env.service = NewService(&infra.Config{ImpersonationTTL: 15 * time.Minute}, env.repository, env.userRepository, nil, nil)
+	env.service.authService = env.authService
+	env.service.accessService = env.accessService
+
+	return env
+}
+
+func TestStart(t *testing.T) {
+	ctx := model.WithClientInfo(context.Background(), model.ClientInfo{IP: "203.0.113.7"})
+	env := newTestEnv(t)
+
+	expected := model.Impersonation{AccessToken: "token", ExpiresAt: time.Now().Add(15 * time.Minute)}
+	env.userRepository.On("GetUserByID", ctx, testUserID).Return(queries.User{ID: testUserID}, nil).Once()
+	env.accessService.On("CheckPermission", ctx, testUserID, model.PermissionUsersImpersonate).Return(utils.ErrForbidden).Once()
+	env.authService.On("GenerateImpersonationToken", ctx, testUserID, testAdminID, admin.SessionID, 15*time.Minute).Return(expected, nil).Once()
+	env.repository.On("Record", ctx, mock.MatchedBy(func(event queries.ImpersonationEvent) bool {
+		return event.ID != "" && event.ActorID == testAdminID && event.UserID == testUserID &&
+			event.Action == model.ActionImpersonationStarted && !event.Status.Valid &&
+			event.Reason == pgtype.Text{String: testReason, Valid: true} && event.Ip == "203.0.113.7"
+	})).Return(nil).Once()
+
+	impersonation, err := env.service.Start(ctx, admin, testUserID, "  "+testReason+" ")
+	require.NoError(t, err)
+	assert.Equal(t, expected, impersonation)
+}
+
+func TestStartRejected(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		actor    model.Principal
+		userID   string
+		reason   string
+		setup    func(env *testEnv)
+		expected error
+	}{
+		{
+			name:     "already impersonating",
+			actor:    model.Principal{UserID: "another-user", ActorID: testAdminID},
+			userID:   testUserID,
+			reason:   testReason,
+			expected: utils.ErrImpersonated,
+		},
+		{
+			name:     "no reason",
+			actor:    admin,
+			userID:   testUserID,
+			reason:   "   ",
+			expected: utils.ErrReasonRequired,
+		},
+		{
+			name:     "self",
+			actor:    admin,
+			userID:   testAdminID,
+			reason:   testReason,
+			expected: utils.ErrCannotImpersonate,
+		},
+		{
+			name:   "unknown user",
+			actor:  admin,
+			userID: testUserID,
+			reason: testReason,
+			setup: func(env *testEnv) {
+				env.userRepository.On("GetUserByID", ctx, testUserID).Return(queries.User{}, pgx.ErrNoRows).Once()
+			},
+			expected: pgx.ErrNoRows,
+		},
+		{
+			name:   "another admin",
+			actor:  admin,
+			userID: testUserID,
+			reason: testReason,
+			setup: func(env *testEnv) {
+				env.userRepository.On("GetUserByID", ctx, testUserID).Return(queries.User{ID: testUserID}, nil).Once()
+				env.accessService.On("CheckPermission", ctx, testUserID, model.PermissionUsersImpersonate).Return(nil).Once()
+			},
+			expected: utils.ErrCannotImpersonate,
+		},
+		{
+			name:   "permission check fails",
+			actor:  admin,
+			userID: testUserID,
+			reason: testReason,
+			setup: func(env *testEnv) {
+				env.userRepository.On("GetUserByID", ctx, testUserID).Return(queries.User{ID: testUserID}, nil).Once()
+				env.accessService.On("CheckPermission", ctx, testUserID, model.PermissionUsersImpersonate).Return(errDB).Once()
+			},
+			expected: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			if tt.setup != nil {
+				tt.setup(env)
+			}
+
+			_, err := env.service.Start(ctx, tt.actor, tt.userID, tt.reason)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestStartNotIssuedWithoutJournal(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+
+	env.userRepository.On("GetUserByID", ctx, testUserID).Return(queries.User{ID: testUserID}, nil).Once()
+	env.accessService.On("CheckPermission", ctx, testUserID, model.PermissionUsersImpersonate).Return(utils.ErrForbidden).Once()
+	env.authService.On("GenerateImpersonationToken", ctx, testUserID, testAdminID, admin.SessionID, 15*time.Minute).Return(model.Impersonation{AccessToken: "token"}, nil).Once()
+	env.repository.On("Record", ctx, mock.Anything).Return(errDB).Once()
+
+	impersonation, err := env.service.Start(ctx, admin, testUserID, testReason)
+	assert.ErrorIs(t, err, errDB)
+	assert.Empty(t, impersonation.AccessToken)
+}
+
+func TestEvents(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+
+	createdAt := time.Now()
+	env.repository.On("GetEvents", ctx, testUserID).Return([]queries.ImpersonationEvent{
+		{
+			ID:        "2",
+			ActorID:   testAdminID,
+			UserID:    testUserID,
+			Action:    "GET /api/user/v1/me/sessions",
+			Status:    pgtype.Int4{Int32: 200, Valid: true},
+			Ip:        "203.0.113.7",
+			CreatedAt: pgtype.Timestamptz{Time: createdAt, Valid: true},
+		},
+		{
+			ID:        "1",
+			ActorID:   testAdminID,
+			UserID:    testUserID,
+			Action:    model.ActionImpersonationStarted,
+			Reason:    pgtype.Text{String: testReason, Valid: true},
+			Ip:        "203.0.113.7",
+			CreatedAt: pgtype.Timestamptz{Time: createdAt, Valid: true},
+		},
+	}, nil).Once()
+
+	events, err := env.service.Events(ctx, testUserID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, 200, events[0].Status)
+	assert.Empty(t, events[0].Reason)
+	assert.Equal(t, model.ActionImpersonationStarted, events[1].Action)
+	assert.Equal(t, testReason, events[1].Reason)
+	assert.Zero(t, events[1].Status)
+	assert.Equal(t, createdAt, events[1].CreatedAt)
+}
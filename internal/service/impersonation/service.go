@@ -0,0 +1,30 @@
+package impersonation
+
+import (
+	"time"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/repository"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/access"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/auth"
+)
+
+type Service struct {
+	ttl            time.Duration
+	repository     repository.ImpersonationRepository
+	userRepository repository.UserRepository
+	authService    service.AuthService
+	accessService  service.AccessService
+}
+
+// NewService - создать новый экземпляр сервиса имперсонации, токены выдаются сервисом авторизации
+func NewService(cfg *infra.Config, impersonationRepository repository.ImpersonationRepository, userRepository repository.UserRepository, authService *auth.Service, accessService *access.Service) *Service {
+	return &Service{
+		ttl:            cfg.ImpersonationTTL,
+		repository:     impersonationRepository,
+		userRepository: userRepository,
+		authService:    authService,
+		accessService:  accessService,
+	}
+}
@@ -0,0 +1,123 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// entry - найденный в каталоге пользователь, пароль которого подошёл
+type entry struct {
+	dn    string
+	id    string
+	email string
+}
+
+// directory - проверка пароля в каталоге
+type directory interface {
+	authenticate(email, password string) (entry, error)
+}
+
+// ldapDirectory - поиск записи пользователя сервисным аккаунтом и bind с её DN и паролем пользователя
+type ldapDirectory struct {
+	url            string
+	startTLS       bool
+	bindDN         string
+	bindPassword   string
+	baseDN         string
+	filter         string
+	emailAttribute string
+	idAttribute    string
+}
+
+func (d *ldapDirectory) authenticate(email, password string) (entry, error) {
+	conn, err := d.dial()
+	if err != nil {
+		return entry{}, err
+	}
+	defer conn.Close()
+
+	if d.bindDN != "" {
+		if err = conn.Bind(d.bindDN, d.bindPassword); err != nil {
+			return entry{}, fmt.Errorf("ldap service bind: %w", err)
+		}
+	}
+
+	result, err := conn.Search(goldap.NewSearchRequest(
+		d.baseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, int(requestTimeout.Seconds()), false,
+		strings.ReplaceAll(d.filter, "%s", goldap.EscapeFilter(email)),
+		[]string{d.emailAttribute, d.idAttribute}, nil,
+	))
+	if err != nil {
+		return entry{}, fmt.Errorf("ldap search: %w", err)
+	}
+
+	// an ambiguous filter must not let one user log in as another
+	if len(result.Entries) != 1 {
+		return entry{}, utils.ErrInvalidUser
+	}
+	found := result.Entries[0]
+
+	// a bind with an empty password is an unauthenticated bind, which servers accept
+	if password == "" {
+		return entry{}, utils.ErrInvalidPassword
+	}
+
+	if err = conn.Bind(found.DN, password); err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return entry{}, utils.ErrInvalidPassword
+		}
+		return entry{}, fmt.Errorf("ldap bind: %w", err)
+	}
+
+	id := found.GetRawAttributeValue(d.idAttribute)
+	if len(id) == 0 {
+		return entry{}, fmt.Errorf("ldap entry %s has no %s", found.DN, d.idAttribute)
+	}
+
+	return entry{
+		dn:    found.DN,
+		id:    formatID(id),
+		email: strings.TrimSpace(found.GetAttributeValue(d.emailAttribute)),
+	}, nil
+}
+
+// dial - открыть соединение, ldap:// при LDAP_START_TLS переводится на TLS до отправки паролей
+func (d *ldapDirectory) dial() (*goldap.Conn, error) {
+	conn, err := goldap.DialURL(d.url, goldap.DialWithDialer(&net.Dialer{Timeout: requestTimeout}))
+	if err != nil {
+		return nil, fmt.Errorf("ldap dial: %w", err)
+	}
+	conn.SetTimeout(requestTimeout)
+
+	if d.startTLS {
+		parsed, err := url.Parse(d.url)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		if err = conn.StartTLS(&tls.Config{ServerName: parsed.Hostname(), MinVersion: tls.VersionTLS12}); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ldap start tls: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// formatID - entryUUID хранится строкой, objectGUID Active Directory - 16 байтами, которые записываются в hex
+func formatID(raw []byte) string {
+	if utf8.Valid(raw) {
+		return string(raw)
+	}
+	return hex.EncodeToString(raw)
+}
@@ -0,0 +1,69 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func (s *Service) Name() string {
+	return model.AuthProviderLDAP
+}
+
+// Authenticate - проверить пароль в каталоге и войти пользователем, привязанным к записи каталога.
+// При первом входе пользователь создаётся, если его почта ещё не занята локальным аккаунтом.
+func (s *Service) Authenticate(ctx context.Context, email, password string) (queries.User, error) {
+	found, err := s.directory.authenticate(email, password)
+	if err != nil {
+		return queries.User{}, err
+	}
+
+	// the directory may keep the address in another attribute than the one searched by
+	if found.email == "" {
+		found.email = email
+	}
+
+	identity, err := s.repository.GetIdentity(ctx, model.AuthProviderLDAP, found.id)
+	if err == nil {
+		if err = s.repository.Touch(ctx, identity.Provider, identity.Subject, found.email); err != nil {
+			return queries.User{}, err
+		}
+		return s.userRepository.GetUserByID(ctx, identity.UserID)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return queries.User{}, err
+	}
+
+	return s.provision(ctx, found)
+}
+
+// provision - создать пользователя для записи каталога, вошедшей в первый раз
+func (s *Service) provision(ctx context.Context, found entry) (queries.User, error) {
+	if _, err := s.userRepository.GetUserByEmail(ctx, strings.ToLower(found.email)); err == nil {
+		return queries.User{}, utils.ErrIdentityNotLinked
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return queries.User{}, err
+	}
+
+	user := queries.User{
+		ID:    ulid.Make().String(),
+		Email: found.email,
+	}
+	identity := queries.UserIdentity{
+		Provider: model.AuthProviderLDAP,
+		Subject:  found.id,
+		Email:    found.email,
+	}
+	if err := s.repository.CreateUser(ctx, user, identity); err != nil {
+		return queries.User{}, err
+	}
+
+	return user, nil
+}
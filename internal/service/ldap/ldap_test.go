@@ -0,0 +1,157 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const (
+	testEmail    = "jdoe@example.com"
+	testPassword = "directory-password"
+	testEntryID  = "6f1e2b64-3c1a-4f0e-9d57-2a4c8e1b7d90"
+)
+
+// stubDirectory - каталог с одной записью
+type stubDirectory struct {
+	entry entry
+	err   error
+}
+
+func (d *stubDirectory) authenticate(email, password string) (entry, error) {
+	if d.err != nil {
+		return entry{}, d.err
+	}
+	if email != testEmail {
+		return entry{}, utils.ErrInvalidUser
+	}
+	if password != testPassword {
+		return entry{}, utils.ErrInvalidPassword
+	}
+	return d.entry, nil
+}
+
+type testEnv struct {
+	service        *Service
+	repository     *repositoryMocks.MockIdentityRepository
+	userRepository *repositoryMocks.MockUserRepository
+}
+
+func newTestEnv(t *testing.T, found entry) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		repository:     repositoryMocks.NewMockIdentityRepository(t),
+		userRepository: repositoryMocks.NewMockUserRepository(t),
+	}
+	env.service = NewService(&infra.Config{}, env.repository, env.userRepository)
+	env.service.directory = &stubDirectory{entry: found}
+
+	return env
+}
+
+func TestAuthenticateLinked(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, entry{dn: "uid=jdoe,ou=people,dc=example,dc=org", id: testEntryID, email: "John.Doe@example.com"})
+
+	user := queries.User{ID: "test-user-123", Email: "john.doe@example.com"}
+	env.repository.On("GetIdentity", ctx, model.AuthProviderLDAP, testEntryID).
+		Return(queries.UserIdentity{Provider: model.AuthProviderLDAP, Subject: testEntryID, UserID: user.ID}, nil).Once()
+	// the address is refreshed from the directory on every login
+	env.repository.On("Touch", ctx, model.AuthProviderLDAP, testEntryID, "John.Doe@example.com").Return(nil).Once()
+	env.userRepository.On("GetUserByID", ctx, user.ID).Return(user, nil).Once()
+
+	authenticated, err := env.service.Authenticate(ctx, testEmail, testPassword)
+	require.NoError(t, err)
+	assert.Equal(t, user, authenticated)
+}
+
+func TestAuthenticateProvisions(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, entry{dn: "uid=jdoe,ou=people,dc=example,dc=org", id: testEntryID})
+
+	env.repository.On("GetIdentity", ctx, model.AuthProviderLDAP, testEntryID).Return(queries.UserIdentity{}, pgx.ErrNoRows).Once()
+	env.userRepository.On("GetUserByEmail", ctx, testEmail).Return(queries.User{}, pgx.ErrNoRows).Once()
+	env.repository.On("CreateUser", ctx, mock.MatchedBy(func(user queries.User) bool {
+		return user.ID != "" && user.Email == testEmail && user.PasswordHash == ""
+	}), queries.UserIdentity{Provider: model.AuthProviderLDAP, Subject: testEntryID, Email: testEmail}).Return(nil).Once()
+
+	user, err := env.service.Authenticate(ctx, testEmail, testPassword)
+	require.NoError(t, err)
+	assert.NotEmpty(t, user.ID)
+	assert.Equal(t, testEmail, user.Email)
+}
+
+func TestAuthenticateRejected(t *testing.T) {
+	ctx := context.Background()
+	errDirectory := errors.New("ldap dial: connection refused")
+
+	tests := []struct {
+		name      string
+		email     string
+		password  string
+		directory error
+		setup     func(env *testEnv)
+		expected  error
+	}{
+		{
+			name:     "not in the directory",
+			email:    "nobody@example.com",
+			password: testPassword,
+			expected: utils.ErrInvalidUser,
+		},
+		{
+			name:     "wrong password",
+			email:    testEmail,
+			password: "wrong",
+			expected: utils.ErrInvalidPassword,
+		},
+		{
+			name:      "directory unavailable",
+			email:     testEmail,
+			password:  testPassword,
+			directory: errDirectory,
+			expected:  errDirectory,
+		},
+		{
+			name:     "email taken by a local account",
+			email:    testEmail,
+			password: testPassword,
+			setup: func(env *testEnv) {
+				env.repository.On("GetIdentity", ctx, model.AuthProviderLDAP, testEntryID).Return(queries.UserIdentity{}, pgx.ErrNoRows).Once()
+				env.userRepository.On("GetUserByEmail", ctx, testEmail).Return(queries.User{ID: "local-user"}, nil).Once()
+			},
+			expected: utils.ErrIdentityNotLinked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, entry{id: testEntryID, email: testEmail})
+			env.service.directory.(*stubDirectory).err = tt.directory
+			if tt.setup != nil {
+				tt.setup(env)
+			}
+
+			_, err := env.service.Authenticate(ctx, tt.email, tt.password)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestFormatID(t *testing.T) {
+	assert.Equal(t, testEntryID, formatID([]byte(testEntryID)))
+	// objectGUID is binary
+	assert.Equal(t, "64b21e6f1a3c0e4f9d572a4c8e1b7d90", formatID([]byte{0x64, 0xb2, 0x1e, 0x6f, 0x1a, 0x3c, 0x0e, 0x4f, 0x9d, 0x57, 0x2a, 0x4c, 0x8e, 0x1b, 0x7d, 0x90}))
+}
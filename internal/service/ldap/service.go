@@ -0,0 +1,36 @@
+package ldap
+
+import (
+	"time"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/repository"
+)
+
+// requestTimeout - сколько ждать ответа каталога
+const requestTimeout = 10 * time.Second
+
+type Service struct {
+	directory      directory
+	repository     repository.IdentityRepository
+	userRepository repository.UserRepository
+}
+
+// NewService - создать новый экземпляр провайдера входа через LDAP/Active Directory,
+// соединение с каталогом открывается на каждый вход
+func NewService(cfg *infra.Config, identityRepository repository.IdentityRepository, userRepository repository.UserRepository) *Service {
+	return &Service{
+		directory: &ldapDirectory{
+			url:            cfg.LDAPURL,
+			startTLS:       cfg.LDAPStartTLS,
+			bindDN:         cfg.LDAPBindDN,
+			bindPassword:   cfg.LDAPBindPassword,
+			baseDN:         cfg.LDAPBaseDN,
+			filter:         cfg.LDAPUserFilter,
+			emailAttribute: cfg.LDAPEmailAttribute,
+			idAttribute:    cfg.LDAPIDAttribute,
+		},
+		repository:     identityRepository,
+		userRepository: userRepository,
+	}
+}
@@ -6,6 +6,9 @@ package service
 
 import (
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"context"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -37,62 +40,2587 @@ func (_m *MockAuthService) EXPECT() *MockAuthService_Expecter {
 	return &MockAuthService_Expecter{mock: &_m.Mock}
 }
 
+// Authenticate provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) Authenticate(ctx context.Context, authHeader string) (model.Principal, error) {
+	ret := _mock.Called(ctx, authHeader)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 model.Principal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.Principal, error)); ok {
+		return returnFunc(ctx, authHeader)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.Principal); ok {
+		r0 = returnFunc(ctx, authHeader)
+	} else {
+		r0 = ret.Get(0).(model.Principal)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, authHeader)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockAuthService_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authHeader string
+func (_e *MockAuthService_Expecter) Authenticate(ctx interface{}, authHeader interface{}) *MockAuthService_Authenticate_Call {
+	return &MockAuthService_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, authHeader)}
+}
+
+func (_c *MockAuthService_Authenticate_Call) Run(run func(ctx context.Context, authHeader string)) *MockAuthService_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_Authenticate_Call) Return(principal model.Principal, err error) *MockAuthService_Authenticate_Call {
+	_c.Call.Return(principal, err)
+	return _c
+}
+
+func (_c *MockAuthService_Authenticate_Call) RunAndReturn(run func(ctx context.Context, authHeader string) (model.Principal, error)) *MockAuthService_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangePassword provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) ChangePassword(ctx context.Context, userID string, sessionID string, change model.PasswordChange) error {
+	ret := _mock.Called(ctx, userID, sessionID, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, model.PasswordChange) error); ok {
+		r0 = returnFunc(ctx, userID, sessionID, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type MockAuthService_ChangePassword_Call struct {
+	*mock.Call
+}
+
+// ChangePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - sessionID string
+//   - change model.PasswordChange
+func (_e *MockAuthService_Expecter) ChangePassword(ctx interface{}, userID interface{}, sessionID interface{}, change interface{}) *MockAuthService_ChangePassword_Call {
+	return &MockAuthService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, userID, sessionID, change)}
+}
+
+func (_c *MockAuthService_ChangePassword_Call) Run(run func(ctx context.Context, userID string, sessionID string, change model.PasswordChange)) *MockAuthService_ChangePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 model.PasswordChange
+		if args[3] != nil {
+			arg3 = args[3].(model.PasswordChange)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ChangePassword_Call) Return(err error) *MockAuthService_ChangePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, userID string, sessionID string, change model.PasswordChange) error) *MockAuthService_ChangePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteLogin provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) CompleteLogin(ctx context.Context, userID string) (model.LoginResult, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteLogin")
+	}
+
+	var r0 model.LoginResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.LoginResult, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.LoginResult); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(model.LoginResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_CompleteLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteLogin'
+type MockAuthService_CompleteLogin_Call struct {
+	*mock.Call
+}
+
+// CompleteLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAuthService_Expecter) CompleteLogin(ctx interface{}, userID interface{}) *MockAuthService_CompleteLogin_Call {
+	return &MockAuthService_CompleteLogin_Call{Call: _e.mock.On("CompleteLogin", ctx, userID)}
+}
+
+func (_c *MockAuthService_CompleteLogin_Call) Run(run func(ctx context.Context, userID string)) *MockAuthService_CompleteLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_CompleteLogin_Call) Return(loginResult model.LoginResult, err error) *MockAuthService_CompleteLogin_Call {
+	_c.Call.Return(loginResult, err)
+	return _c
+}
+
+func (_c *MockAuthService_CompleteLogin_Call) RunAndReturn(run func(ctx context.Context, userID string) (model.LoginResult, error)) *MockAuthService_CompleteLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmEmailChange provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) ConfirmEmailChange(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEmailChange")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_ConfirmEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmEmailChange'
+type MockAuthService_ConfirmEmailChange_Call struct {
+	*mock.Call
+}
+
+// ConfirmEmailChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockAuthService_Expecter) ConfirmEmailChange(ctx interface{}, token interface{}) *MockAuthService_ConfirmEmailChange_Call {
+	return &MockAuthService_ConfirmEmailChange_Call{Call: _e.mock.On("ConfirmEmailChange", ctx, token)}
+}
+
+func (_c *MockAuthService_ConfirmEmailChange_Call) Run(run func(ctx context.Context, token string)) *MockAuthService_ConfirmEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ConfirmEmailChange_Call) Return(err error) *MockAuthService_ConfirmEmailChange_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_ConfirmEmailChange_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockAuthService_ConfirmEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmTOTP provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) ConfirmTOTP(ctx context.Context, userID string, code string) ([]string, error) {
+	ret := _mock.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmTOTP")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return returnFunc(ctx, userID, code)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = returnFunc(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_ConfirmTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmTOTP'
+type MockAuthService_ConfirmTOTP_Call struct {
+	*mock.Call
+}
+
+// ConfirmTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - code string
+func (_e *MockAuthService_Expecter) ConfirmTOTP(ctx interface{}, userID interface{}, code interface{}) *MockAuthService_ConfirmTOTP_Call {
+	return &MockAuthService_ConfirmTOTP_Call{Call: _e.mock.On("ConfirmTOTP", ctx, userID, code)}
+}
+
+func (_c *MockAuthService_ConfirmTOTP_Call) Run(run func(ctx context.Context, userID string, code string)) *MockAuthService_ConfirmTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ConfirmTOTP_Call) Return(strings []string, err error) *MockAuthService_ConfirmTOTP_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockAuthService_ConfirmTOTP_Call) RunAndReturn(run func(ctx context.Context, userID string, code string) ([]string, error)) *MockAuthService_ConfirmTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAccount provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) DeleteAccount(ctx context.Context, userID string, password string) error {
+	ret := _mock.Called(ctx, userID, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAccount")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_DeleteAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAccount'
+type MockAuthService_DeleteAccount_Call struct {
+	*mock.Call
+}
+
+// DeleteAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - password string
+func (_e *MockAuthService_Expecter) DeleteAccount(ctx interface{}, userID interface{}, password interface{}) *MockAuthService_DeleteAccount_Call {
+	return &MockAuthService_DeleteAccount_Call{Call: _e.mock.On("DeleteAccount", ctx, userID, password)}
+}
+
+func (_c *MockAuthService_DeleteAccount_Call) Run(run func(ctx context.Context, userID string, password string)) *MockAuthService_DeleteAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_DeleteAccount_Call) Return(err error) *MockAuthService_DeleteAccount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_DeleteAccount_Call) RunAndReturn(run func(ctx context.Context, userID string, password string) error) *MockAuthService_DeleteAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableTOTP provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) DisableTOTP(ctx context.Context, userID string, code string) error {
+	ret := _mock.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableTOTP")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_DisableTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableTOTP'
+type MockAuthService_DisableTOTP_Call struct {
+	*mock.Call
+}
+
+// DisableTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - code string
+func (_e *MockAuthService_Expecter) DisableTOTP(ctx interface{}, userID interface{}, code interface{}) *MockAuthService_DisableTOTP_Call {
+	return &MockAuthService_DisableTOTP_Call{Call: _e.mock.On("DisableTOTP", ctx, userID, code)}
+}
+
+func (_c *MockAuthService_DisableTOTP_Call) Run(run func(ctx context.Context, userID string, code string)) *MockAuthService_DisableTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_DisableTOTP_Call) Return(err error) *MockAuthService_DisableTOTP_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_DisableTOTP_Call) RunAndReturn(run func(ctx context.Context, userID string, code string) error) *MockAuthService_DisableTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnrollTOTP provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) EnrollTOTP(ctx context.Context, userID string) (model.TOTPEnrollment, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollTOTP")
+	}
+
+	var r0 model.TOTPEnrollment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.TOTPEnrollment, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.TOTPEnrollment); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(model.TOTPEnrollment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_EnrollTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollTOTP'
+type MockAuthService_EnrollTOTP_Call struct {
+	*mock.Call
+}
+
+// EnrollTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAuthService_Expecter) EnrollTOTP(ctx interface{}, userID interface{}) *MockAuthService_EnrollTOTP_Call {
+	return &MockAuthService_EnrollTOTP_Call{Call: _e.mock.On("EnrollTOTP", ctx, userID)}
+}
+
+func (_c *MockAuthService_EnrollTOTP_Call) Run(run func(ctx context.Context, userID string)) *MockAuthService_EnrollTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_EnrollTOTP_Call) Return(tOTPEnrollment model.TOTPEnrollment, err error) *MockAuthService_EnrollTOTP_Call {
+	_c.Call.Return(tOTPEnrollment, err)
+	return _c
+}
+
+func (_c *MockAuthService_EnrollTOTP_Call) RunAndReturn(run func(ctx context.Context, userID string) (model.TOTPEnrollment, error)) *MockAuthService_EnrollTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForgotPassword provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) ForgotPassword(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_ForgotPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForgotPassword'
+type MockAuthService_ForgotPassword_Call struct {
+	*mock.Call
+}
+
+// ForgotPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockAuthService_Expecter) ForgotPassword(ctx interface{}, email interface{}) *MockAuthService_ForgotPassword_Call {
+	return &MockAuthService_ForgotPassword_Call{Call: _e.mock.On("ForgotPassword", ctx, email)}
+}
+
+func (_c *MockAuthService_ForgotPassword_Call) Run(run func(ctx context.Context, email string)) *MockAuthService_ForgotPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ForgotPassword_Call) Return(err error) *MockAuthService_ForgotPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_ForgotPassword_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockAuthService_ForgotPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateClientToken provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) GenerateClientToken(clientID string, scopes []string) (model.TokenPair, error) {
+	ret := _mock.Called(clientID, scopes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateClientToken")
+	}
+
+	var r0 model.TokenPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, []string) (model.TokenPair, error)); ok {
+		return returnFunc(clientID, scopes)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, []string) model.TokenPair); ok {
+		r0 = returnFunc(clientID, scopes)
+	} else {
+		r0 = ret.Get(0).(model.TokenPair)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = returnFunc(clientID, scopes)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_GenerateClientToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateClientToken'
+type MockAuthService_GenerateClientToken_Call struct {
+	*mock.Call
+}
+
+// GenerateClientToken is a helper method to define mock.On call
+//   - clientID string
+//   - scopes []string
+func (_e *MockAuthService_Expecter) GenerateClientToken(clientID interface{}, scopes interface{}) *MockAuthService_GenerateClientToken_Call {
+	return &MockAuthService_GenerateClientToken_Call{Call: _e.mock.On("GenerateClientToken", clientID, scopes)}
+}
+
+func (_c *MockAuthService_GenerateClientToken_Call) Run(run func(clientID string, scopes []string)) *MockAuthService_GenerateClientToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_GenerateClientToken_Call) Return(tokenPair model.TokenPair, err error) *MockAuthService_GenerateClientToken_Call {
+	_c.Call.Return(tokenPair, err)
+	return _c
+}
+
+func (_c *MockAuthService_GenerateClientToken_Call) RunAndReturn(run func(clientID string, scopes []string) (model.TokenPair, error)) *MockAuthService_GenerateClientToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateDownloadToken provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) GenerateDownloadToken(userID string, resource string, expiresAt time.Time) (string, error) {
+	ret := _mock.Called(userID, resource, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateDownloadToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, time.Time) (string, error)); ok {
+		return returnFunc(userID, resource, expiresAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, time.Time) string); ok {
+		r0 = returnFunc(userID, resource, expiresAt)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, time.Time) error); ok {
+		r1 = returnFunc(userID, resource, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_GenerateDownloadToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateDownloadToken'
+type MockAuthService_GenerateDownloadToken_Call struct {
+	*mock.Call
+}
+
+// GenerateDownloadToken is a helper method to define mock.On call
+//   - userID string
+//   - resource string
+//   - expiresAt time.Time
+func (_e *MockAuthService_Expecter) GenerateDownloadToken(userID interface{}, resource interface{}, expiresAt interface{}) *MockAuthService_GenerateDownloadToken_Call {
+	return &MockAuthService_GenerateDownloadToken_Call{Call: _e.mock.On("GenerateDownloadToken", userID, resource, expiresAt)}
+}
+
+func (_c *MockAuthService_GenerateDownloadToken_Call) Run(run func(userID string, resource string, expiresAt time.Time)) *MockAuthService_GenerateDownloadToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_GenerateDownloadToken_Call) Return(s string, err error) *MockAuthService_GenerateDownloadToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockAuthService_GenerateDownloadToken_Call) RunAndReturn(run func(userID string, resource string, expiresAt time.Time) (string, error)) *MockAuthService_GenerateDownloadToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateImpersonationToken provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) GenerateImpersonationToken(ctx context.Context, userID string, actorID string, actorSessionID string, ttl time.Duration) (model.Impersonation, error) {
+	ret := _mock.Called(ctx, userID, actorID, actorSessionID, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateImpersonationToken")
+	}
+
+	var r0 model.Impersonation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, time.Duration) (model.Impersonation, error)); ok {
+		return returnFunc(ctx, userID, actorID, actorSessionID, ttl)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, time.Duration) model.Impersonation); ok {
+		r0 = returnFunc(ctx, userID, actorID, actorSessionID, ttl)
+	} else {
+		r0 = ret.Get(0).(model.Impersonation)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, time.Duration) error); ok {
+		r1 = returnFunc(ctx, userID, actorID, actorSessionID, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_GenerateImpersonationToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateImpersonationToken'
+type MockAuthService_GenerateImpersonationToken_Call struct {
+	*mock.Call
+}
+
+// GenerateImpersonationToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - actorID string
+//   - actorSessionID string
+//   - ttl time.Duration
+func (_e *MockAuthService_Expecter) GenerateImpersonationToken(ctx interface{}, userID interface{}, actorID interface{}, actorSessionID interface{}, ttl interface{}) *MockAuthService_GenerateImpersonationToken_Call {
+	return &MockAuthService_GenerateImpersonationToken_Call{Call: _e.mock.On("GenerateImpersonationToken", ctx, userID, actorID, actorSessionID, ttl)}
+}
+
+func (_c *MockAuthService_GenerateImpersonationToken_Call) Run(run func(ctx context.Context, userID string, actorID string, actorSessionID string, ttl time.Duration)) *MockAuthService_GenerateImpersonationToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Duration
+		if args[4] != nil {
+			arg4 = args[4].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_GenerateImpersonationToken_Call) Return(impersonation model.Impersonation, err error) *MockAuthService_GenerateImpersonationToken_Call {
+	_c.Call.Return(impersonation, err)
+	return _c
+}
+
+func (_c *MockAuthService_GenerateImpersonationToken_Call) RunAndReturn(run func(ctx context.Context, userID string, actorID string, actorSessionID string, ttl time.Duration) (model.Impersonation, error)) *MockAuthService_GenerateImpersonationToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GenerateToken provides a mock function for the type MockAuthService
-func (_mock *MockAuthService) GenerateToken(userID string) (string, error) {
-	ret := _mock.Called(userID)
+func (_mock *MockAuthService) GenerateToken(userID string, roles ...string) (string, error) {
+	// roles ...string
+	_va := make([]interface{}, len(roles))
+	for _i := range roles {
+		_va[_i] = roles[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, userID)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, ...string) (string, error)); ok {
+		return returnFunc(userID, roles...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, ...string) string); ok {
+		r0 = returnFunc(userID, roles...)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, ...string) error); ok {
+		r1 = returnFunc(userID, roles...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_GenerateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateToken'
+type MockAuthService_GenerateToken_Call struct {
+	*mock.Call
+}
+
+// GenerateToken is a helper method to define mock.On call
+//   - userID string
+//   - roles []string
+func (_e *MockAuthService_Expecter) GenerateToken(userID interface{}, roles ...interface{}) *MockAuthService_GenerateToken_Call {
+	return &MockAuthService_GenerateToken_Call{Call: _e.mock.On("GenerateToken",
+		append([]interface{}{userID}, roles...)...)}
+}
+
+func (_c *MockAuthService_GenerateToken_Call) Run(run func(userID string, roles ...string)) *MockAuthService_GenerateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 []string
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_GenerateToken_Call) Return(s string, err error) *MockAuthService_GenerateToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockAuthService_GenerateToken_Call) RunAndReturn(run func(userID string, roles ...string) (string, error)) *MockAuthService_GenerateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IssueClientTokens provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) IssueClientTokens(ctx context.Context, userID string, clientID string, scopes []string) (model.TokenPair, error) {
+	ret := _mock.Called(ctx, userID, clientID, scopes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueClientTokens")
+	}
+
+	var r0 model.TokenPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string) (model.TokenPair, error)); ok {
+		return returnFunc(ctx, userID, clientID, scopes)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string) model.TokenPair); ok {
+		r0 = returnFunc(ctx, userID, clientID, scopes)
+	} else {
+		r0 = ret.Get(0).(model.TokenPair)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []string) error); ok {
+		r1 = returnFunc(ctx, userID, clientID, scopes)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_IssueClientTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IssueClientTokens'
+type MockAuthService_IssueClientTokens_Call struct {
+	*mock.Call
+}
+
+// IssueClientTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - clientID string
+//   - scopes []string
+func (_e *MockAuthService_Expecter) IssueClientTokens(ctx interface{}, userID interface{}, clientID interface{}, scopes interface{}) *MockAuthService_IssueClientTokens_Call {
+	return &MockAuthService_IssueClientTokens_Call{Call: _e.mock.On("IssueClientTokens", ctx, userID, clientID, scopes)}
+}
+
+func (_c *MockAuthService_IssueClientTokens_Call) Run(run func(ctx context.Context, userID string, clientID string, scopes []string)) *MockAuthService_IssueClientTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_IssueClientTokens_Call) Return(tokenPair model.TokenPair, err error) *MockAuthService_IssueClientTokens_Call {
+	_c.Call.Return(tokenPair, err)
+	return _c
+}
+
+func (_c *MockAuthService_IssueClientTokens_Call) RunAndReturn(run func(ctx context.Context, userID string, clientID string, scopes []string) (model.TokenPair, error)) *MockAuthService_IssueClientTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IssueTokens provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) IssueTokens(ctx context.Context, userID string) (model.TokenPair, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueTokens")
+	}
+
+	var r0 model.TokenPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.TokenPair, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.TokenPair); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(model.TokenPair)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_IssueTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IssueTokens'
+type MockAuthService_IssueTokens_Call struct {
+	*mock.Call
+}
+
+// IssueTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAuthService_Expecter) IssueTokens(ctx interface{}, userID interface{}) *MockAuthService_IssueTokens_Call {
+	return &MockAuthService_IssueTokens_Call{Call: _e.mock.On("IssueTokens", ctx, userID)}
+}
+
+func (_c *MockAuthService_IssueTokens_Call) Run(run func(ctx context.Context, userID string)) *MockAuthService_IssueTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_IssueTokens_Call) Return(tokenPair model.TokenPair, err error) *MockAuthService_IssueTokens_Call {
+	_c.Call.Return(tokenPair, err)
+	return _c
+}
+
+func (_c *MockAuthService_IssueTokens_Call) RunAndReturn(run func(ctx context.Context, userID string) (model.TokenPair, error)) *MockAuthService_IssueTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) Login(ctx context.Context, email string, password string, ip string) (model.LoginResult, error) {
+	ret := _mock.Called(ctx, email, password, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 model.LoginResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (model.LoginResult, error)); ok {
+		return returnFunc(ctx, email, password, ip)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) model.LoginResult); ok {
+		r0 = returnFunc(ctx, email, password, ip)
+	} else {
+		r0 = ret.Get(0).(model.LoginResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, email, password, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type MockAuthService_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+//   - ip string
+func (_e *MockAuthService_Expecter) Login(ctx interface{}, email interface{}, password interface{}, ip interface{}) *MockAuthService_Login_Call {
+	return &MockAuthService_Login_Call{Call: _e.mock.On("Login", ctx, email, password, ip)}
+}
+
+func (_c *MockAuthService_Login_Call) Run(run func(ctx context.Context, email string, password string, ip string)) *MockAuthService_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_Login_Call) Return(loginResult model.LoginResult, err error) *MockAuthService_Login_Call {
+	_c.Call.Return(loginResult, err)
+	return _c
+}
+
+func (_c *MockAuthService_Login_Call) RunAndReturn(run func(ctx context.Context, email string, password string, ip string) (model.LoginResult, error)) *MockAuthService_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginMagicLink provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) LoginMagicLink(ctx context.Context, token string, device string) (model.LoginResult, error) {
+	ret := _mock.Called(ctx, token, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginMagicLink")
+	}
+
+	var r0 model.LoginResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (model.LoginResult, error)); ok {
+		return returnFunc(ctx, token, device)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) model.LoginResult); ok {
+		r0 = returnFunc(ctx, token, device)
+	} else {
+		r0 = ret.Get(0).(model.LoginResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, token, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_LoginMagicLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginMagicLink'
+type MockAuthService_LoginMagicLink_Call struct {
+	*mock.Call
+}
+
+// LoginMagicLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - device string
+func (_e *MockAuthService_Expecter) LoginMagicLink(ctx interface{}, token interface{}, device interface{}) *MockAuthService_LoginMagicLink_Call {
+	return &MockAuthService_LoginMagicLink_Call{Call: _e.mock.On("LoginMagicLink", ctx, token, device)}
+}
+
+func (_c *MockAuthService_LoginMagicLink_Call) Run(run func(ctx context.Context, token string, device string)) *MockAuthService_LoginMagicLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_LoginMagicLink_Call) Return(loginResult model.LoginResult, err error) *MockAuthService_LoginMagicLink_Call {
+	_c.Call.Return(loginResult, err)
+	return _c
+}
+
+func (_c *MockAuthService_LoginMagicLink_Call) RunAndReturn(run func(ctx context.Context, token string, device string) (model.LoginResult, error)) *MockAuthService_LoginMagicLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginTOTP provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) LoginTOTP(ctx context.Context, challengeToken string, code string) (model.TokenPair, error) {
+	ret := _mock.Called(ctx, challengeToken, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginTOTP")
+	}
+
+	var r0 model.TokenPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (model.TokenPair, error)); ok {
+		return returnFunc(ctx, challengeToken, code)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) model.TokenPair); ok {
+		r0 = returnFunc(ctx, challengeToken, code)
+	} else {
+		r0 = ret.Get(0).(model.TokenPair)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, challengeToken, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_LoginTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginTOTP'
+type MockAuthService_LoginTOTP_Call struct {
+	*mock.Call
+}
+
+// LoginTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challengeToken string
+//   - code string
+func (_e *MockAuthService_Expecter) LoginTOTP(ctx interface{}, challengeToken interface{}, code interface{}) *MockAuthService_LoginTOTP_Call {
+	return &MockAuthService_LoginTOTP_Call{Call: _e.mock.On("LoginTOTP", ctx, challengeToken, code)}
+}
+
+func (_c *MockAuthService_LoginTOTP_Call) Run(run func(ctx context.Context, challengeToken string, code string)) *MockAuthService_LoginTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_LoginTOTP_Call) Return(tokenPair model.TokenPair, err error) *MockAuthService_LoginTOTP_Call {
+	_c.Call.Return(tokenPair, err)
+	return _c
+}
+
+func (_c *MockAuthService_LoginTOTP_Call) RunAndReturn(run func(ctx context.Context, challengeToken string, code string) (model.TokenPair, error)) *MockAuthService_LoginTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) Logout(ctx context.Context, authHeader string, refreshToken string) error {
+	ret := _mock.Called(ctx, authHeader, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, authHeader, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockAuthService_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authHeader string
+//   - refreshToken string
+func (_e *MockAuthService_Expecter) Logout(ctx interface{}, authHeader interface{}, refreshToken interface{}) *MockAuthService_Logout_Call {
+	return &MockAuthService_Logout_Call{Call: _e.mock.On("Logout", ctx, authHeader, refreshToken)}
+}
+
+func (_c *MockAuthService_Logout_Call) Run(run func(ctx context.Context, authHeader string, refreshToken string)) *MockAuthService_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_Logout_Call) Return(err error) *MockAuthService_Logout_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_Logout_Call) RunAndReturn(run func(ctx context.Context, authHeader string, refreshToken string) error) *MockAuthService_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogoutAll provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) LogoutAll(ctx context.Context, userID string) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_LogoutAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogoutAll'
+type MockAuthService_LogoutAll_Call struct {
+	*mock.Call
+}
+
+// LogoutAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAuthService_Expecter) LogoutAll(ctx interface{}, userID interface{}) *MockAuthService_LogoutAll_Call {
+	return &MockAuthService_LogoutAll_Call{Call: _e.mock.On("LogoutAll", ctx, userID)}
+}
+
+func (_c *MockAuthService_LogoutAll_Call) Run(run func(ctx context.Context, userID string)) *MockAuthService_LogoutAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_LogoutAll_Call) Return(err error) *MockAuthService_LogoutAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_LogoutAll_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockAuthService_LogoutAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotifyPasswordChanged provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) NotifyPasswordChanged(ctx context.Context, userID string) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyPasswordChanged")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_NotifyPasswordChanged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifyPasswordChanged'
+type MockAuthService_NotifyPasswordChanged_Call struct {
+	*mock.Call
+}
+
+// NotifyPasswordChanged is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAuthService_Expecter) NotifyPasswordChanged(ctx interface{}, userID interface{}) *MockAuthService_NotifyPasswordChanged_Call {
+	return &MockAuthService_NotifyPasswordChanged_Call{Call: _e.mock.On("NotifyPasswordChanged", ctx, userID)}
+}
+
+func (_c *MockAuthService_NotifyPasswordChanged_Call) Run(run func(ctx context.Context, userID string)) *MockAuthService_NotifyPasswordChanged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_NotifyPasswordChanged_Call) Return(err error) *MockAuthService_NotifyPasswordChanged_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_NotifyPasswordChanged_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockAuthService_NotifyPasswordChanged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublicKeys provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) PublicKeys() []model.JWK {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublicKeys")
+	}
+
+	var r0 []model.JWK
+	if returnFunc, ok := ret.Get(0).(func() []model.JWK); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.JWK)
+		}
+	}
+	return r0
+}
+
+// MockAuthService_PublicKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicKeys'
+type MockAuthService_PublicKeys_Call struct {
+	*mock.Call
+}
+
+// PublicKeys is a helper method to define mock.On call
+func (_e *MockAuthService_Expecter) PublicKeys() *MockAuthService_PublicKeys_Call {
+	return &MockAuthService_PublicKeys_Call{Call: _e.mock.On("PublicKeys")}
+}
+
+func (_c *MockAuthService_PublicKeys_Call) Run(run func()) *MockAuthService_PublicKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAuthService_PublicKeys_Call) Return(jWKs []model.JWK) *MockAuthService_PublicKeys_Call {
+	_c.Call.Return(jWKs)
+	return _c
+}
+
+func (_c *MockAuthService_PublicKeys_Call) RunAndReturn(run func() []model.JWK) *MockAuthService_PublicKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeletedAccounts provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) PurgeDeletedAccounts(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedAccounts")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_PurgeDeletedAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeletedAccounts'
+type MockAuthService_PurgeDeletedAccounts_Call struct {
+	*mock.Call
+}
+
+// PurgeDeletedAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuthService_Expecter) PurgeDeletedAccounts(ctx interface{}) *MockAuthService_PurgeDeletedAccounts_Call {
+	return &MockAuthService_PurgeDeletedAccounts_Call{Call: _e.mock.On("PurgeDeletedAccounts", ctx)}
+}
+
+func (_c *MockAuthService_PurgeDeletedAccounts_Call) Run(run func(ctx context.Context)) *MockAuthService_PurgeDeletedAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_PurgeDeletedAccounts_Call) Return(err error) *MockAuthService_PurgeDeletedAccounts_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_PurgeDeletedAccounts_Call) RunAndReturn(run func(ctx context.Context) error) *MockAuthService_PurgeDeletedAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeExpiredTokens provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) PurgeExpiredTokens(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpiredTokens")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_PurgeExpiredTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpiredTokens'
+type MockAuthService_PurgeExpiredTokens_Call struct {
+	*mock.Call
+}
+
+// PurgeExpiredTokens is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuthService_Expecter) PurgeExpiredTokens(ctx interface{}) *MockAuthService_PurgeExpiredTokens_Call {
+	return &MockAuthService_PurgeExpiredTokens_Call{Call: _e.mock.On("PurgeExpiredTokens", ctx)}
+}
+
+func (_c *MockAuthService_PurgeExpiredTokens_Call) Run(run func(ctx context.Context)) *MockAuthService_PurgeExpiredTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_PurgeExpiredTokens_Call) Return(err error) *MockAuthService_PurgeExpiredTokens_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_PurgeExpiredTokens_Call) RunAndReturn(run func(ctx context.Context) error) *MockAuthService_PurgeExpiredTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeLoginAttempts provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) PurgeLoginAttempts(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeLoginAttempts")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_PurgeLoginAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeLoginAttempts'
+type MockAuthService_PurgeLoginAttempts_Call struct {
+	*mock.Call
+}
+
+// PurgeLoginAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuthService_Expecter) PurgeLoginAttempts(ctx interface{}) *MockAuthService_PurgeLoginAttempts_Call {
+	return &MockAuthService_PurgeLoginAttempts_Call{Call: _e.mock.On("PurgeLoginAttempts", ctx)}
+}
+
+func (_c *MockAuthService_PurgeLoginAttempts_Call) Run(run func(ctx context.Context)) *MockAuthService_PurgeLoginAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_PurgeLoginAttempts_Call) Return(err error) *MockAuthService_PurgeLoginAttempts_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_PurgeLoginAttempts_Call) RunAndReturn(run func(ctx context.Context) error) *MockAuthService_PurgeLoginAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) Refresh(ctx context.Context, refreshToken string) (model.TokenPair, error) {
+	ret := _mock.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 model.TokenPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.TokenPair, error)); ok {
+		return returnFunc(ctx, refreshToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.TokenPair); ok {
+		r0 = returnFunc(ctx, refreshToken)
+	} else {
+		r0 = ret.Get(0).(model.TokenPair)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockAuthService_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *MockAuthService_Expecter) Refresh(ctx interface{}, refreshToken interface{}) *MockAuthService_Refresh_Call {
+	return &MockAuthService_Refresh_Call{Call: _e.mock.On("Refresh", ctx, refreshToken)}
+}
+
+func (_c *MockAuthService_Refresh_Call) Run(run func(ctx context.Context, refreshToken string)) *MockAuthService_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_Refresh_Call) Return(tokenPair model.TokenPair, err error) *MockAuthService_Refresh_Call {
+	_c.Call.Return(tokenPair, err)
+	return _c
+}
+
+func (_c *MockAuthService_Refresh_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (model.TokenPair, error)) *MockAuthService_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshClient provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) RefreshClient(ctx context.Context, refreshToken string, clientID string) (model.TokenPair, error) {
+	ret := _mock.Called(ctx, refreshToken, clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshClient")
+	}
+
+	var r0 model.TokenPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (model.TokenPair, error)); ok {
+		return returnFunc(ctx, refreshToken, clientID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) model.TokenPair); ok {
+		r0 = returnFunc(ctx, refreshToken, clientID)
+	} else {
+		r0 = ret.Get(0).(model.TokenPair)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, refreshToken, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_RefreshClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshClient'
+type MockAuthService_RefreshClient_Call struct {
+	*mock.Call
+}
+
+// RefreshClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+//   - clientID string
+func (_e *MockAuthService_Expecter) RefreshClient(ctx interface{}, refreshToken interface{}, clientID interface{}) *MockAuthService_RefreshClient_Call {
+	return &MockAuthService_RefreshClient_Call{Call: _e.mock.On("RefreshClient", ctx, refreshToken, clientID)}
+}
+
+func (_c *MockAuthService_RefreshClient_Call) Run(run func(ctx context.Context, refreshToken string, clientID string)) *MockAuthService_RefreshClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_RefreshClient_Call) Return(tokenPair model.TokenPair, err error) *MockAuthService_RefreshClient_Call {
+	_c.Call.Return(tokenPair, err)
+	return _c
+}
+
+func (_c *MockAuthService_RefreshClient_Call) RunAndReturn(run func(ctx context.Context, refreshToken string, clientID string) (model.TokenPair, error)) *MockAuthService_RefreshClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegenerateRecoveryCodes provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) RegenerateRecoveryCodes(ctx context.Context, userID string, code string) ([]string, error) {
+	ret := _mock.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateRecoveryCodes")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return returnFunc(ctx, userID, code)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = returnFunc(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_RegenerateRecoveryCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegenerateRecoveryCodes'
+type MockAuthService_RegenerateRecoveryCodes_Call struct {
+	*mock.Call
+}
+
+// RegenerateRecoveryCodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - code string
+func (_e *MockAuthService_Expecter) RegenerateRecoveryCodes(ctx interface{}, userID interface{}, code interface{}) *MockAuthService_RegenerateRecoveryCodes_Call {
+	return &MockAuthService_RegenerateRecoveryCodes_Call{Call: _e.mock.On("RegenerateRecoveryCodes", ctx, userID, code)}
+}
+
+func (_c *MockAuthService_RegenerateRecoveryCodes_Call) Run(run func(ctx context.Context, userID string, code string)) *MockAuthService_RegenerateRecoveryCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_RegenerateRecoveryCodes_Call) Return(strings []string, err error) *MockAuthService_RegenerateRecoveryCodes_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockAuthService_RegenerateRecoveryCodes_Call) RunAndReturn(run func(ctx context.Context, userID string, code string) ([]string, error)) *MockAuthService_RegenerateRecoveryCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestEmailChange provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) RequestEmailChange(ctx context.Context, userID string, newEmail string, password string) error {
+	ret := _mock.Called(ctx, userID, newEmail, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestEmailChange")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, newEmail, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_RequestEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestEmailChange'
+type MockAuthService_RequestEmailChange_Call struct {
+	*mock.Call
+}
+
+// RequestEmailChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - newEmail string
+//   - password string
+func (_e *MockAuthService_Expecter) RequestEmailChange(ctx interface{}, userID interface{}, newEmail interface{}, password interface{}) *MockAuthService_RequestEmailChange_Call {
+	return &MockAuthService_RequestEmailChange_Call{Call: _e.mock.On("RequestEmailChange", ctx, userID, newEmail, password)}
+}
+
+func (_c *MockAuthService_RequestEmailChange_Call) Run(run func(ctx context.Context, userID string, newEmail string, password string)) *MockAuthService_RequestEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_RequestEmailChange_Call) Return(err error) *MockAuthService_RequestEmailChange_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_RequestEmailChange_Call) RunAndReturn(run func(ctx context.Context, userID string, newEmail string, password string) error) *MockAuthService_RequestEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResendVerificationEmail provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) ResendVerificationEmail(ctx context.Context, email string) error {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerificationEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_ResendVerificationEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendVerificationEmail'
+type MockAuthService_ResendVerificationEmail_Call struct {
+	*mock.Call
+}
+
+// ResendVerificationEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockAuthService_Expecter) ResendVerificationEmail(ctx interface{}, email interface{}) *MockAuthService_ResendVerificationEmail_Call {
+	return &MockAuthService_ResendVerificationEmail_Call{Call: _e.mock.On("ResendVerificationEmail", ctx, email)}
+}
+
+func (_c *MockAuthService_ResendVerificationEmail_Call) Run(run func(ctx context.Context, email string)) *MockAuthService_ResendVerificationEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ResendVerificationEmail_Call) Return(err error) *MockAuthService_ResendVerificationEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_ResendVerificationEmail_Call) RunAndReturn(run func(ctx context.Context, email string) error) *MockAuthService_ResendVerificationEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) ResetPassword(ctx context.Context, token string, password string) error {
+	ret := _mock.Called(ctx, token, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, token, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type MockAuthService_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - password string
+func (_e *MockAuthService_Expecter) ResetPassword(ctx interface{}, token interface{}, password interface{}) *MockAuthService_ResetPassword_Call {
+	return &MockAuthService_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, token, password)}
+}
+
+func (_c *MockAuthService_ResetPassword_Call) Run(run func(ctx context.Context, token string, password string)) *MockAuthService_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ResetPassword_Call) Return(err error) *MockAuthService_ResetPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, token string, password string) error) *MockAuthService_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreAccount provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) RestoreAccount(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAccount")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_RestoreAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreAccount'
+type MockAuthService_RestoreAccount_Call struct {
+	*mock.Call
+}
+
+// RestoreAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockAuthService_Expecter) RestoreAccount(ctx interface{}, token interface{}) *MockAuthService_RestoreAccount_Call {
+	return &MockAuthService_RestoreAccount_Call{Call: _e.mock.On("RestoreAccount", ctx, token)}
+}
+
+func (_c *MockAuthService_RestoreAccount_Call) Run(run func(ctx context.Context, token string)) *MockAuthService_RestoreAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_RestoreAccount_Call) Return(err error) *MockAuthService_RestoreAccount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_RestoreAccount_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockAuthService_RestoreAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevertEmailChange provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) RevertEmailChange(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertEmailChange")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_RevertEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertEmailChange'
+type MockAuthService_RevertEmailChange_Call struct {
+	*mock.Call
+}
+
+// RevertEmailChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockAuthService_Expecter) RevertEmailChange(ctx interface{}, token interface{}) *MockAuthService_RevertEmailChange_Call {
+	return &MockAuthService_RevertEmailChange_Call{Call: _e.mock.On("RevertEmailChange", ctx, token)}
+}
+
+func (_c *MockAuthService_RevertEmailChange_Call) Run(run func(ctx context.Context, token string)) *MockAuthService_RevertEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_RevertEmailChange_Call) Return(err error) *MockAuthService_RevertEmailChange_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_RevertEmailChange_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockAuthService_RevertEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	ret := _mock.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type MockAuthService_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - sessionID string
+func (_e *MockAuthService_Expecter) RevokeSession(ctx interface{}, userID interface{}, sessionID interface{}) *MockAuthService_RevokeSession_Call {
+	return &MockAuthService_RevokeSession_Call{Call: _e.mock.On("RevokeSession", ctx, userID, sessionID)}
+}
+
+func (_c *MockAuthService_RevokeSession_Call) Run(run func(ctx context.Context, userID string, sessionID string)) *MockAuthService_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_RevokeSession_Call) Return(err error) *MockAuthService_RevokeSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_RevokeSession_Call) RunAndReturn(run func(ctx context.Context, userID string, sessionID string) error) *MockAuthService_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMagicLink provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) SendMagicLink(ctx context.Context, email string) (string, error) {
+	ret := _mock.Called(ctx, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GenerateToken")
+		panic("no return value specified for SendMagicLink")
 	}
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return returnFunc(userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, email)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
-		r0 = returnFunc(userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, email)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockAuthService_GenerateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateToken'
-type MockAuthService_GenerateToken_Call struct {
+// MockAuthService_SendMagicLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMagicLink'
+type MockAuthService_SendMagicLink_Call struct {
 	*mock.Call
 }
 
-// GenerateToken is a helper method to define mock.On call
-//   - userID string
-func (_e *MockAuthService_Expecter) GenerateToken(userID interface{}) *MockAuthService_GenerateToken_Call {
-	return &MockAuthService_GenerateToken_Call{Call: _e.mock.On("GenerateToken", userID)}
+// SendMagicLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockAuthService_Expecter) SendMagicLink(ctx interface{}, email interface{}) *MockAuthService_SendMagicLink_Call {
+	return &MockAuthService_SendMagicLink_Call{Call: _e.mock.On("SendMagicLink", ctx, email)}
 }
 
-func (_c *MockAuthService_GenerateToken_Call) Run(run func(userID string)) *MockAuthService_GenerateToken_Call {
+func (_c *MockAuthService_SendMagicLink_Call) Run(run func(ctx context.Context, email string)) *MockAuthService_SendMagicLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockAuthService_GenerateToken_Call) Return(s string, err error) *MockAuthService_GenerateToken_Call {
+func (_c *MockAuthService_SendMagicLink_Call) Return(s string, err error) *MockAuthService_SendMagicLink_Call {
 	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockAuthService_GenerateToken_Call) RunAndReturn(run func(userID string) (string, error)) *MockAuthService_GenerateToken_Call {
+func (_c *MockAuthService_SendMagicLink_Call) RunAndReturn(run func(ctx context.Context, email string) (string, error)) *MockAuthService_SendMagicLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendVerificationEmail provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) SendVerificationEmail(ctx context.Context, userID string) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendVerificationEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_SendVerificationEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendVerificationEmail'
+type MockAuthService_SendVerificationEmail_Call struct {
+	*mock.Call
+}
+
+// SendVerificationEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAuthService_Expecter) SendVerificationEmail(ctx interface{}, userID interface{}) *MockAuthService_SendVerificationEmail_Call {
+	return &MockAuthService_SendVerificationEmail_Call{Call: _e.mock.On("SendVerificationEmail", ctx, userID)}
+}
+
+func (_c *MockAuthService_SendVerificationEmail_Call) Run(run func(ctx context.Context, userID string)) *MockAuthService_SendVerificationEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_SendVerificationEmail_Call) Return(err error) *MockAuthService_SendVerificationEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_SendVerificationEmail_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockAuthService_SendVerificationEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sessions provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) Sessions(ctx context.Context, userID string, currentID string) ([]model.Session, error) {
+	ret := _mock.Called(ctx, userID, currentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sessions")
+	}
+
+	var r0 []model.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]model.Session, error)); ok {
+		return returnFunc(ctx, userID, currentID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []model.Session); ok {
+		r0 = returnFunc(ctx, userID, currentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, currentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_Sessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sessions'
+type MockAuthService_Sessions_Call struct {
+	*mock.Call
+}
+
+// Sessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - currentID string
+func (_e *MockAuthService_Expecter) Sessions(ctx interface{}, userID interface{}, currentID interface{}) *MockAuthService_Sessions_Call {
+	return &MockAuthService_Sessions_Call{Call: _e.mock.On("Sessions", ctx, userID, currentID)}
+}
+
+func (_c *MockAuthService_Sessions_Call) Run(run func(ctx context.Context, userID string, currentID string)) *MockAuthService_Sessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_Sessions_Call) Return(sessions []model.Session, err error) *MockAuthService_Sessions_Call {
+	_c.Call.Return(sessions, err)
+	return _c
+}
+
+func (_c *MockAuthService_Sessions_Call) RunAndReturn(run func(ctx context.Context, userID string, currentID string) ([]model.Session, error)) *MockAuthService_Sessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnlockAccount provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) UnlockAccount(ctx context.Context, userID string) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockAccount")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_UnlockAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockAccount'
+type MockAuthService_UnlockAccount_Call struct {
+	*mock.Call
+}
+
+// UnlockAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockAuthService_Expecter) UnlockAccount(ctx interface{}, userID interface{}) *MockAuthService_UnlockAccount_Call {
+	return &MockAuthService_UnlockAccount_Call{Call: _e.mock.On("UnlockAccount", ctx, userID)}
+}
+
+func (_c *MockAuthService_UnlockAccount_Call) Run(run func(ctx context.Context, userID string)) *MockAuthService_UnlockAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_UnlockAccount_Call) Return(err error) *MockAuthService_UnlockAccount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_UnlockAccount_Call) RunAndReturn(run func(ctx context.Context, userID string) error) *MockAuthService_UnlockAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyDownloadToken provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) VerifyDownloadToken(ctx context.Context, token string) (string, string, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyDownloadToken")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, string, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, token)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAuthService_VerifyDownloadToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyDownloadToken'
+type MockAuthService_VerifyDownloadToken_Call struct {
+	*mock.Call
+}
+
+// VerifyDownloadToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockAuthService_Expecter) VerifyDownloadToken(ctx interface{}, token interface{}) *MockAuthService_VerifyDownloadToken_Call {
+	return &MockAuthService_VerifyDownloadToken_Call{Call: _e.mock.On("VerifyDownloadToken", ctx, token)}
+}
+
+func (_c *MockAuthService_VerifyDownloadToken_Call) Run(run func(ctx context.Context, token string)) *MockAuthService_VerifyDownloadToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_VerifyDownloadToken_Call) Return(s string, s0 string, err error) *MockAuthService_VerifyDownloadToken_Call {
+	_c.Call.Return(s, s0, err)
+	return _c
+}
+
+func (_c *MockAuthService_VerifyDownloadToken_Call) RunAndReturn(run func(ctx context.Context, token string) (string, string, error)) *MockAuthService_VerifyDownloadToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyEmail provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) VerifyEmail(ctx context.Context, token string) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type MockAuthService_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockAuthService_Expecter) VerifyEmail(ctx interface{}, token interface{}) *MockAuthService_VerifyEmail_Call {
+	return &MockAuthService_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", ctx, token)}
+}
+
+func (_c *MockAuthService_VerifyEmail_Call) Run(run func(ctx context.Context, token string)) *MockAuthService_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_VerifyEmail_Call) Return(err error) *MockAuthService_VerifyEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_VerifyEmail_Call) RunAndReturn(run func(ctx context.Context, token string) error) *MockAuthService_VerifyEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -155,8 +2683,8 @@ func (_c *MockAuthService_VerifyPassword_Call) RunAndReturn(run func(user querie
 }
 
 // VerifyToken provides a mock function for the type MockAuthService
-func (_mock *MockAuthService) VerifyToken(authHeader string) (string, error) {
-	ret := _mock.Called(authHeader)
+func (_mock *MockAuthService) VerifyToken(ctx context.Context, authHeader string) (string, error) {
+	ret := _mock.Called(ctx, authHeader)
 
 	if len(ret) == 0 {
 		panic("no return value specified for VerifyToken")
@@ -164,16 +2692,16 @@ func (_mock *MockAuthService) VerifyToken(authHeader string) (string, error) {
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return returnFunc(authHeader)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, authHeader)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
-		r0 = returnFunc(authHeader)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, authHeader)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(authHeader)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, authHeader)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -186,19 +2714,25 @@ type MockAuthService_VerifyToken_Call struct {
 }
 
 // VerifyToken is a helper method to define mock.On call
+//   - ctx context.Context
 //   - authHeader string
-func (_e *MockAuthService_Expecter) VerifyToken(authHeader interface{}) *MockAuthService_VerifyToken_Call {
-	return &MockAuthService_VerifyToken_Call{Call: _e.mock.On("VerifyToken", authHeader)}
+func (_e *MockAuthService_Expecter) VerifyToken(ctx interface{}, authHeader interface{}) *MockAuthService_VerifyToken_Call {
+	return &MockAuthService_VerifyToken_Call{Call: _e.mock.On("VerifyToken", ctx, authHeader)}
 }
 
-func (_c *MockAuthService_VerifyToken_Call) Run(run func(authHeader string)) *MockAuthService_VerifyToken_Call {
+func (_c *MockAuthService_VerifyToken_Call) Run(run func(ctx context.Context, authHeader string)) *MockAuthService_VerifyToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -209,7 +2743,7 @@ func (_c *MockAuthService_VerifyToken_Call) Return(s string, err error) *MockAut
 	return _c
 }
 
-func (_c *MockAuthService_VerifyToken_Call) RunAndReturn(run func(authHeader string) (string, error)) *MockAuthService_VerifyToken_Call {
+func (_c *MockAuthService_VerifyToken_Call) RunAndReturn(run func(ctx context.Context, authHeader string) (string, error)) *MockAuthService_VerifyToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
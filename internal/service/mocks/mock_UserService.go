@@ -6,6 +6,7 @@ package service
 
 import (
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
 	"context"
 
 	mock "github.com/stretchr/testify/mock"
@@ -38,46 +39,45 @@ func (_m *MockUserService) EXPECT() *MockUserService_Expecter {
 	return &MockUserService_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type MockUserService
-func (_mock *MockUserService) Create(ctx context.Context, email string, password string) (string, error) {
-	ret := _mock.Called(ctx, email, password)
+// GetByEmail provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetByEmail(ctx context.Context, email string) (queries.User, error) {
+	ret := _mock.Called(ctx, email)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for GetByEmail")
 	}
 
-	var r0 string
+	var r0 queries.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (queries.User, error)); ok {
+		return returnFunc(ctx, email)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) queries.User); ok {
+		r0 = returnFunc(ctx, email)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(queries.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, email, password)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockUserService_Create_Call struct {
+// MockUserService_GetByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEmail'
+type MockUserService_GetByEmail_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// GetByEmail is a helper method to define mock.On call
 //   - ctx context.Context
 //   - email string
-//   - password string
-func (_e *MockUserService_Expecter) Create(ctx interface{}, email interface{}, password interface{}) *MockUserService_Create_Call {
-	return &MockUserService_Create_Call{Call: _e.mock.On("Create", ctx, email, password)}
+func (_e *MockUserService_Expecter) GetByEmail(ctx interface{}, email interface{}) *MockUserService_GetByEmail_Call {
+	return &MockUserService_GetByEmail_Call{Call: _e.mock.On("GetByEmail", ctx, email)}
 }
 
-func (_c *MockUserService_Create_Call) Run(run func(ctx context.Context, email string, password string)) *MockUserService_Create_Call {
+func (_c *MockUserService_GetByEmail_Call) Run(run func(ctx context.Context, email string)) *MockUserService_GetByEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -87,68 +87,63 @@ func (_c *MockUserService_Create_Call) Run(run func(ctx context.Context, email s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserService_Create_Call) Return(s string, err error) *MockUserService_Create_Call {
-	_c.Call.Return(s, err)
+func (_c *MockUserService_GetByEmail_Call) Return(user queries.User, err error) *MockUserService_GetByEmail_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockUserService_Create_Call) RunAndReturn(run func(ctx context.Context, email string, password string) (string, error)) *MockUserService_Create_Call {
+func (_c *MockUserService_GetByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (queries.User, error)) *MockUserService_GetByEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByEmail provides a mock function for the type MockUserService
-func (_mock *MockUserService) GetByEmail(ctx context.Context, email string) (queries.User, error) {
-	ret := _mock.Called(ctx, email)
+// GetByID provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetByID(ctx context.Context, id string) (queries.User, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByEmail")
+		panic("no return value specified for GetByID")
 	}
 
 	var r0 queries.User
 	var r1 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (queries.User, error)); ok {
-		return returnFunc(ctx, email)
+		return returnFunc(ctx, id)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string) queries.User); ok {
-		r0 = returnFunc(ctx, email)
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Get(0).(queries.User)
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, email)
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserService_GetByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEmail'
-type MockUserService_GetByEmail_Call struct {
+// MockUserService_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockUserService_GetByID_Call struct {
 	*mock.Call
 }
 
-// GetByEmail is a helper method to define mock.On call
+// GetByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-func (_e *MockUserService_Expecter) GetByEmail(ctx interface{}, email interface{}) *MockUserService_GetByEmail_Call {
-	return &MockUserService_GetByEmail_Call{Call: _e.mock.On("GetByEmail", ctx, email)}
+//   - id string
+func (_e *MockUserService_Expecter) GetByID(ctx interface{}, id interface{}) *MockUserService_GetByID_Call {
+	return &MockUserService_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
 }
 
-func (_c *MockUserService_GetByEmail_Call) Run(run func(ctx context.Context, email string)) *MockUserService_GetByEmail_Call {
+func (_c *MockUserService_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockUserService_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -166,55 +161,128 @@ func (_c *MockUserService_GetByEmail_Call) Run(run func(ctx context.Context, ema
 	return _c
 }
 
-func (_c *MockUserService_GetByEmail_Call) Return(user queries.User, err error) *MockUserService_GetByEmail_Call {
+func (_c *MockUserService_GetByID_Call) Return(user queries.User, err error) *MockUserService_GetByID_Call {
 	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockUserService_GetByEmail_Call) RunAndReturn(run func(ctx context.Context, email string) (queries.User, error)) *MockUserService_GetByEmail_Call {
+func (_c *MockUserService_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (queries.User, error)) *MockUserService_GetByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function for the type MockUserService
-func (_mock *MockUserService) GetByID(ctx context.Context, id string) (queries.User, error) {
-	ret := _mock.Called(ctx, id)
+// Register provides a mock function for the type MockUserService
+func (_mock *MockUserService) Register(ctx context.Context, email string, password string) (string, error) {
+	ret := _mock.Called(ctx, email, password)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for Register")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, email, password)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, email, password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, email, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockUserService_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - password string
+func (_e *MockUserService_Expecter) Register(ctx interface{}, email interface{}, password interface{}) *MockUserService_Register_Call {
+	return &MockUserService_Register_Call{Call: _e.mock.On("Register", ctx, email, password)}
+}
+
+func (_c *MockUserService_Register_Call) Run(run func(ctx context.Context, email string, password string)) *MockUserService_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_Register_Call) Return(s string, err error) *MockUserService_Register_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockUserService_Register_Call) RunAndReturn(run func(ctx context.Context, email string, password string) (string, error)) *MockUserService_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfile provides a mock function for the type MockUserService
+func (_mock *MockUserService) UpdateProfile(ctx context.Context, id string, update model.ProfileUpdate) (queries.User, error) {
+	ret := _mock.Called(ctx, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
 	}
 
 	var r0 queries.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (queries.User, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.ProfileUpdate) (queries.User, error)); ok {
+		return returnFunc(ctx, id, update)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) queries.User); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.ProfileUpdate) queries.User); ok {
+		r0 = returnFunc(ctx, id, update)
 	} else {
 		r0 = ret.Get(0).(queries.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.ProfileUpdate) error); ok {
+		r1 = returnFunc(ctx, id, update)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserService_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type MockUserService_GetByID_Call struct {
+// MockUserService_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type MockUserService_UpdateProfile_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
+// UpdateProfile is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-func (_e *MockUserService_Expecter) GetByID(ctx interface{}, id interface{}) *MockUserService_GetByID_Call {
-	return &MockUserService_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+//   - update model.ProfileUpdate
+func (_e *MockUserService_Expecter) UpdateProfile(ctx interface{}, id interface{}, update interface{}) *MockUserService_UpdateProfile_Call {
+	return &MockUserService_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, id, update)}
 }
 
-func (_c *MockUserService_GetByID_Call) Run(run func(ctx context.Context, id string)) *MockUserService_GetByID_Call {
+func (_c *MockUserService_UpdateProfile_Call) Run(run func(ctx context.Context, id string, update model.ProfileUpdate)) *MockUserService_UpdateProfile_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -224,20 +292,25 @@ func (_c *MockUserService_GetByID_Call) Run(run func(ctx context.Context, id str
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 model.ProfileUpdate
+		if args[2] != nil {
+			arg2 = args[2].(model.ProfileUpdate)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserService_GetByID_Call) Return(user queries.User, err error) *MockUserService_GetByID_Call {
+func (_c *MockUserService_UpdateProfile_Call) Return(user queries.User, err error) *MockUserService_UpdateProfile_Call {
 	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockUserService_GetByID_Call) RunAndReturn(run func(ctx context.Context, id string) (queries.User, error)) *MockUserService_GetByID_Call {
+func (_c *MockUserService_UpdateProfile_Call) RunAndReturn(run func(ctx context.Context, id string, update model.ProfileUpdate) (queries.User, error)) *MockUserService_UpdateProfile_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,234 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// registrableGrants - grant types, которые указываются при регистрации клиента
+var registrableGrants = []string{model.GrantAuthorizationCode, model.GrantClientCredentials}
+
+// CreateClient - зарегистрировать клиента от имени principal. Секрет конфиденциального клиента возвращается один раз,
+// в БД хранится только его хеш. Выдать клиенту можно только scope, которые есть и у principal, и у ролей его пользователя.
+func (s *Service) CreateClient(ctx context.Context, principal model.Principal, client model.OAuthClient) (model.OAuthClient, error) {
+	client.Name = strings.TrimSpace(client.Name)
+	if client.Name == "" {
+		return model.OAuthClient{}, utils.ErrNameRequired
+	}
+
+	// NOT NULL columns, nil would be stored as NULL
+	client.GrantTypes = normalize(client.GrantTypes)
+	client.Scopes = normalize(client.Scopes)
+	client.RedirectURIs = append([]string{}, slices.Compact(client.RedirectURIs)...)
+
+	if err := validateClient(client); err != nil {
+		return model.OAuthClient{}, err
+	}
+
+	// a first-party client skips consent, so only a session may register one
+	if client.FirstParty && principal.Restricted() {
+		return model.OAuthClient{}, utils.ErrForbidden
+	}
+
+	// client credentials tokens carry the scopes without any user roles behind them
+	if !principal.Allows(client.Scopes...) {
+		return model.OAuthClient{}, utils.ErrForbidden
+	}
+
+	if principal.UserID != "" {
+		if err := s.accessService.CheckPermission(ctx, principal.UserID, client.Scopes...); err != nil {
+			return model.OAuthClient{}, err
+		}
+	}
+
+	stored := queries.OauthClient{
+		ID:           ulid.Make().String(),
+		Name:         client.Name,
+		RedirectUris: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Scopes:       client.Scopes,
+		FirstParty:   client.FirstParty,
+		CreatedAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+
+	var secret string
+	if client.Confidential {
+		secret = rand.Text() + rand.Text()
+		stored.SecretHash = pgtype.Text{String: utils.HashToken(secret), Valid: true}
+	}
+
+	if err := s.repository.CreateClient(ctx, stored); err != nil {
+		return model.OAuthClient{}, err
+	}
+
+	created := toOAuthClient(stored)
+	created.Secret = secret
+	return created, nil
+}
+
+func (s *Service) Clients(ctx context.Context) ([]model.OAuthClient, error) {
+	stored, err := s.repository.GetClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make([]model.OAuthClient, 0, len(stored))
+	for _, client := range stored {
+		clients = append(clients, toOAuthClient(client))
+	}
+
+	return clients, nil
+}
+
+// DeleteClient - удалить клиента, выданные ему refresh токены перестают работать
+func (s *Service) DeleteClient(ctx context.Context, id string) error {
+	return s.repository.DeleteClient(ctx, id)
+}
+
+// Consents - клиенты, которым пользователь разрешил доступ к аккаунту
+func (s *Service) Consents(ctx context.Context, userID string) ([]model.OAuthConsent, error) {
+	stored, err := s.repository.GetUserConsents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	consents := make([]model.OAuthConsent, 0, len(stored))
+	for _, consent := range stored {
+		consents = append(consents, model.OAuthConsent{
+			ClientID:   consent.ClientID,
+			ClientName: consent.ClientName,
+			Scopes:     consent.Scopes,
+			CreatedAt:  consent.CreatedAt.Time,
+			UpdatedAt:  consent.UpdatedAt.Time,
+		})
+	}
+
+	return consents, nil
+}
+
+// RevokeConsent - отозвать доступ клиента: согласие и refresh токены удаляются,
+// выданные access токены доживают свой короткий срок
+func (s *Service) RevokeConsent(ctx context.Context, userID, clientID string) error {
+	return s.repository.RevokeConsent(ctx, userID, clientID)
+}
+
+// PurgeExpiredCodes - удалить коды авторизации, которые клиенты так и не обменяли
+func (s *Service) PurgeExpiredCodes(ctx context.Context) error {
+	_, err := s.repository.DeleteExpiredCodes(ctx)
+	return err
+}
+
+// authenticateClient - найти клиента token endpoint и проверить его секрет.
+// Публичный клиент передаёт только client_id, его подлинность подтверждает PKCE.
+func (s *Service) authenticateClient(ctx context.Context, clientID, secret string) (queries.OauthClient, error) {
+	if clientID == "" {
+		return queries.OauthClient{}, oauthError(utils.OAuthInvalidClient, "client authentication required")
+	}
+
+	client, err := s.repository.GetClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return queries.OauthClient{}, oauthError(utils.OAuthInvalidClient, "unknown client")
+		}
+		return queries.OauthClient{}, err
+	}
+
+	if !client.SecretHash.Valid {
+		if secret != "" {
+			return queries.OauthClient{}, oauthError(utils.OAuthInvalidClient, "public client has no secret")
+		}
+		return client, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(utils.HashToken(secret)), []byte(client.SecretHash.String)) != 1 {
+		return queries.OauthClient{}, oauthError(utils.OAuthInvalidClient, "invalid client secret")
+	}
+
+	return client, nil
+}
+
+func validateClient(client model.OAuthClient) error {
+	if len(client.GrantTypes) == 0 {
+		return fmt.Errorf("%w: at least one grant type is required", utils.ErrInvalidClientConfig)
+	}
+	for _, grantType := range client.GrantTypes {
+		if !slices.Contains(registrableGrants, grantType) {
+			return fmt.Errorf("%w: unsupported grant type %q", utils.ErrInvalidClientConfig, grantType)
+		}
+	}
+
+	for _, scope := range client.Scopes {
+		if !slices.Contains(model.Scopes, scope) {
+			return utils.ErrUnknownScope
+		}
+	}
+
+	// a public client cannot keep a secret, so it cannot act on its own behalf
+	if slices.Contains(client.GrantTypes, model.GrantClientCredentials) && !client.Confidential {
+		return fmt.Errorf("%w: client_credentials requires a confidential client", utils.ErrInvalidClientConfig)
+	}
+
+	if slices.Contains(client.GrantTypes, model.GrantAuthorizationCode) && len(client.RedirectURIs) == 0 {
+		return fmt.Errorf("%w: authorization_code requires a redirect URI", utils.ErrInvalidClientConfig)
+	}
+
+	for _, redirectURI := range client.RedirectURIs {
+		if err := validateRedirectURI(redirectURI); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateRedirectURI - абсолютный адрес без фрагмента (RFC 6749, раздел 3.1.2).
+// Для мобильных приложений допускаются собственные схемы.
+func validateRedirectURI(redirectURI string) error {
+	parsed, err := url.Parse(redirectURI)
+	if err != nil || parsed.Scheme == "" || parsed.Fragment != "" || strings.Contains(redirectURI, "#") {
+		return fmt.Errorf("%w: invalid redirect URI %q", utils.ErrInvalidClientConfig, redirectURI)
+	}
+
+	if (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host == "" {
+		return fmt.Errorf("%w: invalid redirect URI %q", utils.ErrInvalidClientConfig, redirectURI)
+	}
+
+	return nil
+}
+
+func toOAuthClient(stored queries.OauthClient) model.OAuthClient {
+	return model.OAuthClient{
+		ID:           stored.ID,
+		Name:         stored.Name,
+		RedirectURIs: stored.RedirectUris,
+		GrantTypes:   stored.GrantTypes,
+		Scopes:       stored.Scopes,
+		Confidential: stored.SecretHash.Valid,
+		FirstParty:   stored.FirstParty,
+		CreatedAt:    stored.CreatedAt.Time,
+	}
+}
+
+// normalize - отсортировать и убрать повторы, результат не nil
+func normalize(values []string) []string {
+	return append([]string{}, slices.Compact(slices.Sorted(slices.Values(values)))...)
+}
+
+func oauthError(code, description string) error {
+	return &utils.OAuthError{Code: code, Description: description}
+}
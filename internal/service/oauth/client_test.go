@@ -0,0 +1,176 @@
+package oauth
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func TestCreateClient(t *testing.T) {
+	ctx := context.Background()
+	admin := model.Principal{UserID: "admin-1"}
+
+	tests := []struct {
+		name          string
+		principal     *model.Principal
+		client        model.OAuthClient
+		permissionErr error
+		expectedError error
+	}{
+		{
+			name: "confidential partner",
+			client: model.OAuthClient{
+				Name:         " Partner CRM ",
+				RedirectURIs: []string{testRedirectURI},
+				GrantTypes:   []string{model.GrantClientCredentials, model.GrantAuthorizationCode},
+				Scopes:       []string{model.PermissionUsersWrite, model.PermissionUsersRead, model.PermissionUsersRead},
+				Confidential: true,
+			},
+		},
+		{
+			name: "public mobile app with custom scheme",
+			client: model.OAuthClient{
+				Name:         "Mobile",
+				RedirectURIs: []string{"com.example.app:/oauth/callback"},
+				GrantTypes:   []string{model.GrantAuthorizationCode},
+				Scopes:       []string{model.PermissionUsersRead},
+				FirstParty:   true,
+			},
+		},
+		{
+			name:          "first-party client from an api key",
+			principal:     &model.Principal{UserID: "admin-1", APIKeyID: "key-1", Scopes: []string{model.PermissionClientsManage, model.PermissionUsersRead}},
+			client:        model.OAuthClient{Name: "Web", GrantTypes: []string{model.GrantAuthorizationCode}, RedirectURIs: []string{testRedirectURI}, Scopes: []string{model.PermissionUsersRead}, FirstParty: true},
+			expectedError: utils.ErrForbidden,
+		},
+		{
+			name:          "first-party client from an oauth token",
+			principal:     &model.Principal{ClientID: "client-1", Scopes: []string{model.PermissionClientsManage, model.PermissionUsersRead}},
+			client:        model.OAuthClient{Name: "Web", GrantTypes: []string{model.GrantAuthorizationCode}, RedirectURIs: []string{testRedirectURI}, Scopes: []string{model.PermissionUsersRead}, FirstParty: true},
+			expectedError: utils.ErrForbidden,
+		},
+		{
+			name:          "missing name",
+			client:        model.OAuthClient{GrantTypes: []string{model.GrantAuthorizationCode}, RedirectURIs: []string{testRedirectURI}},
+			expectedError: utils.ErrNameRequired,
+		},
+		{
+			name:          "no grant types",
+			client:        model.OAuthClient{Name: "Partner"},
+			expectedError: utils.ErrInvalidClientConfig,
+		},
+		{
+			name:          "refresh token is not registered separately",
+			client:        model.OAuthClient{Name: "Partner", GrantTypes: []string{model.GrantRefreshToken}},
+			expectedError: utils.ErrInvalidClientConfig,
+		},
+		{
+			name:          "public client cannot use client credentials",
+			client:        model.OAuthClient{Name: "Partner", GrantTypes: []string{model.GrantClientCredentials}, Scopes: []string{model.PermissionUsersRead}},
+			expectedError: utils.ErrInvalidClientConfig,
+		},
+		{
+			name:          "authorization code without redirect uri",
+			client:        model.OAuthClient{Name: "Partner", GrantTypes: []string{model.GrantAuthorizationCode}},
+			expectedError: utils.ErrInvalidClientConfig,
+		},
+		{
+			name:          "redirect uri with fragment",
+			client:        model.OAuthClient{Name: "Partner", GrantTypes: []string{model.GrantAuthorizationCode}, RedirectURIs: []string{testRedirectURI + "#token"}},
+			expectedError: utils.ErrInvalidClientConfig,
+		},
+		{
+			name:          "relative redirect uri",
+			client:        model.OAuthClient{Name: "Partner", GrantTypes: []string{model.GrantAuthorizationCode}, RedirectURIs: []string{"/callback"}},
+			expectedError: utils.ErrInvalidClientConfig,
+		},
+		{
+			name:          "unknown scope",
+			client:        model.OAuthClient{Name: "Partner", GrantTypes: []string{model.GrantAuthorizationCode}, RedirectURIs: []string{testRedirectURI}, Scopes: []string{"admin:*"}},
+			expectedError: utils.ErrUnknownScope,
+		},
+		{
+			name:          "scope not held by the caller roles",
+			client:        model.OAuthClient{Name: "Partner", GrantTypes: []string{model.GrantClientCredentials}, Scopes: []string{model.PermissionRolesManage}, Confidential: true},
+			permissionErr: utils.ErrForbidden,
+			expectedError: utils.ErrForbidden,
+		},
+		{
+			name:          "scope outside the caller api key",
+			principal:     &model.Principal{UserID: "admin-1", APIKeyID: "key-1", Scopes: []string{model.PermissionClientsManage}},
+			client:        model.OAuthClient{Name: "Partner", GrantTypes: []string{model.GrantClientCredentials}, Scopes: []string{model.PermissionUsersRead}, Confidential: true},
+			expectedError: utils.ErrForbidden,
+		},
+		{
+			name:      "client acting on its own behalf within its scopes",
+			principal: &model.Principal{ClientID: "client-1", Scopes: []string{model.PermissionClientsManage, model.PermissionUsersRead}},
+			client:    model.OAuthClient{Name: "Partner", GrantTypes: []string{model.GrantClientCredentials}, Scopes: []string{model.PermissionUsersRead}, Confidential: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+
+			principal := admin
+			if tt.principal != nil {
+				principal = *tt.principal
+			}
+
+			// roles are checked only for a valid client the caller scopes allow
+			if principal.UserID != "" && (tt.expectedError == nil || tt.permissionErr != nil) {
+				args := []any{ctx, principal.UserID}
+				for _, scope := range slices.Compact(slices.Sorted(slices.Values(tt.client.Scopes))) {
+					args = append(args, scope)
+				}
+				env.accessService.On("CheckPermission", args...).Return(tt.permissionErr).Once()
+			}
+
+			var stored queries.OauthClient
+			if tt.expectedError == nil {
+				env.repository.On("CreateClient", ctx, mock.Anything).Run(func(args mock.Arguments) {
+					stored = args.Get(1).(queries.OauthClient)
+				}).Return(nil).Once()
+			}
+
+			client, err := env.service.CreateClient(ctx, principal, tt.client)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, client.ID)
+			assert.NotNil(t, stored.Scopes)
+			assert.Equal(t, stored.Scopes, client.Scopes)
+			assert.True(t, slices.IsSorted(client.GrantTypes))
+			assert.Equal(t, tt.client.Confidential, client.Confidential)
+
+			if tt.client.Confidential {
+				// only the hash is stored, the secret is returned once
+				assert.NotEmpty(t, client.Secret)
+				assert.Equal(t, utils.HashToken(client.Secret), stored.SecretHash.String)
+			} else {
+				assert.Empty(t, client.Secret)
+				assert.False(t, stored.SecretHash.Valid)
+			}
+		})
+	}
+}
+
+func TestAuthenticatePublicClientWithSecret(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	env.repository.On("GetClient", ctx, spaClient.ID).Return(spaClient, nil).Once()
+
+	_, err := env.service.Token(ctx, model.OAuthTokenRequest{GrantType: model.GrantRefreshToken, ClientID: spaClient.ID, ClientSecret: "guess"})
+	assertOAuthError(t, err, utils.OAuthInvalidClient)
+}
@@ -0,0 +1,41 @@
+package oauth
+
+import (
+	"context"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+// Exporter - раздел "oauth_consents" выгрузки персональных данных: клиенты OAuth, которым пользователь выдал доступ
+type Exporter struct {
+	service *Service
+}
+
+// NewExporter - выгрузить согласия, выданные клиентам OAuth
+func NewExporter(service *Service) *Exporter {
+	return &Exporter{service: service}
+}
+
+func (e *Exporter) Name() string {
+	return "oauth_consents"
+}
+
+func (e *Exporter) Export(ctx context.Context, userID string) (any, error) {
+	consents, err := e.service.Consents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ExportOAuthConsent, 0, len(consents))
+	for _, consent := range consents {
+		result = append(result, model.ExportOAuthConsent{
+			ClientID:   consent.ClientID,
+			ClientName: consent.ClientName,
+			Scopes:     consent.Scopes,
+			CreatedAt:  consent.CreatedAt,
+			UpdatedAt:  consent.UpdatedAt,
+		})
+	}
+
+	return result, nil
+}
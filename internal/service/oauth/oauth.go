@@ -0,0 +1,273 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const (
+	// responseTypeCode - единственный поддерживаемый response_type, implicit flow не поддерживается
+	responseTypeCode = "code"
+	// challengeMethodS256 - единственный поддерживаемый метод PKCE, plain не защищает от перехвата кода
+	challengeMethodS256 = "S256"
+	// minVerifierLength, maxVerifierLength - границы длины code_verifier и code_challenge из RFC 7636
+	minVerifierLength = 43
+	maxVerifierLength = 128
+)
+
+// Authorize - обработать запрос авторизации от имени вошедшего пользователя.
+// Стороннему клиенту без сохранённого согласия на все запрошенные scope код не выдаётся,
+// пока пользователь не подтвердит их повторным запросом с Consent.
+func (s *Service) Authorize(ctx context.Context, userID string, request model.OAuthAuthorizeRequest) (model.OAuthAuthorization, error) {
+	client, err := s.repository.GetClient(ctx, request.ClientID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.OAuthAuthorization{}, oauthError(utils.OAuthInvalidRequest, "unknown client_id")
+		}
+		return model.OAuthAuthorization{}, err
+	}
+
+	// an unregistered redirect URI must never receive a code
+	if !slices.Contains(client.RedirectUris, request.RedirectURI) {
+		return model.OAuthAuthorization{}, oauthError(utils.OAuthInvalidRequest, "redirect_uri is not registered for the client")
+	}
+
+	if request.ResponseType != responseTypeCode {
+		return model.OAuthAuthorization{}, oauthError(utils.OAuthUnsupportedResponseType, "only response_type=code is supported")
+	}
+
+	if !slices.Contains(client.GrantTypes, model.GrantAuthorizationCode) {
+		return model.OAuthAuthorization{}, oauthError(utils.OAuthUnauthorizedClient, "authorization_code grant is not allowed for the client")
+	}
+
+	// PKCE is required from confidential clients too, as OAuth 2.1 recommends
+	if request.CodeChallengeMethod != challengeMethodS256 || !validVerifier(request.CodeChallenge) {
+		return model.OAuthAuthorization{}, oauthError(utils.OAuthInvalidRequest, "code_challenge with code_challenge_method=S256 is required")
+	}
+
+	scopes, err := resolveScopes(client, request.Scope)
+	if err != nil {
+		return model.OAuthAuthorization{}, err
+	}
+
+	authorization := model.OAuthAuthorization{
+		ClientName: client.Name,
+		Scopes:     scopes,
+	}
+
+	if !client.FirstParty {
+		granted, err := s.grantedScopes(ctx, userID, client.ID)
+		if err != nil {
+			return model.OAuthAuthorization{}, err
+		}
+
+		if !containsAll(granted, scopes) {
+			if !request.Consent {
+				authorization.ConsentRequired = true
+				return authorization, nil
+			}
+
+			if err = s.repository.SaveConsent(ctx, queries.OauthConsent{
+				UserID:   userID,
+				ClientID: client.ID,
+				Scopes:   normalize(append(granted, scopes...)),
+			}); err != nil {
+				return model.OAuthAuthorization{}, err
+			}
+		}
+	}
+
+	code := rand.Text()
+	if err = s.repository.CreateCode(ctx, queries.OauthAuthorizationCode{
+		ID:            utils.HashToken(code),
+		ClientID:      client.ID,
+		UserID:        userID,
+		RedirectUri:   request.RedirectURI,
+		Scopes:        scopes,
+		CodeChallenge: request.CodeChallenge,
+		ExpiresAt:     pgtype.Timestamptz{Time: time.Now().Add(s.codeExpires), Valid: true},
+	}); err != nil {
+		return model.OAuthAuthorization{}, err
+	}
+
+	params := url.Values{"code": {code}}
+	if request.State != "" {
+		params.Set("state", request.State)
+	}
+	authorization.RedirectTo = withQuery(request.RedirectURI, params)
+
+	return authorization, nil
+}
+
+// Token - token endpoint: обменять код, выдать токен клиенту от его имени или обновить пару.
+// Выдаются те же JWT, что и при обычном входе, с claims client_id и scope.
+func (s *Service) Token(ctx context.Context, request model.OAuthTokenRequest) (model.OAuthToken, error) {
+	if !slices.Contains([]string{model.GrantAuthorizationCode, model.GrantClientCredentials, model.GrantRefreshToken}, request.GrantType) {
+		return model.OAuthToken{}, oauthError(utils.OAuthUnsupportedGrantType, "unsupported grant_type")
+	}
+
+	client, err := s.authenticateClient(ctx, request.ClientID, request.ClientSecret)
+	if err != nil {
+		return model.OAuthToken{}, err
+	}
+
+	if !grantAllowed(client, request.GrantType) {
+		return model.OAuthToken{}, oauthError(utils.OAuthUnauthorizedClient, request.GrantType+" grant is not allowed for the client")
+	}
+
+	switch request.GrantType {
+	case model.GrantAuthorizationCode:
+		return s.exchangeCode(ctx, client, request)
+	case model.GrantClientCredentials:
+		return s.clientCredentials(client, request)
+	default:
+		return s.refresh(ctx, client, request)
+	}
+}
+
+func (s *Service) exchangeCode(ctx context.Context, client queries.OauthClient, request model.OAuthTokenRequest) (model.OAuthToken, error) {
+	if request.Code == "" || request.CodeVerifier == "" {
+		return model.OAuthToken{}, oauthError(utils.OAuthInvalidRequest, "code and code_verifier are required")
+	}
+
+	// the code is consumed before the checks, a leaked code cannot be retried
+	code, err := s.repository.ConsumeCode(ctx, utils.HashToken(request.Code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.OAuthToken{}, oauthError(utils.OAuthInvalidGrant, "invalid or expired code")
+		}
+		return model.OAuthToken{}, err
+	}
+
+	if code.ClientID != client.ID || code.RedirectUri != request.RedirectURI {
+		return model.OAuthToken{}, oauthError(utils.OAuthInvalidGrant, "code was issued to another client or redirect_uri")
+	}
+
+	if !verifyPKCE(request.CodeVerifier, code.CodeChallenge) {
+		return model.OAuthToken{}, oauthError(utils.OAuthInvalidGrant, "code_verifier does not match code_challenge")
+	}
+
+	tokens, err := s.authService.IssueClientTokens(ctx, code.UserID, client.ID, code.Scopes)
+	if err != nil {
+		return model.OAuthToken{}, err
+	}
+
+	return model.OAuthToken{TokenPair: tokens, Scopes: code.Scopes}, nil
+}
+
+func (s *Service) clientCredentials(client queries.OauthClient, request model.OAuthTokenRequest) (model.OAuthToken, error) {
+	scopes, err := resolveScopes(client, request.Scope)
+	if err != nil {
+		return model.OAuthToken{}, err
+	}
+
+	tokens, err := s.authService.GenerateClientToken(client.ID, scopes)
+	if err != nil {
+		return model.OAuthToken{}, err
+	}
+
+	return model.OAuthToken{TokenPair: tokens, Scopes: scopes}, nil
+}
+
+// refresh - обновить пару, scope сохраняются из исходного кода авторизации
+func (s *Service) refresh(ctx context.Context, client queries.OauthClient, request model.OAuthTokenRequest) (model.OAuthToken, error) {
+	tokens, err := s.authService.RefreshClient(ctx, request.RefreshToken, client.ID)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidToken) || errors.Is(err, utils.ErrRefreshTokenReused) {
+			return model.OAuthToken{}, oauthError(utils.OAuthInvalidGrant, "invalid refresh_token")
+		}
+		return model.OAuthToken{}, err
+	}
+
+	return model.OAuthToken{TokenPair: tokens}, nil
+}
+
+// grantedScopes - scope, на которые пользователь уже дал согласие клиенту
+func (s *Service) grantedScopes(ctx context.Context, userID, clientID string) ([]string, error) {
+	consent, err := s.repository.GetConsent(ctx, userID, clientID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return consent.Scopes, nil
+}
+
+// resolveScopes - проверить запрошенные scope по зарегистрированным у клиента, без scope клиент получает все свои scope
+func resolveScopes(client queries.OauthClient, scope string) ([]string, error) {
+	requested := strings.Fields(scope)
+	if len(requested) == 0 {
+		requested = client.Scopes
+	}
+
+	if len(requested) == 0 {
+		return nil, oauthError(utils.OAuthInvalidScope, "client has no scopes registered")
+	}
+
+	for _, requestedScope := range requested {
+		if !slices.Contains(client.Scopes, requestedScope) {
+			return nil, oauthError(utils.OAuthInvalidScope, "scope "+requestedScope+" is not registered for the client")
+		}
+	}
+
+	return normalize(requested), nil
+}
+
+// grantAllowed - refresh_token доступен всем клиентам, которым разрешён authorization_code
+func grantAllowed(client queries.OauthClient, grantType string) bool {
+	if grantType == model.GrantRefreshToken {
+		grantType = model.GrantAuthorizationCode
+	}
+
+	return slices.Contains(client.GrantTypes, grantType)
+}
+
+// verifyPKCE - BASE64URL(SHA256(code_verifier)) должен совпасть с code_challenge (RFC 7636, раздел 4.6)
+func verifyPKCE(verifier, challenge string) bool {
+	if !validVerifier(verifier) {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+func validVerifier(value string) bool {
+	return len(value) >= minVerifierLength && len(value) <= maxVerifierLength
+}
+
+func containsAll(granted, requested []string) bool {
+	for _, scope := range requested {
+		if !slices.Contains(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// withQuery - добавить параметры к redirect URI, сохранив его собственный query
+func withQuery(redirectURI string, params url.Values) string {
+	separator := "?"
+	if strings.Contains(redirectURI, "?") {
+		separator = "&"
+	}
+	return redirectURI + separator + params.Encode()
+}
@@ -0,0 +1,440 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	serviceMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/service/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const (
+	testUserID      = "test-user-123"
+	testRedirectURI = "https://partner.example.com/callback"
+	testSecret      = "client-secret"
+	testVerifier    = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+var (
+	partnerClient = queries.OauthClient{
+		ID:           "partner",
+		Name:         "Partner CRM",
+		SecretHash:   pgtype.Text{String: utils.HashToken(testSecret), Valid: true},
+		RedirectUris: []string{testRedirectURI},
+		GrantTypes:   []string{model.GrantAuthorizationCode, model.GrantClientCredentials},
+		Scopes:       []string{model.PermissionUsersRead, model.PermissionUsersWrite},
+	}
+	spaClient = queries.OauthClient{
+		ID:           "spa",
+		Name:         "Web",
+		RedirectUris: []string{"http://localhost:3000/callback"},
+		GrantTypes:   []string{model.GrantAuthorizationCode},
+		Scopes:       []string{model.PermissionUsersRead},
+		FirstParty:   true,
+	}
+)
+
+type testEnv struct {
+	service       *Service
+	repository    *repositoryMocks.MockOAuthRepository
+	authService   *serviceMocks.MockAuthService
+	accessService *serviceMocks.MockAccessService
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		repository:    repositoryMocks.NewMockOAuthRepository(t),
+		authService:   serviceMocks.NewMockAuthService(t),
+		accessService: serviceMocks.NewMockAccessService(t),
+	}
+	env.service = NewService(&infra.Config{OAuthCodeTTL: time.Minute}, env.repository, nil, nil)
+	env.service.authService = env.authService
+	env.service.accessService = env.accessService
+
+	return env
+}
+
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func authorizeRequest(client queries.OauthClient) model.OAuthAuthorizeRequest {
+	return model.OAuthAuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            client.ID,
+		RedirectURI:         client.RedirectUris[0],
+		State:               "af0ifjsldkj",
+		CodeChallenge:       challenge(testVerifier),
+		CodeChallengeMethod: "S256",
+	}
+}
+
+func assertOAuthError(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var oauthErr *utils.OAuthError
+	require.ErrorAs(t, err, &oauthErr)
+	assert.Equal(t, code, oauthErr.Code)
+}
+
+func TestAuthorizeRejectsInvalidRequests(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		modify   func(*model.OAuthAuthorizeRequest)
+		expected string
+	}{
+		{name: "unregistered redirect uri", modify: func(r *model.OAuthAuthorizeRequest) { r.RedirectURI = "https://evil.example.com/callback" }, expected: utils.OAuthInvalidRequest},
+		{name: "implicit flow", modify: func(r *model.OAuthAuthorizeRequest) { r.ResponseType = "token" }, expected: utils.OAuthUnsupportedResponseType},
+		{name: "missing pkce", modify: func(r *model.OAuthAuthorizeRequest) { r.CodeChallenge = "" }, expected: utils.OAuthInvalidRequest},
+		{name: "plain pkce", modify: func(r *model.OAuthAuthorizeRequest) { r.CodeChallengeMethod = "plain" }, expected: utils.OAuthInvalidRequest},
+		{name: "scope not registered for client", modify: func(r *model.OAuthAuthorizeRequest) { r.Scope = model.PermissionRolesManage }, expected: utils.OAuthInvalidScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.repository.On("GetClient", ctx, partnerClient.ID).Return(partnerClient, nil).Once()
+
+			request := authorizeRequest(partnerClient)
+			tt.modify(&request)
+
+			_, err := env.service.Authorize(ctx, testUserID, request)
+			assertOAuthError(t, err, tt.expected)
+		})
+	}
+
+	t.Run("unknown client", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.repository.On("GetClient", ctx, "unknown").Return(queries.OauthClient{}, pgx.ErrNoRows).Once()
+
+		request := authorizeRequest(partnerClient)
+		request.ClientID = "unknown"
+
+		_, err := env.service.Authorize(ctx, testUserID, request)
+		assertOAuthError(t, err, utils.OAuthInvalidRequest)
+	})
+}
+
+func TestAuthorizeConsent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("third-party client asks for consent", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.repository.On("GetClient", ctx, partnerClient.ID).Return(partnerClient, nil).Once()
+		env.repository.On("GetConsent", ctx, testUserID, partnerClient.ID).Return(queries.OauthConsent{}, pgx.ErrNoRows).Once()
+
+		request := authorizeRequest(partnerClient)
+		request.Scope = model.PermissionUsersRead
+
+		authorization, err := env.service.Authorize(ctx, testUserID, request)
+		require.NoError(t, err)
+		assert.True(t, authorization.ConsentRequired)
+		assert.Empty(t, authorization.RedirectTo)
+		assert.Equal(t, partnerClient.Name, authorization.ClientName)
+		assert.Equal(t, []string{model.PermissionUsersRead}, authorization.Scopes)
+	})
+
+	t.Run("approved consent is merged with the previous one", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.repository.On("GetClient", ctx, partnerClient.ID).Return(partnerClient, nil).Once()
+		env.repository.On("GetConsent", ctx, testUserID, partnerClient.ID).
+			Return(queries.OauthConsent{Scopes: []string{model.PermissionUsersRead}}, nil).Once()
+		env.repository.On("SaveConsent", ctx, queries.OauthConsent{
+			UserID:   testUserID,
+			ClientID: partnerClient.ID,
+			Scopes:   []string{model.PermissionUsersRead, model.PermissionUsersWrite},
+		}).Return(nil).Once()
+		env.repository.On("CreateCode", ctx, mock.Anything).Return(nil).Once()
+
+		request := authorizeRequest(partnerClient)
+		request.Scope = model.PermissionUsersWrite
+		request.Consent = true
+
+		authorization, err := env.service.Authorize(ctx, testUserID, request)
+		require.NoError(t, err)
+		assert.False(t, authorization.ConsentRequired)
+		assert.NotEmpty(t, authorization.RedirectTo)
+	})
+
+	t.Run("existing consent is not asked again", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.repository.On("GetClient", ctx, partnerClient.ID).Return(partnerClient, nil).Once()
+		env.repository.On("GetConsent", ctx, testUserID, partnerClient.ID).
+			Return(queries.OauthConsent{Scopes: partnerClient.Scopes}, nil).Once()
+		env.repository.On("CreateCode", ctx, mock.Anything).Return(nil).Once()
+
+		authorization, err := env.service.Authorize(ctx, testUserID, authorizeRequest(partnerClient))
+		require.NoError(t, err)
+		assert.False(t, authorization.ConsentRequired)
+		// empty scope means every scope of the client
+		assert.Equal(t, partnerClient.Scopes, authorization.Scopes)
+	})
+
+	t.Run("first-party client skips consent and gets its scopes", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.repository.On("GetClient", ctx, spaClient.ID).Return(spaClient, nil).Once()
+		env.repository.On("CreateCode", ctx, mock.MatchedBy(func(code queries.OauthAuthorizationCode) bool {
+			return slices.Equal(code.Scopes, spaClient.Scopes)
+		})).Return(nil).Once()
+
+		authorization, err := env.service.Authorize(ctx, testUserID, authorizeRequest(spaClient))
+		require.NoError(t, err)
+		assert.Equal(t, spaClient.Scopes, authorization.Scopes)
+		assert.NotEmpty(t, authorization.RedirectTo)
+	})
+}
+
+// authorize - пройти запрос авторизации и вернуть код из редиректа вместе с сохранённой записью
+func (e *testEnv) authorize(t *testing.T, client queries.OauthClient) (string, queries.OauthAuthorizationCode) {
+	t.Helper()
+	ctx := context.Background()
+
+	var stored queries.OauthAuthorizationCode
+	e.repository.On("GetClient", ctx, client.ID).Return(client, nil).Once()
+	e.repository.On("GetConsent", ctx, testUserID, client.ID).Return(queries.OauthConsent{Scopes: client.Scopes}, nil).Maybe()
+	e.repository.On("CreateCode", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(queries.OauthAuthorizationCode)
+	}).Return(nil).Once()
+
+	authorization, err := e.service.Authorize(ctx, testUserID, authorizeRequest(client))
+	require.NoError(t, err)
+
+	redirect, err := url.Parse(authorization.RedirectTo)
+	require.NoError(t, err)
+	assert.Equal(t, client.RedirectUris[0], redirect.Scheme+"://"+redirect.Host+redirect.Path)
+	assert.Equal(t, "af0ifjsldkj", redirect.Query().Get("state"))
+
+	code := redirect.Query().Get("code")
+	require.NotEmpty(t, code)
+	assert.Equal(t, utils.HashToken(code), stored.ID)
+	assert.Equal(t, testUserID, stored.UserID)
+
+	return code, stored
+}
+
+func TestAuthorizationCodeGrant(t *testing.T) {
+	ctx := context.Background()
+	tokens := model.TokenPair{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: time.Hour}
+
+	t.Run("code is exchanged with the verifier", func(t *testing.T) {
+		env := newTestEnv(t)
+		code, stored := env.authorize(t, partnerClient)
+
+		env.repository.On("GetClient", ctx, partnerClient.ID).Return(partnerClient, nil).Once()
+		env.repository.On("ConsumeCode", ctx, stored.ID).Return(stored, nil).Once()
+		env.authService.On("IssueClientTokens", ctx, testUserID, partnerClient.ID, partnerClient.Scopes).Return(tokens, nil).Once()
+
+		token, err := env.service.Token(ctx, model.OAuthTokenRequest{
+			GrantType:    model.GrantAuthorizationCode,
+			ClientID:     partnerClient.ID,
+			ClientSecret: testSecret,
+			Code:         code,
+			RedirectURI:  testRedirectURI,
+			CodeVerifier: testVerifier,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, tokens, token.TokenPair)
+		assert.Equal(t, partnerClient.Scopes, token.Scopes)
+	})
+
+	t.Run("public client authenticates with pkce only", func(t *testing.T) {
+		env := newTestEnv(t)
+		code, stored := env.authorize(t, spaClient)
+
+		env.repository.On("GetClient", ctx, spaClient.ID).Return(spaClient, nil).Once()
+		env.repository.On("ConsumeCode", ctx, stored.ID).Return(stored, nil).Once()
+		env.authService.On("IssueClientTokens", ctx, testUserID, spaClient.ID, spaClient.Scopes).Return(tokens, nil).Once()
+
+		token, err := env.service.Token(ctx, model.OAuthTokenRequest{
+			GrantType:    model.GrantAuthorizationCode,
+			ClientID:     spaClient.ID,
+			Code:         code,
+			RedirectURI:  spaClient.RedirectUris[0],
+			CodeVerifier: testVerifier,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, spaClient.Scopes, token.Scopes)
+	})
+
+	rejected := []struct {
+		name     string
+		modify   func(*model.OAuthTokenRequest)
+		expected string
+	}{
+		{name: "wrong verifier", modify: func(r *model.OAuthTokenRequest) { r.CodeVerifier = testVerifier + "x" }, expected: utils.OAuthInvalidGrant},
+		{name: "wrong redirect uri", modify: func(r *model.OAuthTokenRequest) { r.RedirectURI = "https://partner.example.com/other" }, expected: utils.OAuthInvalidGrant},
+	}
+
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			code, stored := env.authorize(t, partnerClient)
+
+			env.repository.On("GetClient", ctx, partnerClient.ID).Return(partnerClient, nil).Once()
+			env.repository.On("ConsumeCode", ctx, stored.ID).Return(stored, nil).Once()
+
+			request := model.OAuthTokenRequest{
+				GrantType:    model.GrantAuthorizationCode,
+				ClientID:     partnerClient.ID,
+				ClientSecret: testSecret,
+				Code:         code,
+				RedirectURI:  testRedirectURI,
+				CodeVerifier: testVerifier,
+			}
+			tt.modify(&request)
+
+			_, err := env.service.Token(ctx, request)
+			assertOAuthError(t, err, tt.expected)
+		})
+	}
+
+	t.Run("code issued to another client", func(t *testing.T) {
+		env := newTestEnv(t)
+		code, stored := env.authorize(t, spaClient)
+
+		env.repository.On("GetClient", ctx, partnerClient.ID).Return(partnerClient, nil).Once()
+		env.repository.On("ConsumeCode", ctx, stored.ID).Return(stored, nil).Once()
+
+		_, err := env.service.Token(ctx, model.OAuthTokenRequest{
+			GrantType:    model.GrantAuthorizationCode,
+			ClientID:     partnerClient.ID,
+			ClientSecret: testSecret,
+			Code:         code,
+			RedirectURI:  spaClient.RedirectUris[0],
+			CodeVerifier: testVerifier,
+		})
+		assertOAuthError(t, err, utils.OAuthInvalidGrant)
+	})
+
+	t.Run("used or expired code", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.repository.On("GetClient", ctx, partnerClient.ID).Return(partnerClient, nil).Once()
+		env.repository.On("ConsumeCode", ctx, utils.HashToken("used")).Return(queries.OauthAuthorizationCode{}, pgx.ErrNoRows).Once()
+
+		_, err := env.service.Token(ctx, model.OAuthTokenRequest{
+			GrantType:    model.GrantAuthorizationCode,
+			ClientID:     partnerClient.ID,
+			ClientSecret: testSecret,
+			Code:         "used",
+			RedirectURI:  testRedirectURI,
+			CodeVerifier: testVerifier,
+		})
+		assertOAuthError(t, err, utils.OAuthInvalidGrant)
+	})
+}
+
+func TestClientCredentialsGrant(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		client         queries.OauthClient
+		request        model.OAuthTokenRequest
+		expectedScopes []string
+		expectedError  string
+	}{
+		{
+			name:           "all client scopes by default",
+			client:         partnerClient,
+			request:        model.OAuthTokenRequest{ClientID: partnerClient.ID, ClientSecret: testSecret},
+			expectedScopes: partnerClient.Scopes,
+		},
+		{
+			name:           "requested subset",
+			client:         partnerClient,
+			request:        model.OAuthTokenRequest{ClientID: partnerClient.ID, ClientSecret: testSecret, Scope: model.PermissionUsersRead},
+			expectedScopes: []string{model.PermissionUsersRead},
+		},
+		{
+			name:          "scope not registered for client",
+			client:        partnerClient,
+			request:       model.OAuthTokenRequest{ClientID: partnerClient.ID, ClientSecret: testSecret, Scope: model.PermissionRolesManage},
+			expectedError: utils.OAuthInvalidScope,
+		},
+		{
+			name:          "wrong secret",
+			client:        partnerClient,
+			request:       model.OAuthTokenRequest{ClientID: partnerClient.ID, ClientSecret: "wrong"},
+			expectedError: utils.OAuthInvalidClient,
+		},
+		{
+			name:          "grant not allowed",
+			client:        spaClient,
+			request:       model.OAuthTokenRequest{ClientID: spaClient.ID},
+			expectedError: utils.OAuthUnauthorizedClient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.repository.On("GetClient", ctx, tt.client.ID).Return(tt.client, nil).Once()
+			if tt.expectedError == "" {
+				env.authService.On("GenerateClientToken", tt.client.ID, tt.expectedScopes).
+					Return(model.TokenPair{AccessToken: "access", ExpiresIn: time.Hour}, nil).Once()
+			}
+
+			tt.request.GrantType = model.GrantClientCredentials
+			token, err := env.service.Token(ctx, tt.request)
+
+			if tt.expectedError != "" {
+				assertOAuthError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "access", token.AccessToken)
+			assert.Equal(t, tt.expectedScopes, token.Scopes)
+		})
+	}
+}
+
+func TestRefreshTokenGrant(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pair is rotated for the client", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.repository.On("GetClient", ctx, spaClient.ID).Return(spaClient, nil).Once()
+		env.authService.On("RefreshClient", ctx, "refresh", spaClient.ID).
+			Return(model.TokenPair{AccessToken: "access", RefreshToken: "next"}, nil).Once()
+
+		token, err := env.service.Token(ctx, model.OAuthTokenRequest{GrantType: model.GrantRefreshToken, ClientID: spaClient.ID, RefreshToken: "refresh"})
+		require.NoError(t, err)
+		assert.Equal(t, "next", token.RefreshToken)
+	})
+
+	t.Run("rejected refresh token", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.repository.On("GetClient", ctx, spaClient.ID).Return(spaClient, nil).Once()
+		env.authService.On("RefreshClient", ctx, "reused", spaClient.ID).Return(model.TokenPair{}, utils.ErrRefreshTokenReused).Once()
+
+		_, err := env.service.Token(ctx, model.OAuthTokenRequest{GrantType: model.GrantRefreshToken, ClientID: spaClient.ID, RefreshToken: "reused"})
+		assertOAuthError(t, err, utils.OAuthInvalidGrant)
+	})
+
+	t.Run("unsupported grant type", func(t *testing.T) {
+		env := newTestEnv(t)
+
+		_, err := env.service.Token(ctx, model.OAuthTokenRequest{GrantType: "password", ClientID: spaClient.ID})
+		assertOAuthError(t, err, utils.OAuthUnsupportedGrantType)
+	})
+}
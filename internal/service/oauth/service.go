@@ -0,0 +1,28 @@
+package oauth
+
+import (
+	"time"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/repository"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/access"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/auth"
+)
+
+type Service struct {
+	codeExpires   time.Duration
+	repository    repository.OAuthRepository
+	authService   service.AuthService
+	accessService service.AccessService
+}
+
+// NewService - создать новый экземпляр сервера авторизации OAuth 2.0, токены выдаются сервисом авторизации
+func NewService(cfg *infra.Config, oauthRepository repository.OAuthRepository, authService *auth.Service, accessService *access.Service) *Service {
+	return &Service{
+		codeExpires:   cfg.OAuthCodeTTL,
+		repository:    oauthRepository,
+		authService:   authService,
+		accessService: accessService,
+	}
+}
@@ -0,0 +1,40 @@
+package oidc
+
+import (
+	"context"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+// Exporter - раздел "identities" выгрузки персональных данных: привязанные аккаунты внешних провайдеров
+type Exporter struct {
+	service *Service
+}
+
+// NewExporter - выгрузить привязанные аккаунты OpenID Connect и каталога
+func NewExporter(service *Service) *Exporter {
+	return &Exporter{service: service}
+}
+
+func (e *Exporter) Name() string {
+	return "identities"
+}
+
+func (e *Exporter) Export(ctx context.Context, userID string) (any, error) {
+	identitys, err := e.service.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ExportIdentity, 0, len(identitys))
+	for _, identity := range identitys {
+		result = append(result, model.ExportIdentity{
+			Provider:    identity.Provider,
+			Email:       identity.Email,
+			CreatedAt:   identity.CreatedAt,
+			LastLoginAt: identity.LastLoginAt,
+		})
+	}
+
+	return result, nil
+}
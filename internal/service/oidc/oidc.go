@@ -0,0 +1,245 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// stateExpires - сколько пользователь может провести на странице провайдера
+const stateExpires = 10 * time.Minute
+
+// Providers - имена настроенных провайдеров в порядке из настроек
+func (s *Service) Providers() []string {
+	return s.names
+}
+
+// BeginLogin - начать вход через провайдера. Клиент переходит по ссылке и должен запомнить state,
+// чтобы после возврата убедиться, что вход начат в этом же браузере.
+func (s *Service) BeginLogin(ctx context.Context, providerName string) (model.OIDCAuthorization, error) {
+	return s.begin(ctx, providerName, "")
+}
+
+// FinishLogin - обменять code на ID токен и войти. Неизвестный аккаунт провайдера регистрирует
+// нового пользователя, если провайдер подтвердил почту и она ещё не занята.
+func (s *Service) FinishLogin(ctx context.Context, state, code string) (model.LoginResult, error) {
+	stored, claims, err := s.finish(ctx, state, code)
+	if err != nil {
+		return model.LoginResult{}, err
+	}
+
+	// linking flow must be finished by the logged in user who started it
+	if stored.UserID.Valid {
+		return model.LoginResult{}, utils.ErrInvalidToken
+	}
+
+	identity, err := s.repository.GetIdentity(ctx, stored.Provider, claims.Subject)
+	if err == nil {
+		if err = s.repository.Touch(ctx, identity.Provider, identity.Subject, claims.Email); err != nil {
+			return model.LoginResult{}, err
+		}
+		return s.authService.CompleteLogin(ctx, identity.UserID)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return model.LoginResult{}, err
+	}
+
+	userID, err := s.register(ctx, stored.Provider, claims)
+	if err != nil {
+		return model.LoginResult{}, err
+	}
+
+	return s.authService.CompleteLogin(ctx, userID)
+}
+
+// BeginLink - начать привязку аккаунта провайдера к вошедшему пользователю
+func (s *Service) BeginLink(ctx context.Context, userID, providerName string) (model.OIDCAuthorization, error) {
+	return s.begin(ctx, providerName, userID)
+}
+
+// FinishLink - привязать аккаунт провайдера, привязка начатая другим пользователем не принимается
+func (s *Service) FinishLink(ctx context.Context, userID, state, code string) (model.Identity, error) {
+	stored, claims, err := s.finish(ctx, state, code)
+	if err != nil {
+		return model.Identity{}, err
+	}
+
+	if stored.UserID.String != userID {
+		return model.Identity{}, utils.ErrInvalidToken
+	}
+
+	identity := queries.UserIdentity{
+		Provider:  stored.Provider,
+		Subject:   claims.Subject,
+		UserID:    userID,
+		Email:     claims.Email,
+		CreatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+	if err = s.repository.Link(ctx, identity); err != nil {
+		return model.Identity{}, err
+	}
+
+	return toIdentity(identity), nil
+}
+
+func (s *Service) List(ctx context.Context, userID string) ([]model.Identity, error) {
+	stored, err := s.repository.GetUserIdentities(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]model.Identity, 0, len(stored))
+	for _, identity := range stored {
+		identities = append(identities, toIdentity(identity))
+	}
+
+	return identities, nil
+}
+
+// Unlink - отвязать аккаунт провайдера. Пользователь без пароля не может отвязать последний аккаунт,
+// иначе он не сможет войти.
+func (s *Service) Unlink(ctx context.Context, userID, providerName string) error {
+	user, err := s.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.PasswordHash == "" {
+		identities, err := s.repository.GetUserIdentities(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if len(identities) == 1 && identities[0].Provider == providerName {
+			return utils.ErrLastLoginMethod
+		}
+	}
+
+	return s.repository.Unlink(ctx, userID, providerName)
+}
+
+// PurgeExpiredStates - удалить брошенные входы
+func (s *Service) PurgeExpiredStates(ctx context.Context) error {
+	_, err := s.repository.DeleteExpiredStates(ctx)
+	return err
+}
+
+// begin - сохранить state, nonce и PKCE verifier на сервере и собрать ссылку на провайдера
+func (s *Service) begin(ctx context.Context, providerName, userID string) (model.OIDCAuthorization, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return model.OIDCAuthorization{}, utils.ErrUnknownProvider
+	}
+
+	state := rand.Text()
+	nonce := rand.Text()
+	codeVerifier := rand.Text() + rand.Text()
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	authorizationURL, err := provider.authorizationURL(ctx, s.redirectURL, state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return model.OIDCAuthorization{}, err
+	}
+
+	if err = s.repository.CreateState(ctx, queries.OidcState{
+		ID:           utils.HashToken(state),
+		Provider:     provider.name,
+		UserID:       pgtype.Text{String: userID, Valid: userID != ""},
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    pgtype.Timestamptz{Time: time.Now().Add(stateExpires), Valid: true},
+	}); err != nil {
+		return model.OIDCAuthorization{}, err
+	}
+
+	return model.OIDCAuthorization{
+		URL:   authorizationURL,
+		State: state,
+	}, nil
+}
+
+// finish - забрать одноразовый state и обменять code на проверенные claims ID токена
+func (s *Service) finish(ctx context.Context, state, code string) (queries.OidcState, *idTokenClaims, error) {
+	if state == "" || code == "" {
+		return queries.OidcState{}, nil, utils.ErrInvalidToken
+	}
+
+	stored, err := s.repository.ConsumeState(ctx, utils.HashToken(state))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return queries.OidcState{}, nil, utils.ErrInvalidToken
+		}
+		return queries.OidcState{}, nil, err
+	}
+
+	// provider was removed from the config while the user was away
+	provider, ok := s.providers[stored.Provider]
+	if !ok {
+		return queries.OidcState{}, nil, utils.ErrUnknownProvider
+	}
+
+	claims, err := provider.exchange(ctx, s.redirectURL, code, stored.CodeVerifier, stored.Nonce)
+	if err != nil {
+		return queries.OidcState{}, nil, err
+	}
+
+	return stored, claims, nil
+}
+
+// register - создать пользователя без пароля для нового аккаунта провайдера.
+// Существующий аккаунт с той же почтой не привязывается автоматически: владелец почты
+// у провайдера и у нас может отличаться, поэтому привязка делается только вручную после входа.
+func (s *Service) register(ctx context.Context, providerName string, claims *idTokenClaims) (string, error) {
+	email := strings.TrimSpace(claims.Email)
+	if email == "" || !claims.verifiedEmail() {
+		return "", utils.ErrEmailNotVerified
+	}
+
+	if _, err := s.userRepository.GetUserByEmail(ctx, email); err == nil {
+		return "", utils.ErrIdentityNotLinked
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return "", err
+	}
+
+	user := queries.User{
+		ID:    ulid.Make().String(),
+		Email: email,
+	}
+	identity := queries.UserIdentity{
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    email,
+	}
+	if err := s.repository.CreateUser(ctx, user, identity); err != nil {
+		return "", err
+	}
+
+	return user.ID, nil
+}
+
+func toIdentity(stored queries.UserIdentity) model.Identity {
+	identity := model.Identity{
+		Provider:  stored.Provider,
+		Email:     stored.Email,
+		CreatedAt: stored.CreatedAt.Time,
+	}
+
+	if stored.LastLoginAt.Valid {
+		lastLoginAt := stored.LastLoginAt.Time
+		identity.LastLoginAt = &lastLoginAt
+	}
+
+	return identity
+}
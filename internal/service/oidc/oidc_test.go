@@ -0,0 +1,307 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	serviceMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/service/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+var testUser = mockUser{Subject: "248289761001", Email: "jane@example.com", EmailVerified: true}
+
+type testEnv struct {
+	service            *Service
+	provider           *mockProvider
+	identityRepository *repositoryMocks.MockIdentityRepository
+	userRepository     *repositoryMocks.MockUserRepository
+	authService        *serviceMocks.MockAuthService
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	provider := newMockProvider(t)
+	env := &testEnv{
+		provider:           provider,
+		identityRepository: repositoryMocks.NewMockIdentityRepository(t),
+		userRepository:     repositoryMocks.NewMockUserRepository(t),
+		authService:        serviceMocks.NewMockAuthService(t),
+	}
+
+	cfg := &infra.Config{
+		OIDCProviders:   []infra.OIDCProvider{provider.config()},
+		OIDCRedirectURL: testRedirectURL,
+	}
+	env.service = NewService(cfg, env.identityRepository, env.userRepository, nil)
+	env.service.authService = env.authService
+
+	return env
+}
+
+// signIn - начать вход или привязку, пройти страницу провайдера и вернуть state и code из редиректа
+func (e *testEnv) signIn(t *testing.T, userID string, user mockUser) (string, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	var stored queries.OidcState
+	e.identityRepository.On("CreateState", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(queries.OidcState)
+	}).Return(nil).Once()
+
+	var authorization model.OIDCAuthorization
+	var err error
+	if userID == "" {
+		authorization, err = e.service.BeginLogin(ctx, "mock")
+	} else {
+		authorization, err = e.service.BeginLink(ctx, userID, "mock")
+	}
+	require.NoError(t, err)
+
+	assert.Equal(t, utils.HashToken(authorization.State), stored.ID)
+	assert.Equal(t, userID, stored.UserID.String)
+
+	state, code := e.provider.authorize(t, authorization.URL, user)
+	require.Equal(t, authorization.State, state)
+
+	e.identityRepository.On("ConsumeState", ctx, stored.ID).Return(stored, nil).Once()
+	return state, code
+}
+
+func TestFinishLogin(t *testing.T) {
+	ctx := context.Background()
+	tokens := model.LoginResult{Tokens: model.TokenPair{AccessToken: "access", RefreshToken: "refresh"}}
+
+	t.Run("linked identity", func(t *testing.T) {
+		env := newTestEnv(t)
+		state, code := env.signIn(t, "", testUser)
+
+		env.identityRepository.On("GetIdentity", ctx, "mock", testUser.Subject).Return(queries.UserIdentity{Provider: "mock", Subject: testUser.Subject, UserID: "user-1"}, nil).Once()
+		env.identityRepository.On("Touch", ctx, "mock", testUser.Subject, testUser.Email).Return(nil).Once()
+		env.authService.On("CompleteLogin", ctx, "user-1").Return(tokens, nil).Once()
+
+		result, err := env.service.FinishLogin(ctx, state, code)
+		require.NoError(t, err)
+		assert.Equal(t, tokens, result)
+	})
+
+	t.Run("new user is registered", func(t *testing.T) {
+		env := newTestEnv(t)
+		state, code := env.signIn(t, "", testUser)
+
+		var createdID string
+		env.identityRepository.On("GetIdentity", ctx, "mock", testUser.Subject).Return(queries.UserIdentity{}, pgx.ErrNoRows).Once()
+		env.userRepository.On("GetUserByEmail", ctx, testUser.Email).Return(queries.User{}, pgx.ErrNoRows).Once()
+		env.identityRepository.On("CreateUser", ctx, mock.MatchedBy(func(user queries.User) bool {
+			createdID = user.ID
+			return user.ID != "" && user.Email == testUser.Email && user.PasswordHash == ""
+		}), queries.UserIdentity{Provider: "mock", Subject: testUser.Subject, Email: testUser.Email}).Return(nil).Once()
+		env.authService.On("CompleteLogin", ctx, mock.MatchedBy(func(userID string) bool {
+			return userID == createdID
+		})).Return(tokens, nil).Once()
+
+		result, err := env.service.FinishLogin(ctx, state, code)
+		require.NoError(t, err)
+		assert.Equal(t, tokens, result)
+	})
+
+	t.Run("email already registered", func(t *testing.T) {
+		env := newTestEnv(t)
+		state, code := env.signIn(t, "", testUser)
+
+		env.identityRepository.On("GetIdentity", ctx, "mock", testUser.Subject).Return(queries.UserIdentity{}, pgx.ErrNoRows).Once()
+		env.userRepository.On("GetUserByEmail", ctx, testUser.Email).Return(queries.User{ID: "user-1"}, nil).Once()
+
+		_, err := env.service.FinishLogin(ctx, state, code)
+		assert.ErrorIs(t, err, utils.ErrIdentityNotLinked)
+	})
+
+	t.Run("unverified email is not registered", func(t *testing.T) {
+		env := newTestEnv(t)
+		unverified := testUser
+		unverified.EmailVerified = false
+		state, code := env.signIn(t, "", unverified)
+
+		env.identityRepository.On("GetIdentity", ctx, "mock", testUser.Subject).Return(queries.UserIdentity{}, pgx.ErrNoRows).Once()
+
+		_, err := env.service.FinishLogin(ctx, state, code)
+		assert.ErrorIs(t, err, utils.ErrEmailNotVerified)
+	})
+
+	t.Run("linking state cannot log in", func(t *testing.T) {
+		env := newTestEnv(t)
+		state, code := env.signIn(t, "user-1", testUser)
+
+		_, err := env.service.FinishLogin(ctx, state, code)
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	t.Run("unknown or used state", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.identityRepository.On("ConsumeState", ctx, utils.HashToken("stale")).Return(queries.OidcState{}, pgx.ErrNoRows).Once()
+
+		_, err := env.service.FinishLogin(ctx, "stale", "code")
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	t.Run("code replay", func(t *testing.T) {
+		env := newTestEnv(t)
+		state, code := env.signIn(t, "", testUser)
+		env.provider.mu.Lock()
+		delete(env.provider.grants, code)
+		env.provider.mu.Unlock()
+
+		_, err := env.service.FinishLogin(ctx, state, code)
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	rejected := []struct {
+		name   string
+		tamper func(claims jwt.MapClaims)
+	}{
+		{name: "nonce mismatch", tamper: func(claims jwt.MapClaims) { claims["nonce"] = "other" }},
+		{name: "another audience", tamper: func(claims jwt.MapClaims) { claims["aud"] = "other-client" }},
+		{name: "another issuer", tamper: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" }},
+		{name: "authorized party mismatch", tamper: func(claims jwt.MapClaims) { claims["azp"] = "other-client" }},
+		{name: "expired", tamper: func(claims jwt.MapClaims) { claims["exp"] = claims["iat"].(int64) - 3600 }},
+		{name: "no subject", tamper: func(claims jwt.MapClaims) { delete(claims, "sub") }},
+	}
+	for _, tt := range rejected {
+		t.Run("id token "+tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.provider.tamper = tt.tamper
+			state, code := env.signIn(t, "", testUser)
+
+			_, err := env.service.FinishLogin(ctx, state, code)
+			assert.ErrorIs(t, err, utils.ErrInvalidToken)
+		})
+	}
+}
+
+func TestFinishLink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		env := newTestEnv(t)
+		state, code := env.signIn(t, "user-1", testUser)
+
+		env.identityRepository.On("Link", ctx, mock.MatchedBy(func(identity queries.UserIdentity) bool {
+			return identity.Provider == "mock" && identity.Subject == testUser.Subject && identity.UserID == "user-1" && identity.Email == testUser.Email
+		})).Return(nil).Once()
+
+		identity, err := env.service.FinishLink(ctx, "user-1", state, code)
+		require.NoError(t, err)
+		assert.Equal(t, "mock", identity.Provider)
+		assert.Equal(t, testUser.Email, identity.Email)
+	})
+
+	t.Run("identity of another user", func(t *testing.T) {
+		env := newTestEnv(t)
+		state, code := env.signIn(t, "user-1", testUser)
+
+		env.identityRepository.On("Link", ctx, mock.Anything).Return(utils.ErrIdentityLinked).Once()
+
+		_, err := env.service.FinishLink(ctx, "user-1", state, code)
+		assert.ErrorIs(t, err, utils.ErrIdentityLinked)
+	})
+
+	t.Run("started by another user", func(t *testing.T) {
+		env := newTestEnv(t)
+		state, code := env.signIn(t, "user-1", testUser)
+
+		_, err := env.service.FinishLink(ctx, "user-2", state, code)
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+
+	t.Run("login state cannot link", func(t *testing.T) {
+		env := newTestEnv(t)
+		state, code := env.signIn(t, "", testUser)
+
+		_, err := env.service.FinishLink(ctx, "user-1", state, code)
+		assert.ErrorIs(t, err, utils.ErrInvalidToken)
+	})
+}
+
+func TestUnlink(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		passwordHash  string
+		identities    []queries.UserIdentity
+		expectedError error
+	}{
+		{name: "user with password", passwordHash: "$argon2id$hash"},
+		{name: "another identity left", identities: []queries.UserIdentity{{Provider: "mock"}, {Provider: "google"}}},
+		{name: "last login method", identities: []queries.UserIdentity{{Provider: "mock"}}, expectedError: utils.ErrLastLoginMethod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+
+			env.userRepository.On("GetUserByID", ctx, "user-1").Return(queries.User{ID: "user-1", PasswordHash: tt.passwordHash}, nil).Once()
+			if tt.passwordHash == "" {
+				env.identityRepository.On("GetUserIdentities", ctx, "user-1").Return(tt.identities, nil).Once()
+			}
+			if tt.expectedError == nil {
+				env.identityRepository.On("Unlink", ctx, "user-1", "mock").Return(nil).Once()
+			}
+
+			err := env.service.Unlink(ctx, "user-1", "mock")
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUnknownProvider(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.service.BeginLogin(context.Background(), "github")
+	assert.ErrorIs(t, err, utils.ErrUnknownProvider)
+	assert.Equal(t, []string{"mock"}, env.service.Providers())
+}
+
+func TestProviderUnavailable(t *testing.T) {
+	env := newTestEnv(t)
+	env.provider.server.Close()
+
+	_, err := env.service.BeginLogin(context.Background(), "mock")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, utils.ErrInvalidToken)
+}
+
+func TestRotatedKey(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	provider := env.service.providers["mock"]
+
+	// keys cached before the provider rotated them
+	_, err := provider.key(ctx, env.provider.kid)
+	require.NoError(t, err)
+	env.provider.kid = "rotated-key"
+	provider.keysFetchedAt = provider.keysFetchedAt.Add(-keysRefreshInterval)
+
+	state, code := env.signIn(t, "", testUser)
+	env.identityRepository.On("GetIdentity", ctx, "mock", testUser.Subject).Return(queries.UserIdentity{UserID: "user-1"}, nil).Once()
+	env.identityRepository.On("Touch", ctx, mock.Anything, mock.Anything, testUser.Email).Return(nil).Once()
+	env.authService.On("CompleteLogin", ctx, "user-1").Return(model.LoginResult{}, nil).Once()
+
+	_, err = env.service.FinishLogin(ctx, state, code)
+	assert.NoError(t, err)
+	assert.Contains(t, provider.keys, "rotated-key")
+}
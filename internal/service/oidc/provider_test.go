@@ -0,0 +1,259 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+const (
+	testClientID     = "webTemplate"
+	testClientSecret = "client-secret"
+	testRedirectURL  = "http://localhost:8080/oidc/callback"
+)
+
+// mockUser - аккаунт, которым пользователь входит на странице mock провайдера
+type mockUser struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+type mockGrant struct {
+	user          mockUser
+	nonce         string
+	codeChallenge string
+	redirectURI   string
+}
+
+// mockProvider - локальный OpenID Connect провайдер: discovery, JWKS и token endpoint с PKCE
+type mockProvider struct {
+	server *httptest.Server
+	key    *ecdsa.PrivateKey
+	kid    string
+
+	mu     sync.Mutex
+	grants map[string]mockGrant
+	// tamper - изменить claims ID токена перед подписью
+	tamper func(claims jwt.MapClaims)
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	provider := &mockProvider{
+		key:    key,
+		kid:    "mock-key",
+		grants: make(map[string]mockGrant),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", provider.discovery)
+	mux.HandleFunc("GET /jwks", provider.jwks)
+	mux.HandleFunc("POST /token", provider.token)
+	provider.server = httptest.NewServer(mux)
+	t.Cleanup(provider.server.Close)
+
+	return provider
+}
+
+func (p *mockProvider) config() infra.OIDCProvider {
+	return infra.OIDCProvider{
+		Name:         "mock",
+		Issuer:       p.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		Scopes:       []string{"openid", "email"},
+	}
+}
+
+// authorize - то, что делает провайдер после входа пользователя: проверить запрос и выдать code
+func (p *mockProvider) authorize(t *testing.T, authorizationURL string, user mockUser) (state, code string) {
+	t.Helper()
+
+	parsed, err := url.Parse(authorizationURL)
+	require.NoError(t, err)
+	require.Equal(t, p.server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+
+	query := parsed.Query()
+	require.Equal(t, "code", query.Get("response_type"))
+	require.Equal(t, testClientID, query.Get("client_id"))
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+	require.Contains(t, query.Get("scope"), "openid")
+	require.NotEmpty(t, query.Get("nonce"))
+
+	code = rand.Text()
+	p.mu.Lock()
+	p.grants[code] = mockGrant{
+		user:          user,
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		redirectURI:   query.Get("redirect_uri"),
+	}
+	p.mu.Unlock()
+
+	return query.Get("state"), code
+}
+
+func (p *mockProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 p.server.URL,
+		"authorization_endpoint": p.server.URL + "/authorize",
+		"token_endpoint":         p.server.URL + "/token",
+		"jwks_uri":               p.server.URL + "/jwks",
+	})
+}
+
+func (p *mockProvider) jwks(w http.ResponseWriter, _ *http.Request) {
+	point, err := p.key.PublicKey.Bytes()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]model.JWK{
+		"keys": {
+			{Kty: "EC", Kid: p.kid, Alg: "ES256", Use: "sig", Crv: "P-256", X: encode(point[1:33]), Y: encode(point[33:])},
+			// encryption keys must be ignored
+			{Kty: "EC", Kid: "enc-key", Use: "enc", Crv: "P-256", X: encode(point[1:33]), Y: encode(point[33:])},
+		},
+	})
+}
+
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != testClientID || clientSecret != testClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	p.mu.Lock()
+	grant, found := p.grants[code]
+	delete(p.grants, code)
+	tamper := p.tamper
+	p.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !found || grant.redirectURI != r.PostForm.Get("redirect_uri") || encode(challenge[:]) != grant.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            p.server.URL,
+		"aud":            testClientID,
+		"sub":            grant.user.Subject,
+		"email":          grant.user.Email,
+		"email_verified": grant.user.EmailVerified,
+		"nonce":          grant.nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+	if tamper != nil {
+		tamper(claims)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = p.kid
+	idToken, err := token.SignedString(p.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": rand.Text(),
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func TestDiscoverIssuerMismatch(t *testing.T) {
+	mock := newMockProvider(t)
+	cfg := mock.config()
+	cfg.Issuer = mock.server.URL + "/tenant"
+
+	_, err := newProvider(cfg, http.DefaultClient).discover(t.Context())
+	assert.Error(t, err)
+}
+
+func TestParseJWK(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		jwk      model.JWK
+		expected any
+		wantErr  bool
+	}{
+		{
+			name:     "rsa",
+			jwk:      model.JWK{Kty: "RSA", N: encode(rsaKey.N.Bytes()), E: encode(big.NewInt(int64(rsaKey.E)).Bytes())},
+			expected: &rsaKey.PublicKey,
+		},
+		{
+			name:     "ed25519",
+			jwk:      model.JWK{Kty: "OKP", Crv: "Ed25519", X: encode(edKey)},
+			expected: edKey,
+		},
+		{name: "unsupported curve", jwk: model.JWK{Kty: "EC", Crv: "P-384"}, wantErr: true},
+		{name: "point not on curve", jwk: model.JWK{Kty: "EC", Crv: "P-256", X: encode(make([]byte, 32)), Y: encode(make([]byte, 32))}, wantErr: true},
+		{name: "short ed25519 key", jwk: model.JWK{Kty: "OKP", Crv: "Ed25519", X: encode(edKey[:16])}, wantErr: true},
+		{name: "symmetric key", jwk: model.JWK{Kty: "oct"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := parseJWK(tt.jwk)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, key)
+		})
+	}
+}
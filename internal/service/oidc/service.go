@@ -0,0 +1,44 @@
+package oidc
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/repository"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/auth"
+)
+
+// requestTimeout - сколько ждать ответа провайдера
+const requestTimeout = 10 * time.Second
+
+type Service struct {
+	providers      map[string]*provider
+	names          []string
+	redirectURL    string
+	repository     repository.IdentityRepository
+	userRepository repository.UserRepository
+	authService    service.AuthService
+}
+
+// NewService - создать новый экземпляр сервиса входа через OpenID Connect, токены выдаются сервисом авторизации
+func NewService(cfg *infra.Config, identityRepository repository.IdentityRepository, userRepository repository.UserRepository, authService *auth.Service) *Service {
+	client := &http.Client{Timeout: requestTimeout}
+
+	providers := make(map[string]*provider, len(cfg.OIDCProviders))
+	names := make([]string, 0, len(cfg.OIDCProviders))
+	for _, providerCfg := range cfg.OIDCProviders {
+		providers[providerCfg.Name] = newProvider(providerCfg, client)
+		names = append(names, providerCfg.Name)
+	}
+
+	return &Service{
+		providers:      providers,
+		names:          names,
+		redirectURL:    cfg.OIDCRedirectURL,
+		repository:     identityRepository,
+		userRepository: userRepository,
+		authService:    authService,
+	}
+}
@@ -0,0 +1,40 @@
+package passkey
+
+import (
+	"context"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+// Exporter - раздел "passkeys" выгрузки персональных данных: зарегистрированные passkey без ключей
+type Exporter struct {
+	service *Service
+}
+
+// NewExporter - выгрузить имена и даты использования passkey
+func NewExporter(service *Service) *Exporter {
+	return &Exporter{service: service}
+}
+
+func (e *Exporter) Name() string {
+	return "passkeys"
+}
+
+func (e *Exporter) Export(ctx context.Context, userID string) (any, error) {
+	passkeys, err := e.service.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ExportPasskey, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		result = append(result, model.ExportPasskey{
+			ID:         passkey.ID,
+			Name:       passkey.Name,
+			CreatedAt:  passkey.CreatedAt,
+			LastUsedAt: passkey.LastUsedAt,
+		})
+	}
+
+	return result, nil
+}
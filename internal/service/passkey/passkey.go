@@ -0,0 +1,218 @@
+package passkey
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/oklog/ulid/v2"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// ceremonyExpires - сколько живёт начатая регистрация или вход
+const ceremonyExpires = 5 * time.Minute
+
+// BeginRegistration - начать регистрацию нового ключа для вошедшего пользователя
+func (s *Service) BeginRegistration(ctx context.Context, userID string) (model.PasskeyCeremony, error) {
+	user, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return model.PasskeyCeremony{}, err
+	}
+
+	creation, session, err := s.webAuthn.BeginRegistration(user,
+		webauthn.WithExclusions(user.exclusions()),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+	)
+	if err != nil {
+		return model.PasskeyCeremony{}, err
+	}
+
+	return s.saveCeremony(ctx, userID, creation, session)
+}
+
+// FinishRegistration - проверить ответ аутентификатора и сохранить ключ
+func (s *Service) FinishRegistration(ctx context.Context, userID, sessionID, name string, response []byte) (model.Passkey, error) {
+	session, err := s.consumeSession(ctx, sessionID, userID)
+	if err != nil {
+		return model.Passkey{}, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		return model.Passkey{}, fmt.Errorf("%w: %w", utils.ErrInvalidPasskey, err)
+	}
+
+	user, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return model.Passkey{}, err
+	}
+
+	credential, err := s.webAuthn.CreateCredential(user, session, parsed)
+	if err != nil {
+		return model.Passkey{}, fmt.Errorf("%w: %w", utils.ErrInvalidPasskey, err)
+	}
+
+	stored := fromCredential(ulid.Make().String(), userID, strings.TrimSpace(name), credential)
+	stored.CreatedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	if err = s.repository.CreateCredential(ctx, stored); err != nil {
+		return model.Passkey{}, err
+	}
+
+	return toPasskey(stored), nil
+}
+
+// BeginLogin - начать вход по passkey, пользователь определяется выбранным на устройстве ключом
+func (s *Service) BeginLogin(ctx context.Context) (model.PasskeyCeremony, error) {
+	// user verification makes the passkey a second factor by itself, so 2FA is not asked
+	assertion, session, err := s.webAuthn.BeginDiscoverableLogin(
+		webauthn.WithUserVerification(protocol.VerificationRequired),
+	)
+	if err != nil {
+		return model.PasskeyCeremony{}, err
+	}
+
+	return s.saveCeremony(ctx, "", assertion, session)
+}
+
+// FinishLogin - проверить подпись ключа и выдать те же токены, что и Login
+func (s *Service) FinishLogin(ctx context.Context, sessionID string, response []byte) (model.TokenPair, error) {
+	session, err := s.consumeSession(ctx, sessionID, "")
+	if err != nil {
+		return model.TokenPair{}, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return model.TokenPair{}, fmt.Errorf("%w: %w", utils.ErrInvalidPasskey, err)
+	}
+
+	var stored queries.WebauthnCredential
+	handler := func(rawID, userHandle []byte) (webauthn.User, error) {
+		stored, err = s.repository.GetCredential(ctx, rawID)
+		if err != nil {
+			return nil, err
+		}
+
+		// credential must belong to the account the authenticator claims
+		if !bytes.Equal([]byte(stored.UserID), userHandle) {
+			return nil, utils.ErrInvalidPasskey
+		}
+
+		return s.loadUser(ctx, stored.UserID)
+	}
+
+	_, credential, err := s.webAuthn.ValidatePasskeyLogin(handler, session, parsed)
+	if err != nil {
+		return model.TokenPair{}, fmt.Errorf("%w: %w", utils.ErrInvalidPasskey, err)
+	}
+
+	// sign counter went backwards, private key was probably copied
+	if credential.Authenticator.CloneWarning {
+		return model.TokenPair{}, fmt.Errorf("%w: sign counter regression", utils.ErrInvalidPasskey)
+	}
+
+	if err = s.repository.UpdateCredentialUsage(ctx, stored.ID, int64(credential.Authenticator.SignCount), credential.Flags.BackupState); err != nil {
+		return model.TokenPair{}, err
+	}
+
+	return s.authService.IssueTokens(ctx, stored.UserID)
+}
+
+func (s *Service) List(ctx context.Context, userID string) ([]model.Passkey, error) {
+	stored, err := s.repository.GetUserCredentials(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	passkeys := make([]model.Passkey, 0, len(stored))
+	for _, credential := range stored {
+		passkeys = append(passkeys, toPasskey(credential))
+	}
+
+	return passkeys, nil
+}
+
+func (s *Service) Delete(ctx context.Context, userID, id string) error {
+	return s.repository.DeleteCredential(ctx, id, userID)
+}
+
+// PurgeExpiredSessions - удалить брошенные церемонии
+func (s *Service) PurgeExpiredSessions(ctx context.Context) error {
+	_, err := s.repository.DeleteExpiredSessions(ctx)
+	return err
+}
+
+func (s *Service) loadUser(ctx context.Context, userID string) (*webAuthnUser, error) {
+	user, err := s.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := s.repository.GetUserCredentials(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWebAuthnUser(user, credentials), nil
+}
+
+// saveCeremony - сохранить данные церемонии на сервере, клиент получает только ID сессии и options
+func (s *Service) saveCeremony(ctx context.Context, userID string, options any, session *webauthn.SessionData) (model.PasskeyCeremony, error) {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return model.PasskeyCeremony{}, err
+	}
+
+	rawOptions, err := json.Marshal(options)
+	if err != nil {
+		return model.PasskeyCeremony{}, err
+	}
+
+	stored := queries.WebauthnSession{
+		ID:        ulid.Make().String(),
+		UserID:    pgtype.Text{String: userID, Valid: userID != ""},
+		Data:      data,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(ceremonyExpires), Valid: true},
+	}
+	if err = s.repository.CreateSession(ctx, stored); err != nil {
+		return model.PasskeyCeremony{}, err
+	}
+
+	return model.PasskeyCeremony{
+		SessionID: stored.ID,
+		Options:   rawOptions,
+	}, nil
+}
+
+// consumeSession - забрать одноразовую сессию церемонии, начатую тем же пользователем
+func (s *Service) consumeSession(ctx context.Context, sessionID, userID string) (webauthn.SessionData, error) {
+	stored, err := s.repository.ConsumeSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return webauthn.SessionData{}, utils.ErrInvalidPasskey
+		}
+		return webauthn.SessionData{}, err
+	}
+
+	if stored.UserID.String != userID {
+		return webauthn.SessionData{}, utils.ErrInvalidPasskey
+	}
+
+	var session webauthn.SessionData
+	if err = json.Unmarshal(stored.Data, &session); err != nil {
+		return webauthn.SessionData{}, err
+	}
+
+	return session, nil
+}
@@ -0,0 +1,330 @@
+package passkey
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	serviceMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/service/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const (
+	testOrigin = "http://localhost:8080"
+	testRPID   = "localhost"
+	testUserID = "test-user-123"
+)
+
+// authenticator - программный аутентификатор с ключом ES256
+type authenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	counter      uint32
+}
+
+func newAuthenticator(t *testing.T) *authenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	credentialID := make([]byte, 16)
+	_, err = rand.Read(credentialID)
+	require.NoError(t, err)
+
+	return &authenticator{key: key, credentialID: credentialID}
+}
+
+func (a *authenticator) publicKey(t *testing.T) []byte {
+	t.Helper()
+	publicKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  int64(webauthncose.P256),
+		XCoord: a.key.X.FillBytes(make([]byte, 32)),
+		YCoord: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+	require.NoError(t, err)
+	return publicKey
+}
+
+func (a *authenticator) authData(t *testing.T, attested bool) []byte {
+	t.Helper()
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+
+	// user present | user verified
+	flags := byte(0x01 | 0x04)
+	if attested {
+		flags |= 0x40
+	}
+
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.counter)
+	if attested {
+		data = append(data, make([]byte, 16)...) // zero AAGUID
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, a.publicKey(t)...)
+	}
+	return data
+}
+
+func clientData(t *testing.T, ceremonyType, challenge string) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]string{
+		"type":      ceremonyType,
+		"challenge": challenge,
+		"origin":    testOrigin,
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func (a *authenticator) create(t *testing.T, challenge string) []byte {
+	t.Helper()
+	attestation, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": a.authData(t, true),
+	})
+	require.NoError(t, err)
+
+	return encodeCredential(t, a.credentialID, map[string]string{
+		"clientDataJSON":    b64(clientData(t, "webauthn.create", challenge)),
+		"attestationObject": b64(attestation),
+	})
+}
+
+func (a *authenticator) get(t *testing.T, challenge, userHandle string) []byte {
+	t.Helper()
+	authData := a.authData(t, false)
+	clientDataJSON := clientData(t, "webauthn.get", challenge)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(t, err)
+
+	return encodeCredential(t, a.credentialID, map[string]string{
+		"clientDataJSON":    b64(clientDataJSON),
+		"authenticatorData": b64(authData),
+		"signature":         b64(signature),
+		"userHandle":        b64([]byte(userHandle)),
+	})
+}
+
+func (a *authenticator) stored(t *testing.T, signCount uint32) queries.WebauthnCredential {
+	t.Helper()
+	return queries.WebauthnCredential{
+		ID:              "passkey-1",
+		UserID:          testUserID,
+		Name:            "laptop",
+		CredentialID:    a.credentialID,
+		PublicKey:       a.publicKey(t),
+		AttestationType: "none",
+		Aaguid:          make([]byte, 16),
+		SignCount:       int64(signCount),
+	}
+}
+
+func encodeCredential(t *testing.T, credentialID []byte, response map[string]string) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"id":       b64(credentialID),
+		"rawId":    b64(credentialID),
+		"type":     "public-key",
+		"response": response,
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+type testMocks struct {
+	passkeyRepo *repositoryMocks.MockPasskeyRepository
+	userRepo    *repositoryMocks.MockUserRepository
+	authService *serviceMocks.MockAuthService
+}
+
+func newTestService(t *testing.T) (*Service, testMocks) {
+	t.Helper()
+	cfg := &infra.Config{
+		WebAuthnRPID:    testRPID,
+		WebAuthnRPName:  "webTemplate",
+		WebAuthnOrigins: []string{testOrigin},
+	}
+	webAuthn, err := newWebAuthn(cfg)
+	require.NoError(t, err)
+
+	mocks := testMocks{
+		passkeyRepo: repositoryMocks.NewMockPasskeyRepository(t),
+		userRepo:    repositoryMocks.NewMockUserRepository(t),
+		authService: serviceMocks.NewMockAuthService(t),
+	}
+
+	return &Service{
+		webAuthn:       webAuthn,
+		repository:     mocks.passkeyRepo,
+		userRepository: mocks.userRepo,
+		authService:    mocks.authService,
+	}, mocks
+}
+
+// expectCeremony - сохранить сессию церемонии и вернуть её challenge
+func expectCeremony(t *testing.T, mocks testMocks, userID string) (*queries.WebauthnSession, func() string) {
+	t.Helper()
+	var stored queries.WebauthnSession
+	mocks.passkeyRepo.On("CreateSession", mock.Anything, mock.MatchedBy(func(session queries.WebauthnSession) bool {
+		return session.UserID.String == userID && session.ExpiresAt.Valid
+	})).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(queries.WebauthnSession)
+	}).Return(nil).Once()
+
+	return &stored, func() string {
+		var session webauthn.SessionData
+		require.NoError(t, json.Unmarshal(stored.Data, &session))
+		return session.Challenge
+	}
+}
+
+func TestRegistration(t *testing.T) {
+	ctx := context.Background()
+	user := queries.User{ID: testUserID, Email: "test@example.com"}
+
+	t.Run("successful registration", func(t *testing.T) {
+		service, mocks := newTestService(t)
+		key := newAuthenticator(t)
+
+		mocks.userRepo.On("GetUserByID", ctx, testUserID).Return(user, nil).Twice()
+		mocks.passkeyRepo.On("GetUserCredentials", ctx, testUserID).Return(nil, nil).Twice()
+		session, challenge := expectCeremony(t, mocks, testUserID)
+
+		ceremony, err := service.BeginRegistration(ctx, testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, session.ID, ceremony.SessionID)
+		assert.Contains(t, string(ceremony.Options), challenge())
+
+		mocks.passkeyRepo.On("ConsumeSession", ctx, session.ID).Return(*session, nil).Once()
+		mocks.passkeyRepo.On("CreateCredential", ctx, mock.MatchedBy(func(credential queries.WebauthnCredential) bool {
+			return credential.UserID == testUserID && credential.Name == "laptop" &&
+				assert.ObjectsAreEqual(key.credentialID, credential.CredentialID)
+		})).Return(nil).Once()
+
+		created, err := service.FinishRegistration(ctx, testUserID, session.ID, " laptop ", key.create(t, challenge()))
+		require.NoError(t, err)
+		assert.Equal(t, "laptop", created.Name)
+		assert.NotEmpty(t, created.ID)
+	})
+
+	t.Run("session started by another user", func(t *testing.T) {
+		service, mocks := newTestService(t)
+		key := newAuthenticator(t)
+
+		session := queries.WebauthnSession{ID: "session-1", UserID: pgtype.Text{String: "other-user", Valid: true}}
+		mocks.passkeyRepo.On("ConsumeSession", ctx, session.ID).Return(session, nil).Once()
+
+		_, err := service.FinishRegistration(ctx, testUserID, session.ID, "laptop", key.create(t, "challenge"))
+		assert.ErrorIs(t, err, utils.ErrInvalidPasskey)
+	})
+}
+
+func TestLogin(t *testing.T) {
+	ctx := context.Background()
+	user := queries.User{ID: testUserID, Email: "test@example.com"}
+	tokens := model.TokenPair{AccessToken: "access", RefreshToken: "refresh"}
+
+	tests := []struct {
+		name          string
+		storedCount   uint32
+		counter       uint32
+		userHandle    string
+		expectedError error
+	}{
+		{
+			name:        "successful login",
+			storedCount: 1,
+			counter:     2,
+			userHandle:  testUserID,
+		},
+		{
+			name:          "sign counter regression",
+			storedCount:   10,
+			counter:       5,
+			userHandle:    testUserID,
+			expectedError: utils.ErrInvalidPasskey,
+		},
+		{
+			name:          "user handle of another account",
+			storedCount:   1,
+			counter:       2,
+			userHandle:    "other-user",
+			expectedError: utils.ErrInvalidPasskey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mocks := newTestService(t)
+			key := newAuthenticator(t)
+			key.counter = tt.counter
+			stored := key.stored(t, tt.storedCount)
+
+			session, challenge := expectCeremony(t, mocks, "")
+			ceremony, err := service.BeginLogin(ctx)
+			require.NoError(t, err)
+
+			mocks.passkeyRepo.On("ConsumeSession", ctx, ceremony.SessionID).Return(*session, nil).Once()
+			mocks.passkeyRepo.On("GetCredential", ctx, key.credentialID).Return(stored, nil).Once()
+			if tt.userHandle == testUserID {
+				mocks.userRepo.On("GetUserByID", ctx, testUserID).Return(user, nil).Once()
+				mocks.passkeyRepo.On("GetUserCredentials", ctx, testUserID).
+					Return([]queries.WebauthnCredential{stored}, nil).Once()
+			}
+			if tt.expectedError == nil {
+				mocks.passkeyRepo.On("UpdateCredentialUsage", ctx, stored.ID, int64(tt.counter), false).Return(nil).Once()
+				mocks.authService.On("IssueTokens", ctx, testUserID).Return(tokens, nil).Once()
+			}
+
+			result, err := service.FinishLogin(ctx, ceremony.SessionID, key.get(t, challenge(), tt.userHandle))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Empty(t, result.AccessToken)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tokens, result)
+			}
+		})
+	}
+
+	t.Run("unknown session", func(t *testing.T) {
+		service, mocks := newTestService(t)
+		mocks.passkeyRepo.On("ConsumeSession", ctx, "missing").Return(queries.WebauthnSession{}, pgx.ErrNoRows).Once()
+
+		_, err := service.FinishLogin(ctx, "missing", []byte("{}"))
+		assert.ErrorIs(t, err, utils.ErrInvalidPasskey)
+	})
+}
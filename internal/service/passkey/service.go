@@ -0,0 +1,40 @@
+package passkey
+
+import (
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/repository"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/auth"
+)
+
+type Service struct {
+	webAuthn       *webauthn.WebAuthn
+	repository     repository.PasskeyRepository
+	userRepository repository.UserRepository
+	authService    service.AuthService
+}
+
+// NewService - создать новый экземпляр сервиса passkey, токены выдаются сервисом авторизации
+func NewService(cfg *infra.Config, passkeyRepository repository.PasskeyRepository, userRepository repository.UserRepository, authService *auth.Service) (*Service, error) {
+	webAuthn, err := newWebAuthn(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		webAuthn:       webAuthn,
+		repository:     passkeyRepository,
+		userRepository: userRepository,
+		authService:    authService,
+	}, nil
+}
+
+func newWebAuthn(cfg *infra.Config) (*webauthn.WebAuthn, error) {
+	return webauthn.New(&webauthn.Config{
+		RPID:          cfg.WebAuthnRPID,
+		RPDisplayName: cfg.WebAuthnRPName,
+		RPOrigins:     cfg.WebAuthnOrigins,
+	})
+}
@@ -0,0 +1,108 @@
+package passkey
+
+import (
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+// webAuthnUser - пользователь вместе с его ключами в виде, который ожидает go-webauthn
+type webAuthnUser struct {
+	user        queries.User
+	credentials []webauthn.Credential
+}
+
+func newWebAuthnUser(user queries.User, stored []queries.WebauthnCredential) *webAuthnUser {
+	credentials := make([]webauthn.Credential, 0, len(stored))
+	for _, credential := range stored {
+		credentials = append(credentials, toCredential(credential))
+	}
+
+	return &webAuthnUser{user: user, credentials: credentials}
+}
+
+// WebAuthnID - user handle, ULID пользователя не содержит персональных данных
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return []byte(u.user.ID)
+}
+
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.user.Email
+}
+
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+func (u *webAuthnUser) exclusions() []protocol.CredentialDescriptor {
+	descriptors := make([]protocol.CredentialDescriptor, 0, len(u.credentials))
+	for _, credential := range u.credentials {
+		descriptors = append(descriptors, credential.Descriptor())
+	}
+	return descriptors
+}
+
+func toCredential(stored queries.WebauthnCredential) webauthn.Credential {
+	transports := make([]protocol.AuthenticatorTransport, 0, len(stored.Transports))
+	for _, transport := range stored.Transports {
+		transports = append(transports, protocol.AuthenticatorTransport(transport))
+	}
+
+	return webauthn.Credential{
+		ID:              stored.CredentialID,
+		PublicKey:       stored.PublicKey,
+		AttestationType: stored.AttestationType,
+		Transport:       transports,
+		Flags: webauthn.CredentialFlags{
+			UserPresent:    true,
+			BackupEligible: stored.BackupEligible,
+			BackupState:    stored.BackupState,
+		},
+		Authenticator: webauthn.Authenticator{
+			AAGUID:    stored.Aaguid,
+			SignCount: uint32(stored.SignCount),
+		},
+	}
+}
+
+func fromCredential(id, userID, name string, credential *webauthn.Credential) queries.WebauthnCredential {
+	transports := make([]string, 0, len(credential.Transport))
+	for _, transport := range credential.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	return queries.WebauthnCredential{
+		ID:              id,
+		UserID:          userID,
+		Name:            name,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Aaguid:          credential.Authenticator.AAGUID,
+		Transports:      transports,
+		SignCount:       int64(credential.Authenticator.SignCount),
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+	}
+}
+
+func toPasskey(stored queries.WebauthnCredential) model.Passkey {
+	passkey := model.Passkey{
+		ID:        stored.ID,
+		Name:      stored.Name,
+		CreatedAt: stored.CreatedAt.Time,
+	}
+
+	if stored.LastUsedAt.Valid {
+		lastUsedAt := stored.LastUsedAt.Time
+		passkey.LastUsedAt = &lastUsedAt
+	}
+
+	return passkey
+}
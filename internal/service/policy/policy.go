@@ -0,0 +1,130 @@
+package policy
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// minBannedWordLength - более короткие части почты встречаются в любом пароле
+const minBannedWordLength = 3
+
+// названия правил, возвращаются клиенту в списке нарушений
+const (
+	ruleMinLength   = "min_length"
+	ruleMaxLength   = "max_length"
+	ruleCharClasses = "character_classes"
+	ruleEntropy     = "entropy"
+	ruleBannedWord  = "banned_word"
+	ruleBreached    = "breached"
+)
+
+// размеры алфавитов классов символов, буквы любых алфавитов считаются как латиница
+const (
+	lowerPool        = 26
+	upperPool        = 26
+	digitPool        = 10
+	symbolPool       = 33
+	charClassesTotal = 4
+)
+
+// Check - проверить пароль по всем правилам политики.
+// Возвращает utils.PasswordPolicyError со списком всех нарушенных правил, а не только первого.
+func (s *Service) Check(password, email string) error {
+	var violations []utils.PolicyViolation
+	fail := func(rule, format string, args ...any) {
+		violations = append(violations, utils.PolicyViolation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if s.minLength > 0 && length < s.minLength {
+		fail(ruleMinLength, "password must be at least %d characters long", s.minLength)
+	}
+	if s.maxLength > 0 && length > s.maxLength {
+		fail(ruleMaxLength, "password must be at most %d characters long", s.maxLength)
+	}
+
+	if classes := len(charClasses(password)); classes < s.minClasses {
+		fail(ruleCharClasses, "password must contain at least %d of: lowercase letters, uppercase letters, digits, symbols", min(s.minClasses, charClassesTotal))
+	}
+
+	if s.minEntropy > 0 && entropy(password) < s.minEntropy {
+		fail(ruleEntropy, "password is too predictable, make it longer or less repetitive")
+	}
+
+	lowered := strings.ToLower(password)
+	for _, word := range s.bannedWordsFor(email) {
+		if strings.Contains(lowered, word) {
+			fail(ruleBannedWord, "password must not contain %q", word)
+		}
+	}
+
+	if _, found := s.breached[sha1.Sum([]byte(password))]; found {
+		fail(ruleBreached, "password has appeared in a data breach")
+	}
+
+	if len(violations) > 0 {
+		return &utils.PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// bannedWordsFor - запрещённые слова из настроек и локальная часть почты пользователя
+func (s *Service) bannedWordsFor(email string) []string {
+	words := s.bannedWords
+
+	localPart, _, _ := strings.Cut(strings.ToLower(email), "@")
+	// plus addressing: "name+tag@example.com" belongs to "name"
+	localPart, _, _ = strings.Cut(localPart, "+")
+	if utf8.RuneCountInString(localPart) >= minBannedWordLength {
+		words = append(words[:len(words):len(words)], localPart)
+	}
+
+	return words
+}
+
+// charClasses - классы символов пароля и размер их алфавитов
+func charClasses(password string) map[string]int {
+	classes := make(map[string]int, charClassesTotal)
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			classes["lower"] = lowerPool
+		case unicode.IsUpper(r):
+			classes["upper"] = upperPool
+		case unicode.IsDigit(r):
+			classes["digit"] = digitPool
+		default:
+			classes["symbol"] = symbolPool
+		}
+	}
+	return classes
+}
+
+// entropy - грубая оценка стойкости в битах: число символов, умноженное на log2 размера алфавита.
+// Повторы и последовательности вроде "aaa" или "123" стойкости не добавляют и не учитываются.
+func entropy(password string) float64 {
+	pool := 0
+	for _, size := range charClasses(password) {
+		pool += size
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	effective := 0
+	var previous rune
+	for i, r := range []rune(password) {
+		if i == 0 || (r != previous && r != previous+1 && r != previous-1) {
+			effective++
+		}
+		previous = r
+	}
+
+	return float64(effective) * math.Log2(float64(pool))
+}
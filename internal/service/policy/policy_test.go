@@ -0,0 +1,148 @@
+package policy
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func writeBreachedList(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600))
+	return path
+}
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func rules(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+
+	var policyErr *utils.PasswordPolicyError
+	require.ErrorAs(t, err, &policyErr)
+	require.ErrorIs(t, err, utils.ErrWeakPassword)
+
+	result := make([]string, 0, len(policyErr.Violations))
+	for _, violation := range policyErr.Violations {
+		assert.NotEmpty(t, violation.Message)
+		result = append(result, violation.Rule)
+	}
+	return result
+}
+
+func TestCheck(t *testing.T) {
+	cfg := &infra.Config{
+		PasswordMinLength:      8,
+		PasswordMaxLength:      64,
+		PasswordMinCharClasses: 3,
+		PasswordMinEntropy:     50,
+		PasswordBannedWords:    []string{" Acme ", ""},
+		PasswordBreachedList: writeBreachedList(t,
+			"# leaked passwords",
+			sha1Hex("Tr0ub4dor&3")+":1337",
+			"",
+		),
+	}
+	service, err := NewService(cfg)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		password string
+		email    string
+		expected []string
+	}{
+		{
+			name:     "strong password",
+			password: "SuperStrongPassword2000!",
+			email:    "user@example.com",
+		},
+		{
+			name:     "everything wrong at once",
+			password: "dsadsa",
+			email:    "user@example.com",
+			expected: []string{ruleMinLength, ruleCharClasses, ruleEntropy},
+		},
+		{
+			name:     "too long",
+			password: strings.Repeat("Ab1!", 17),
+			email:    "user@example.com",
+			expected: []string{ruleMaxLength},
+		},
+		{
+			name:     "repeats and sequences are predictable",
+			password: "Aaaaaaaa1234567!",
+			email:    "user@example.com",
+			expected: []string{ruleEntropy},
+		},
+		{
+			name:     "email local part",
+			password: "John.Smith2000!",
+			email:    "john.smith+news@example.com",
+			expected: []string{ruleBannedWord},
+		},
+		{
+			name:     "configured banned word",
+			password: "MyACMEAccount2000!",
+			email:    "user@example.com",
+			expected: []string{ruleBannedWord},
+		},
+		{
+			name:     "short local part is not banned",
+			password: "Strong-Password-42",
+			email:    "jo@example.com",
+		},
+		{
+			name:     "breached password",
+			password: "Tr0ub4dor&3",
+			email:    "user@example.com",
+			expected: []string{ruleBreached},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rules(t, service.Check(tt.password, tt.email)))
+		})
+	}
+}
+
+func TestCheckDisabledRules(t *testing.T) {
+	service, err := NewService(&infra.Config{})
+	require.NoError(t, err)
+
+	assert.NoError(t, service.Check("", "user@example.com"))
+}
+
+func TestNewServiceBreachedListErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.txt")},
+		{name: "truncated hash", path: writeBreachedList(t, "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD")},
+		{name: "not hex", path: writeBreachedList(t, strings.Repeat("Z", 40))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewService(&infra.Config{PasswordBreachedList: tt.path})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package policy
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+)
+
+type Service struct {
+	minLength   int
+	maxLength   int
+	minClasses  int
+	minEntropy  float64
+	bannedWords []string
+	breached    map[[sha1.Size]byte]struct{}
+}
+
+// NewService - создать новый экземпляр парольной политики, список утёкших паролей читается при старте
+func NewService(cfg *infra.Config) (*Service, error) {
+	breached, err := loadBreached(cfg.PasswordBreachedList)
+	if err != nil {
+		return nil, err
+	}
+
+	bannedWords := make([]string, 0, len(cfg.PasswordBannedWords))
+	for _, word := range cfg.PasswordBannedWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			bannedWords = append(bannedWords, word)
+		}
+	}
+
+	return &Service{
+		minLength:   cfg.PasswordMinLength,
+		maxLength:   cfg.PasswordMaxLength,
+		minClasses:  cfg.PasswordMinCharClasses,
+		minEntropy:  cfg.PasswordMinEntropy,
+		bannedWords: bannedWords,
+		breached:    breached,
+	}, nil
+}
+
+// loadBreached - прочитать файл с SHA-1 хэшами утёкших паролей в формате "HASH[:COUNT]"
+func loadBreached(path string) (map[[sha1.Size]byte]struct{}, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breached password list: %w", err)
+	}
+	defer file.Close()
+
+	breached := make(map[[sha1.Size]byte]struct{})
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(line, ":")
+		var digest [sha1.Size]byte
+		if len(hash) != hex.EncodedLen(sha1.Size) {
+			return nil, fmt.Errorf("breached password list %s:%d: invalid SHA-1 hash", path, lineNumber)
+		}
+		if _, err = hex.Decode(digest[:], []byte(hash)); err != nil {
+			return nil, fmt.Errorf("breached password list %s:%d: %w", path, lineNumber, err)
+		}
+		breached[digest] = struct{}{}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read breached password list: %w", err)
+	}
+
+	return breached, nil
+}
@@ -2,16 +2,79 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
 )
 
 // AuthService defines auth service interface
 type AuthService interface {
-	VerifyToken(authHeader string) (string, error)
+	VerifyToken(ctx context.Context, authHeader string) (string, error)
+	Authenticate(ctx context.Context, authHeader string) (model.Principal, error)
 	VerifyPassword(user queries.User, password string) error
-	GenerateToken(userID string) (string, error)
-	Login(ctx context.Context, email, password string) (string, error)
+	GenerateToken(userID string, roles ...string) (string, error)
+	PublicKeys() []model.JWK
+	Login(ctx context.Context, email, password, ip string) (model.LoginResult, error)
+	LoginTOTP(ctx context.Context, challengeToken, code string) (model.TokenPair, error)
+	CompleteLogin(ctx context.Context, userID string) (model.LoginResult, error)
+	IssueTokens(ctx context.Context, userID string) (model.TokenPair, error)
+	IssueClientTokens(ctx context.Context, userID, clientID string, scopes []string) (model.TokenPair, error)
+	GenerateClientToken(clientID string, scopes []string) (model.TokenPair, error)
+	GenerateImpersonationToken(ctx context.Context, userID, actorID, actorSessionID string, ttl time.Duration) (model.Impersonation, error)
+	GenerateDownloadToken(userID, resource string, expiresAt time.Time) (string, error)
+	VerifyDownloadToken(ctx context.Context, token string) (string, string, error)
+	EnrollTOTP(ctx context.Context, userID string) (model.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error)
+	Refresh(ctx context.Context, refreshToken string) (model.TokenPair, error)
+	RefreshClient(ctx context.Context, refreshToken, clientID string) (model.TokenPair, error)
+	Logout(ctx context.Context, authHeader, refreshToken string) error
+	LogoutAll(ctx context.Context, userID string) error
+	Sessions(ctx context.Context, userID, currentID string) ([]model.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	SendVerificationEmail(ctx context.Context, userID string) error
+	ResendVerificationEmail(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
+	ChangePassword(ctx context.Context, userID, sessionID string, change model.PasswordChange) error
+	NotifyPasswordChanged(ctx context.Context, userID string) error
+	RequestEmailChange(ctx context.Context, userID, newEmail, password string) error
+	ConfirmEmailChange(ctx context.Context, token string) error
+	RevertEmailChange(ctx context.Context, token string) error
+	DeleteAccount(ctx context.Context, userID, password string) error
+	RestoreAccount(ctx context.Context, token string) error
+	PurgeDeletedAccounts(ctx context.Context) error
+	SendMagicLink(ctx context.Context, email string) (string, error)
+	LoginMagicLink(ctx context.Context, token, device string) (model.LoginResult, error)
+	PurgeExpiredTokens(ctx context.Context) error
+	UnlockAccount(ctx context.Context, userID string) error
+	PurgeLoginAttempts(ctx context.Context) error
+}
+
+// AuthProvider defines a way Login checks email and password.
+// utils.ErrInvalidUser means the provider does not know the user and the next one is asked.
+type AuthProvider interface {
+	Name() string
+	Authenticate(ctx context.Context, email, password string) (queries.User, error)
+}
+
+// DataExporter defines a section of the personal data export. Every module that stores
+// user data registers its own exporter, Export returns a JSON encodable value.
+type DataExporter interface {
+	Name() string
+	Export(ctx context.Context, userID string) (any, error)
+}
+
+// ExportService defines personal data export service interface
+type ExportService interface {
+	Request(ctx context.Context, userID string) (model.DataExport, error)
+	List(ctx context.Context, userID string) ([]model.DataExport, error)
+	Download(ctx context.Context, token string) (model.DataExportArchive, error)
+	ProcessPending(ctx context.Context) error
+	PurgeExpired(ctx context.Context) error
 }
 
 // UserService defines user service interface
@@ -19,4 +82,77 @@ type UserService interface {
 	Register(ctx context.Context, email, password string) (string, error)
 	GetByID(ctx context.Context, id string) (queries.User, error)
 	GetByEmail(ctx context.Context, email string) (queries.User, error)
+	UpdateProfile(ctx context.Context, id string, update model.ProfileUpdate) (queries.User, error)
+}
+
+// PolicyService defines password policy interface
+type PolicyService interface {
+	Check(password, email string) error
+}
+
+// HasherService defines password hashing interface
+type HasherService interface {
+	Hash(password string) (string, error)
+	Verify(password, encodedHash string) (bool, error)
+}
+
+// AccessService defines role based access control service interface
+type AccessService interface {
+	Authorize(ctx context.Context, roles []string, permissions ...string) error
+	CheckPermission(ctx context.Context, userID string, permissions ...string) error
+	GetUserRoles(ctx context.Context, userID string) ([]string, error)
+	AssignRole(ctx context.Context, userID, role string) error
+	RemoveRole(ctx context.Context, userID, role string) error
+	BootstrapAdmin(ctx context.Context, email string) error
+}
+
+// PasskeyService defines WebAuthn passkey service interface
+type PasskeyService interface {
+	BeginRegistration(ctx context.Context, userID string) (model.PasskeyCeremony, error)
+	FinishRegistration(ctx context.Context, userID, sessionID, name string, response []byte) (model.Passkey, error)
+	BeginLogin(ctx context.Context) (model.PasskeyCeremony, error)
+	FinishLogin(ctx context.Context, sessionID string, response []byte) (model.TokenPair, error)
+	List(ctx context.Context, userID string) ([]model.Passkey, error)
+	Delete(ctx context.Context, userID, id string) error
+	PurgeExpiredSessions(ctx context.Context) error
+}
+
+// APIKeyService defines API key service interface
+type APIKeyService interface {
+	Create(ctx context.Context, userID, name string, scopes []string, expiresAt time.Time) (model.APIKey, error)
+	List(ctx context.Context, userID string) ([]model.APIKey, error)
+	Revoke(ctx context.Context, userID, id string) error
+	Authenticate(ctx context.Context, key string) (model.Principal, error)
+	PurgeExpired(ctx context.Context) error
+}
+
+// OIDCService defines OpenID Connect login and account linking interface
+type OIDCService interface {
+	Providers() []string
+	BeginLogin(ctx context.Context, provider string) (model.OIDCAuthorization, error)
+	FinishLogin(ctx context.Context, state, code string) (model.LoginResult, error)
+	BeginLink(ctx context.Context, userID, provider string) (model.OIDCAuthorization, error)
+	FinishLink(ctx context.Context, userID, state, code string) (model.Identity, error)
+	List(ctx context.Context, userID string) ([]model.Identity, error)
+	Unlink(ctx context.Context, userID, provider string) error
+	PurgeExpiredStates(ctx context.Context) error
+}
+
+// OAuthService defines OAuth 2.0 authorization server interface
+type OAuthService interface {
+	CreateClient(ctx context.Context, principal model.Principal, client model.OAuthClient) (model.OAuthClient, error)
+	Clients(ctx context.Context) ([]model.OAuthClient, error)
+	DeleteClient(ctx context.Context, id string) error
+	Authorize(ctx context.Context, userID string, request model.OAuthAuthorizeRequest) (model.OAuthAuthorization, error)
+	Token(ctx context.Context, request model.OAuthTokenRequest) (model.OAuthToken, error)
+	Consents(ctx context.Context, userID string) ([]model.OAuthConsent, error)
+	RevokeConsent(ctx context.Context, userID, clientID string) error
+	PurgeExpiredCodes(ctx context.Context) error
+}
+
+// ImpersonationService defines admin impersonation service interface
+type ImpersonationService interface {
+	Start(ctx context.Context, actor model.Principal, userID, reason string) (model.Impersonation, error)
+	Record(ctx context.Context, event model.ImpersonationEvent) error
+	Events(ctx context.Context, userID string) ([]model.ImpersonationEvent, error)
 }
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+)
+
+// Exporter - раздел "profile" выгрузки персональных данных: почта и настройки профиля
+type Exporter struct {
+	service *Service
+}
+
+// NewExporter - выгрузить профиль пользователя, хеш пароля в выгрузку не попадает
+func NewExporter(service *Service) *Exporter {
+	return &Exporter{service: service}
+}
+
+func (e *Exporter) Name() string {
+	return "profile"
+}
+
+func (e *Exporter) Export(ctx context.Context, userID string) (any, error) {
+	user, err := e.service.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := model.ExportProfile{
+		ID:          user.ID,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		Locale:      user.Locale,
+		Timezone:    user.Timezone,
+	}
+	if user.EmailVerifiedAt.Valid {
+		profile.EmailVerifiedAt = &user.EmailVerifiedAt.Time
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,79 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const maxDisplayNameLength = 64
+
+// UpdateProfile - изменить отображаемое имя, локаль и часовой пояс пользователя.
+// Значения нормализуются: имя обрезается по краям, локаль и пояс приводятся к каноничному виду.
+func (s *Service) UpdateProfile(ctx context.Context, id string, update model.ProfileUpdate) (queries.User, error) {
+	var normalized model.ProfileUpdate
+	if update.DisplayName != nil {
+		displayName, err := normalizeDisplayName(*update.DisplayName)
+		if err != nil {
+			return queries.User{}, err
+		}
+		normalized.DisplayName = &displayName
+	}
+	if update.Locale != nil {
+		locale, err := normalizeLocale(*update.Locale)
+		if err != nil {
+			return queries.User{}, err
+		}
+		normalized.Locale = &locale
+	}
+	if update.Timezone != nil {
+		timezone, err := normalizeTimezone(*update.Timezone)
+		if err != nil {
+			return queries.User{}, err
+		}
+		normalized.Timezone = &timezone
+	}
+
+	// unset fields are kept by the query itself, concurrent updates of other fields are not overwritten
+	return s.repository.UpdateProfile(ctx, id, normalized)
+}
+
+func normalizeDisplayName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if !utf8.ValidString(name) || utf8.RuneCountInString(name) > maxDisplayNameLength {
+		return "", fmt.Errorf("%w: display name must be at most %d characters", utils.ErrInvalidProfile, maxDisplayNameLength)
+	}
+	if strings.ContainsFunc(name, unicode.IsControl) {
+		return "", fmt.Errorf("%w: display name contains control characters", utils.ErrInvalidProfile)
+	}
+	return name, nil
+}
+
+func normalizeLocale(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil || tag == language.Und {
+		return "", fmt.Errorf("%w: unknown locale", utils.ErrInvalidProfile)
+	}
+	return tag.String(), nil
+}
+
+func normalizeTimezone(timezone string) (string, error) {
+	// LoadLocation also accepts "" and "Local", neither means anything to a client
+	if timezone == "" || timezone == "Local" {
+		return "", fmt.Errorf("%w: unknown timezone", utils.ErrInvalidProfile)
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", fmt.Errorf("%w: unknown timezone", utils.ErrInvalidProfile)
+	}
+	return location.String(), nil
+}
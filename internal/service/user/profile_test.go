@@ -0,0 +1,112 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func (s *ServiceSuite) TestUpdateProfile() {
+	ctx := context.Background()
+
+	updated := queries.User{
+		ID:          "user-id",
+		Email:       "test@gmail.com",
+		DisplayName: "Jane Doe",
+		Locale:      "pt-BR",
+		Timezone:    "Europe/Amsterdam",
+	}
+
+	tests := []struct {
+		name          string
+		update        model.ProfileUpdate
+		expected      model.ProfileUpdate
+		updateError   error
+		expectedError error
+	}{
+		{
+			name: "all fields normalized",
+			update: model.ProfileUpdate{
+				DisplayName: ptr("  Jane Doe "),
+				Locale:      ptr("pt_br"),
+				Timezone:    ptr("Europe/Amsterdam"),
+			},
+			expected: model.ProfileUpdate{
+				DisplayName: ptr("Jane Doe"),
+				Locale:      ptr("pt-BR"),
+				Timezone:    ptr("Europe/Amsterdam"),
+			},
+		},
+		{
+			name:     "missing fields are kept",
+			update:   model.ProfileUpdate{Timezone: ptr("Asia/Tokyo")},
+			expected: model.ProfileUpdate{Timezone: ptr("Asia/Tokyo")},
+		},
+		{
+			name:     "display name can be cleared",
+			update:   model.ProfileUpdate{DisplayName: ptr("  ")},
+			expected: model.ProfileUpdate{DisplayName: ptr("")},
+		},
+		{
+			name:          "display name too long",
+			update:        model.ProfileUpdate{DisplayName: ptr(strings.Repeat("a", 65))},
+			expectedError: utils.ErrInvalidProfile,
+		},
+		{
+			name:          "display name with control characters",
+			update:        model.ProfileUpdate{DisplayName: ptr("Jane\nDoe")},
+			expectedError: utils.ErrInvalidProfile,
+		},
+		{
+			name:          "unknown locale",
+			update:        model.ProfileUpdate{Locale: ptr("not a locale")},
+			expectedError: utils.ErrInvalidProfile,
+		},
+		{
+			name:          "unknown timezone",
+			update:        model.ProfileUpdate{Timezone: ptr("Mars/Olympus")},
+			expectedError: utils.ErrInvalidProfile,
+		},
+		{
+			name:          "local timezone",
+			update:        model.ProfileUpdate{Timezone: ptr("Local")},
+			expectedError: utils.ErrInvalidProfile,
+		},
+		{
+			name:          "user not found",
+			update:        model.ProfileUpdate{Locale: ptr("de")},
+			expected:      model.ProfileUpdate{Locale: ptr("de")},
+			updateError:   pgx.ErrNoRows,
+			expectedError: pgx.ErrNoRows,
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			if test.expectedError == nil || test.updateError != nil {
+				s.userRepository.On("UpdateProfile", ctx, "user-id", test.expected).Return(updated, test.updateError).Once()
+			}
+
+			user, err := s.service.UpdateProfile(ctx, "user-id", test.update)
+
+			if test.expectedError != nil {
+				s.True(errors.Is(err, test.expectedError), err)
+			} else {
+				s.NoError(err)
+				s.Equal(updated, user)
+			}
+
+			s.userRepository.AssertExpectations(s.T())
+		})
+	}
+}
+
+func ptr(value string) *string {
+	return &value
+}
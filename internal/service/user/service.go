@@ -2,12 +2,17 @@ package user
 
 import (
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/repository"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/hasher"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/policy"
 )
 
 type Service struct {
-	repository repository.UserRepository
+	repository     repository.UserRepository
+	passwordPolicy service.PolicyService
+	passwordHasher service.HasherService
 }
 
-func NewService(repository repository.UserRepository) *Service {
-	return &Service{repository: repository}
+func NewService(repository repository.UserRepository, passwordPolicy *policy.Service, passwordHasher *hasher.Service) *Service {
+	return &Service{repository: repository, passwordPolicy: passwordPolicy, passwordHasher: passwordHasher}
 }
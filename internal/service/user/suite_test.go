@@ -5,7 +5,10 @@ import (
 
 	"github.com/stretchr/testify/suite"
 
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
 	repositoryMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/repository/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/hasher"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/policy"
 )
 
 type ServiceSuite struct {
@@ -16,7 +19,10 @@ type ServiceSuite struct {
 
 func (s *ServiceSuite) SetupTest() {
 	s.userRepository = repositoryMocks.NewMockUserRepository(s.T())
-	s.service = NewService(s.userRepository)
+
+	passwordPolicy, err := policy.NewService(&infra.Config{PasswordMinLength: 8, PasswordMinCharClasses: 3, PasswordMinEntropy: 50})
+	s.Require().NoError(err)
+	s.service = NewService(s.userRepository, passwordPolicy, hasher.NewService(&infra.Config{}))
 }
 
 func (s *ServiceSuite) TearDownTest() {
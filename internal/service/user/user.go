@@ -3,8 +3,8 @@ package user
 import (
 	"context"
 	"errors"
+	"net/mail"
 
-	"github.com/alexedwards/argon2id"
 	"github.com/jackc/pgx/v5"
 	"github.com/oklog/ulid/v2"
 
@@ -13,6 +13,14 @@ import (
 )
 
 func (s *Service) Register(ctx context.Context, email, password string) (string, error) {
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return "", utils.ErrInvalidEmail
+	}
+
+	if err := s.passwordPolicy.Check(password, email); err != nil {
+		return "", err
+	}
+
 	if _, err := s.repository.GetUserByEmail(ctx, email); err == nil {
 		return "", utils.ErrEmailAlreadySignup
 	} else if !errors.Is(err, pgx.ErrNoRows) {
@@ -22,7 +30,7 @@ func (s *Service) Register(ctx context.Context, email, password string) (string,
 
 	id := ulid.Make().String()
 
-	passwordHash, err := argon2id.CreateHash(password, argon2id.DefaultParams)
+	passwordHash, err := s.passwordHasher.Hash(password)
 	if err != nil {
 		return "", err
 	}
@@ -84,6 +84,22 @@ func (s *ServiceSuite) TestRegister() {
 			expectedError: errors.New("insert failed"),
 			checkID:       false,
 		},
+		{
+			name:          "invalid email",
+			email:         "adasdsadsa",
+			password:      "SecurePassword123",
+			mockSetup:     func() {},
+			expectedError: utils.ErrInvalidEmail,
+			checkID:       false,
+		},
+		{
+			name:          "weak password",
+			email:         "weak@gmail.com",
+			password:      "dsadsa",
+			mockSetup:     func() {},
+			expectedError: errors.New("password does not meet the policy: min_length, character_classes, entropy"),
+			checkID:       false,
+		},
 	}
 
 	for _, test := range tests {
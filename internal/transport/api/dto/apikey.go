@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+type APIKeyData struct {
+	Name      string    `json:"name" example:"CI deploy"`                  // Name shown in the key list
+	Scopes    []string  `json:"scopes" example:"users:read"`               // Scopes of the key, a subset of the user's permissions
+	ExpiresAt time.Time `json:"expires_at" example:"2026-06-01T00:00:00Z"` // Expiry time, at most API_KEY_MAX_TTL from now
+}
+
+type APIKey struct {
+	ID         string     `json:"id" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"`
+	Name       string     `json:"name" example:"CI deploy"`
+	Prefix     string     `json:"prefix" example:"wtk_Y2KQ6JWX"` // First characters of the key to recognize it
+	Scopes     []string   `json:"scopes" example:"users:read"`
+	ExpiresAt  time.Time  `json:"expires_at" example:"2026-06-01T00:00:00Z"`
+	CreatedAt  time.Time  `json:"created_at" example:"2025-12-14T12:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2025-12-15T08:30:00Z"`
+}
+
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key" example:"wtk_Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E"` // Secret, shown only once
+}
@@ -0,0 +1,5 @@
+package dto
+
+type CookieSession struct {
+	CSRFToken string `json:"csrf_token" example:"Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E"` // Send back in the X-CSRF-Token header of POST, PUT, PATCH and DELETE requests
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type OIDCAuthorization struct {
+	AuthorizationURL string `json:"authorization_url" example:"https://accounts.example.com/authorize?client_id=webTemplate&state=Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E"` // Provider page to redirect the user to
+	State            string `json:"state" example:"Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E"`                                                                                // Keep until the callback and compare with the returned state
+}
+
+type OIDCCallback struct {
+	State string `json:"state" example:"Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E"` // State from the provider redirect
+	Code  string `json:"code" example:"SplxlOBeZQQYbYS6WxSbIA"`      // Authorization code from the provider redirect
+}
+
+type Identity struct {
+	Provider    string     `json:"provider" example:"google"`
+	Email       string     `json:"email" example:"shad@tinkoff.ru"`
+	CreatedAt   time.Time  `json:"created_at" example:"2025-12-15T12:00:00Z"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty" example:"2025-12-16T08:30:00Z"`
+}
@@ -0,0 +1,78 @@
+package dto
+
+import "time"
+
+type OAuthAuthorizeRequest struct {
+	ResponseType        string `json:"response_type" example:"code"`                                         // Must be code
+	ClientID            string `json:"client_id" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"`                       // ID of a registered client
+	RedirectURI         string `json:"redirect_uri" example:"https://partner.example.com/callback"`          // Must match one of the client's redirect URIs
+	Scope               string `json:"scope" example:"users:read"`                                           // Space separated scopes, all client scopes when empty
+	State               string `json:"state" example:"af0ifjsldkj"`                                          // Opaque value returned to the client as is
+	CodeChallenge       string `json:"code_challenge" example:"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"` // PKCE challenge, BASE64URL(SHA256(code_verifier))
+	CodeChallengeMethod string `json:"code_challenge_method" example:"S256"`                                 // Must be S256
+	Consent             bool   `json:"consent" example:"true"`                                               // Whether the user approved the requested scopes
+}
+
+type OAuthAuthorization struct {
+	RedirectTo      string   `json:"redirect_to,omitempty" example:"https://partner.example.com/callback?code=Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E&state=af0ifjsldkj"` // Where to send the browser, empty while consent is required
+	ConsentRequired bool     `json:"consent_required" example:"false"`                                                                                       // Show the consent screen and repeat the request with consent
+	ClientName      string   `json:"client_name" example:"Partner CRM"`
+	Scopes          []string `json:"scopes" example:"users:read"`
+}
+
+type OAuthTokenRequest struct {
+	GrantType    string `form:"grant_type" example:"authorization_code"`        // authorization_code, client_credentials or refresh_token
+	ClientID     string `form:"client_id" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"` // Required for public clients and client_secret_post
+	ClientSecret string `form:"client_secret"`                                  // Secret for client_secret_post, prefer HTTP Basic
+	Code         string `form:"code" example:"Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E"`
+	RedirectURI  string `form:"redirect_uri" example:"https://partner.example.com/callback"`
+	CodeVerifier string `form:"code_verifier" example:"dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"`
+	RefreshToken string `form:"refresh_token" example:"Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E"`
+	Scope        string `form:"scope" example:"users:read"` // Only for client_credentials, all client scopes when empty
+}
+
+type OAuthToken struct {
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJFUzI1NiIsInR5cCI6IkpXVCJ9.eyJzdWIiOiIxMjM0NTY3ODkwIn0.signature"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int    `json:"expires_in" example:"3600"` // Access token lifetime in seconds
+	RefreshToken string `json:"refresh_token,omitempty" example:"Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E"`
+	Scope        string `json:"scope,omitempty" example:"users:read"` // Granted scopes, absent for unrestricted tokens
+}
+
+type OAuthError struct {
+	Error            string `json:"error" example:"invalid_grant"` // Error code from RFC 6749
+	ErrorDescription string `json:"error_description,omitempty" example:"invalid or expired code"`
+}
+
+type OAuthClientData struct {
+	Name         string   `json:"name" example:"Partner CRM"`                                   // Name shown on the consent screen
+	RedirectURIs []string `json:"redirect_uris" example:"https://partner.example.com/callback"` // Allowed redirect URIs, required for authorization_code
+	GrantTypes   []string `json:"grant_types" example:"authorization_code"`                     // authorization_code and/or client_credentials
+	Scopes       []string `json:"scopes" example:"users:read"`                                  // Scopes the client may request
+	Confidential bool     `json:"confidential" example:"true"`                                  // Client can keep a secret, public clients (SPA, mobile) rely on PKCE
+	FirstParty   bool     `json:"first_party" example:"false"`                                  // Own application, the consent screen is skipped, only a session may set it
+}
+
+type OAuthClient struct {
+	ID           string    `json:"id" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"`
+	Name         string    `json:"name" example:"Partner CRM"`
+	RedirectURIs []string  `json:"redirect_uris" example:"https://partner.example.com/callback"`
+	GrantTypes   []string  `json:"grant_types" example:"authorization_code"`
+	Scopes       []string  `json:"scopes" example:"users:read"`
+	Confidential bool      `json:"confidential" example:"true"`
+	FirstParty   bool      `json:"first_party" example:"false"`
+	CreatedAt    time.Time `json:"created_at" example:"2025-12-16T12:00:00Z"`
+}
+
+type CreatedOAuthClient struct {
+	OAuthClient
+	ClientSecret string `json:"client_secret,omitempty" example:"Y2KQ6JWXN7D3BFRQ5AZ4TLMH3EN7D3BFRQ5AZ4TLMH3EY2KQ6JWX"` // Secret of a confidential client, shown only once
+}
+
+type OAuthConsent struct {
+	ClientID   string    `json:"client_id" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"`
+	ClientName string    `json:"client_name" example:"Partner CRM"`
+	Scopes     []string  `json:"scopes" example:"users:read"`
+	CreatedAt  time.Time `json:"created_at" example:"2025-12-16T12:00:00Z"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2025-12-16T12:00:00Z"`
+}
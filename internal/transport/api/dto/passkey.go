@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type PasskeyCeremony struct {
+	SessionID string          `json:"session_id" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"` // Ceremony ID to send back on finish
+	Options   json.RawMessage `json:"options" swaggertype:"object"`                    // Options for navigator.credentials.create or get
+}
+
+type PasskeyRegistration struct {
+	SessionID  string          `json:"session_id" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"` // Ceremony ID returned by the begin step
+	Name       string          `json:"name" example:"MacBook Touch ID"`                 // Name shown in the passkey list
+	Credential json.RawMessage `json:"credential" swaggertype:"object"`                 // PublicKeyCredential from navigator.credentials.create
+}
+
+type PasskeyLogin struct {
+	SessionID  string          `json:"session_id" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"` // Ceremony ID returned by the begin step
+	Credential json.RawMessage `json:"credential" swaggertype:"object"`                 // PublicKeyCredential from navigator.credentials.get
+}
+
+type Passkey struct {
+	ID         string     `json:"id" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"`
+	Name       string     `json:"name" example:"MacBook Touch ID"`
+	CreatedAt  time.Time  `json:"created_at" example:"2025-12-10T12:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2025-12-11T08:30:00Z"`
+}
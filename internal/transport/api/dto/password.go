@@ -0,0 +1,12 @@
+package dto
+
+type ResetPasswordData struct {
+	Token    string `json:"token" example:"Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E"` // Token from the reset link
+	Password string `json:"password" example:"v3RyH@RdPa$$w0rd"`        // New password
+}
+
+type ChangePasswordData struct {
+	CurrentPassword     string `json:"current_password" example:"0ldPa$$w0rd!2000"` // Current password
+	NewPassword         string `json:"new_password" example:"v3RyH@RdPa$$w0rd"`     // New password
+	RevokeOtherSessions bool   `json:"revoke_other_sessions" example:"true"`        // Log out all other sessions and revoke all API keys
+}
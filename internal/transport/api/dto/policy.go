@@ -0,0 +1,12 @@
+package dto
+
+type PolicyViolation struct {
+	Rule    string `json:"rule" example:"min_length"`                                     // Machine readable rule name
+	Message string `json:"message" example:"password must be at least 8 characters long"` // Human readable explanation
+}
+
+type PasswordPolicyError struct {
+	Code       int               `json:"code" example:"400"`
+	Message    string            `json:"message" example:"password does not meet the policy"`
+	Violations []PolicyViolation `json:"violations"` // Every rule the password failed
+}
@@ -0,0 +1,17 @@
+package dto
+
+type Profile struct {
+	ID            string `json:"id" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"`
+	Email         string `json:"email" example:"user@example.com"`
+	EmailVerified bool   `json:"email_verified" example:"true"`
+	DisplayName   string `json:"display_name" example:"Jane Doe"`
+	Locale        string `json:"locale" example:"en-US"`              // BCP 47 language tag
+	Timezone      string `json:"timezone" example:"Europe/Amsterdam"` // IANA time zone name
+}
+
+// ProfileData - изменение профиля, отсутствующие поля не меняются
+type ProfileData struct {
+	DisplayName *string `json:"display_name,omitempty" example:"Jane Doe"`
+	Locale      *string `json:"locale,omitempty" example:"en-US"`
+	Timezone    *string `json:"timezone,omitempty" example:"Europe/Amsterdam"`
+}
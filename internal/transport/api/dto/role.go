@@ -0,0 +1,9 @@
+package dto
+
+type RoleData struct {
+	Role string `json:"role" example:"admin"` // Role name
+}
+
+type Roles struct {
+	Roles []string `json:"roles" example:"admin,user"` // Roles assigned to the user
+}
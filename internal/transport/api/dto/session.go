@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+type Session struct {
+	ID           string    `json:"id" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"`
+	ClientID     string    `json:"client_id,omitempty" example:"01JEX3N8Q3Z7Y5V6W4T2R1P0M9"` // OAuth client the login was made through
+	UserAgent    string    `json:"user_agent" example:"Mozilla/5.0 (X11; Linux x86_64; rv:133.0) Gecko/20100101 Firefox/133.0"`
+	IP           string    `json:"ip" example:"203.0.113.7"`
+	CreatedAt    time.Time `json:"created_at" example:"2025-12-17T12:00:00Z"`
+	LastActiveAt time.Time `json:"last_active_at" example:"2025-12-17T12:30:00Z"`
+	Current      bool      `json:"current" example:"true"` // Session of the token used for this request
+}
@@ -0,0 +1,137 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/apikey"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/middlewares"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+type APIKey struct {
+	apiKeyService service.APIKeyService
+	logger        *infra.Logger
+}
+
+// NewAPIKey - создать новый экземпляр обработчика
+func NewAPIKey(apiKeyService *apikey.Service, logger *infra.Logger, router *echo.Echo, authWare *middlewares.Auth) *APIKey {
+	result := &APIKey{
+		apiKeyService: apiKeyService,
+		logger:        logger,
+	}
+
+	// keys are managed only from an interactive session, a leaked key cannot mint new ones
+	keys := router.Group("/api/auth/v1/api-keys", authWare.Required, authWare.RejectImpersonation)
+	keys.GET("", result.list)
+	keys.POST("", result.create)
+	keys.DELETE("/:id", result.revoke)
+	return result
+}
+
+// create godoc
+// @Summary      Create API key
+// @Description  Выпустить API ключ для скриптов и CI. Ключ показывается один раз, передаётся как Bearer токен
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body      dto.APIKeyData  true  "Name, scopes and expiry"
+// @Success      201  {object}  dto.CreatedAPIKey
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/api-keys [post]
+func (h *APIKey) create(echoCtx echo.Context) error {
+	var data dto.APIKeyData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	key, err := h.apiKeyService.Create(echoCtx.Request().Context(), userID, data.Name, data.Scopes, data.ExpiresAt)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusCreated, dto.CreatedAPIKey{
+		APIKey: toAPIKey(key),
+		Key:    key.Key,
+	})
+}
+
+// list godoc
+// @Summary      List API keys
+// @Description  Неотозванные API ключи текущего пользователя, секреты не возвращаются
+// @Tags         api-keys
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {array}   dto.APIKey
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/api-keys [get]
+func (h *APIKey) list(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	keys, err := h.apiKeyService.List(echoCtx.Request().Context(), userID)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	result := make([]dto.APIKey, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, toAPIKey(key))
+	}
+	return echoCtx.JSON(http.StatusOK, result)
+}
+
+// revoke godoc
+// @Summary      Revoke API key
+// @Description  Отозвать API ключ текущего пользователя
+// @Tags         api-keys
+// @Security     Bearer
+// @Param        id   path      string  true  "API key ID"
+// @Success      204
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      404  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/api-keys/{id} [delete]
+func (h *APIKey) revoke(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if err = h.apiKeyService.Revoke(echoCtx.Request().Context(), userID, echoCtx.Param("id")); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+func toAPIKey(key model.APIKey) dto.APIKey {
+	return dto.APIKey{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+}
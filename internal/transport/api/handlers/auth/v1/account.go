@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// restoreAccount godoc
+// @Summary      Restore account
+// @Description  Отменить удаление аккаунта токеном из письма об удалении, пока не истёк срок восстановления.
+// @Description  Сессии не восстанавливаются, после восстановления нужно войти заново
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.RestoreAccountData  true  "Restore token"
+// @Success      204
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/account/restore [post]
+func (h *Auth) restoreAccount(echoCtx echo.Context) error {
+	var data dto.RestoreAccountData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	if err := h.authService.RestoreAccount(echoCtx.Request().Context(), data.Token); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
@@ -1,43 +1,82 @@
 package v1
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/auth"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/middlewares"
 	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
 )
 
 type Auth struct {
 	authService service.AuthService
+	cookies     *middlewares.Cookies
 	logger      *infra.Logger
 }
 
 // NewAuth - создать новый экземпляр обработчика
-func NewAuth(authService *auth.Service, logger *infra.Logger, router *echo.Echo) *Auth {
+func NewAuth(authService *auth.Service, cookies *middlewares.Cookies, logger *infra.Logger, router *echo.Echo, authWare *middlewares.Auth) *Auth {
 	result := &Auth{
 		authService: authService,
+		cookies:     cookies,
 		logger:      logger,
 	}
 
 	router.POST("/api/login", result.login)
+	router.POST("/api/auth/v1/login/totp", result.loginTOTP)
+	router.POST("/api/auth/v1/refresh", result.refresh)
+	router.POST("/api/auth/v1/logout", result.logout)
+	router.POST("/api/auth/v1/logout-all", result.logoutAll, authWare.Required, authWare.RejectImpersonation)
+	router.POST("/api/auth/v1/email/verify", result.verifyEmail)
+	router.POST("/api/auth/v1/email/resend", result.resendVerification)
+	router.POST("/api/auth/v1/email/change", result.changeEmail, authWare.Required, authWare.RejectImpersonation)
+	router.POST("/api/auth/v1/email/change/confirm", result.confirmEmailChange)
+	router.POST("/api/auth/v1/email/change/revert", result.revertEmailChange)
+	router.POST("/api/auth/v1/account/restore", result.restoreAccount)
+	router.POST("/api/auth/v1/password/forgot", result.forgotPassword)
+	router.POST("/api/auth/v1/password/reset", result.resetPassword)
+	router.POST("/api/auth/v1/password/change", result.changePassword, authWare.Required, authWare.RejectImpersonation)
+	router.POST("/api/auth/v1/magic-link", result.sendMagicLink)
+	router.POST("/api/auth/v1/magic-link/login", result.loginMagicLink)
+	router.GET("/.well-known/jwks.json", result.jwks)
+
+	totp := router.Group("/api/auth/v1/totp", authWare.Required, authWare.RejectImpersonation)
+	totp.POST("/enroll", result.enrollTOTP)
+	totp.POST("/confirm", result.confirmTOTP)
+	totp.POST("/disable", result.disableTOTP)
+	totp.POST("/recovery-codes", result.regenerateRecoveryCodes)
 	return result
 }
 
 // login godoc
 // @Summary      Login
-// @Description  Вход в аккаунт
+// @Description  Вход в аккаунт. При включённой 2FA возвращается 202 с challenge для /api/auth/v1/login/totp.
+// @Description  Если требуется подтверждение почты, до него возвращается 403.
+// @Description  Пароль проверяют провайдеры из AUTH_PROVIDERS, пользователь LDAP каталога создаётся при первом входе,
+// @Description  если его почта занята локальным аккаунтом - 409.
+// @Description  С mode=cookie токены записываются в HttpOnly cookie, а в ответе приходит dto.CookieSession
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        body body dto.AuthData  true  "Auth data"
+// @Param        mode query string false "cookie - keep the tokens in cookies" Enums(cookie)
 // @Success      200  {object}  dto.Token
+// @Success      202  {object}  dto.Challenge
 // @Failure      400  {object}  dto.ApiError
 // @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      429  {object}  dto.ApiError
+// @Header       429  {integer}  Retry-After  "Seconds until the lockout ends"
 // @Failure      500  {object}  dto.ApiError
 // @Router       /api/auth/v1/login [post]
 func (h *Auth) login(echoCtx echo.Context) error {
@@ -49,13 +88,179 @@ func (h *Auth) login(echoCtx echo.Context) error {
 	ctx := echoCtx.Request().Context()
 	h.logger.Info("login: " + data.Email)
 
-	token, err := h.authService.Login(ctx, data.Email, data.Password)
+	result, err := h.authService.Login(ctx, data.Email, data.Password, echoCtx.RealIP())
+	if err != nil {
+		return h.loginError(echoCtx, err)
+	}
+
+	return h.loginResult(echoCtx, result)
+}
+
+// loginResult - ответить парой токенов или, при включённой 2FA, challenge со статусом 202
+func (h *Auth) loginResult(echoCtx echo.Context, result model.LoginResult) error {
+	if result.ChallengeToken != "" {
+		return echoCtx.JSON(http.StatusAccepted, dto.Challenge{
+			ChallengeToken: result.ChallengeToken,
+		})
+	}
+
+	return h.cookies.Respond(echoCtx, result.Tokens)
+}
+
+// loginError - при блокировке входа или отправки ссылок добавить заголовок Retry-After
+func (h *Auth) loginError(echoCtx echo.Context, err error) error {
+	var lockout *utils.LockoutError
+	if errors.As(err, &lockout) {
+		seconds := int(math.Ceil(lockout.RetryAfter.Seconds()))
+		echoCtx.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+
+	return utils.Convert(err, h.logger)
+}
+
+// refresh godoc
+// @Summary      Refresh
+// @Description  Обновить пару токенов, refresh токен одноразовый.
+// @Description  С mode=cookie refresh токен берётся из cookie, запрос должен передать CSRF токен в X-CSRF-Token
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.RefreshData  false  "Refresh token"
+// @Param        mode query string false "cookie - keep the tokens in cookies" Enums(cookie)
+// @Success      200  {object}  dto.Token
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/refresh [post]
+func (h *Auth) refresh(echoCtx echo.Context) error {
+	var data dto.RefreshData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	if middlewares.CookieMode(echoCtx) {
+		refreshToken, err := middlewares.RefreshToken(echoCtx)
+		if err != nil {
+			return utils.Convert(err, h.logger)
+		}
+		data.RefreshToken = refreshToken
+	}
+
+	ctx := echoCtx.Request().Context()
+
+	tokens, err := h.authService.Refresh(ctx, data.RefreshToken)
+	if err != nil {
+		if errors.Is(err, utils.ErrRefreshTokenReused) {
+			h.logger.Warn("refresh token reuse detected, token family revoked")
+		}
+		return utils.Convert(err, h.logger)
+	}
+
+	return h.cookies.Respond(echoCtx, tokens)
+}
+
+// logout godoc
+// @Summary      Logout
+// @Description  Выйти: отозвать текущий access токен и переданный refresh токен.
+// @Description  С mode=cookie оба токена берутся из cookie, а cookie удаляются
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body dto.RefreshData  false  "Refresh token of this login"
+// @Param        mode query string false "cookie - the tokens are kept in cookies" Enums(cookie)
+// @Success      204
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/logout [post]
+func (h *Auth) logout(echoCtx echo.Context) error {
+	var data dto.RefreshData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	cookieMode := middlewares.CookieMode(echoCtx)
+	if cookieMode {
+		refreshToken, err := middlewares.RefreshToken(echoCtx)
+		if err != nil {
+			return utils.Convert(err, h.logger)
+		}
+		data.RefreshToken = refreshToken
+	}
+
+	authHeader, err := middlewares.Credentials(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	err = h.authService.Logout(echoCtx.Request().Context(), authHeader, data.RefreshToken)
+	// the browser forgets the session even if its tokens have already expired
+	if cookieMode {
+		h.cookies.Clear(echoCtx)
+	}
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// logoutAll godoc
+// @Summary      Logout everywhere
+// @Description  Выйти на всех устройствах: отозвать все токены пользователя, с mode=cookie также удалить cookie
+// @Tags         auth
+// @Produce      json
+// @Security     Bearer
+// @Param        mode query string false "cookie - the tokens are kept in cookies" Enums(cookie)
+// @Success      204
+// @Failure      401  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/logout-all [post]
+func (h *Auth) logoutAll(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
 	if err != nil {
 		return utils.Convert(err, h.logger)
 	}
 
-	tokenData := dto.Token{
-		Token: token,
+	if err = h.authService.LogoutAll(echoCtx.Request().Context(), userID); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if middlewares.CookieMode(echoCtx) {
+		h.cookies.Clear(echoCtx)
 	}
-	return echoCtx.JSON(http.StatusOK, tokenData)
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// jwks godoc
+// @Summary      JWKS
+// @Description  Публичные ключи для офлайн проверки токенов другими сервисами
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  dto.JWKS
+// @Router       /.well-known/jwks.json [get]
+func (h *Auth) jwks(echoCtx echo.Context) error {
+	publicKeys := h.authService.PublicKeys()
+
+	keys := make([]dto.JWK, 0, len(publicKeys))
+	for _, key := range publicKeys {
+		keys = append(keys, dto.JWK{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Alg: key.Alg,
+			Use: key.Use,
+			N:   key.N,
+			E:   key.E,
+			Crv: key.Crv,
+			X:   key.X,
+			Y:   key.Y,
+		})
+	}
+
+	// keys change only on rotation, let verifiers cache them for a while
+	echoCtx.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return echoCtx.JSON(http.StatusOK, dto.JWKS{Keys: keys})
 }
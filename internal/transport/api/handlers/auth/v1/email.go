@@ -0,0 +1,151 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/middlewares"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// verifyEmail godoc
+// @Summary      Verify email
+// @Description  Подтвердить почту токеном из письма, токен одноразовый
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.VerifyEmailData  true  "Verification token"
+// @Success      204
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/email/verify [post]
+func (h *Auth) verifyEmail(echoCtx echo.Context) error {
+	var data dto.VerifyEmailData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	if err := h.authService.VerifyEmail(echoCtx.Request().Context(), data.Token); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// resendVerification godoc
+// @Summary      Resend verification email
+// @Description  Повторно отправить письмо для подтверждения почты. Ответ не зависит от того, зарегистрирован ли адрес
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.EmailData  true  "Email"
+// @Success      204
+// @Failure      400  {object}  dto.ApiError
+// @Failure      429  {object}  dto.ApiError
+// @Header       429  {integer}  Retry-After  "Seconds until more emails can be sent to the address"
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/email/resend [post]
+func (h *Auth) resendVerification(echoCtx echo.Context) error {
+	var data dto.EmailData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	if err := h.authService.ResendVerificationEmail(echoCtx.Request().Context(), data.Email); err != nil {
+		return h.loginError(echoCtx, err)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// changeEmail godoc
+// @Summary      Change email
+// @Description  Начать смену почты, нужен текущий пароль. На новый адрес приходит ссылка для подтверждения,
+// @Description  на старый - ссылка для отмены. До подтверждения почта не меняется, новый адрес зарезервирован
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body dto.ChangeEmailData  true  "New email and current password"
+// @Success      204
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      429  {object}  dto.ApiError
+// @Header       429  {integer}  Retry-After  "Seconds until the lockout ends"
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/email/change [post]
+func (h *Auth) changeEmail(echoCtx echo.Context) error {
+	var data dto.ChangeEmailData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if err = h.authService.RequestEmailChange(echoCtx.Request().Context(), userID, data.NewEmail, data.Password); err != nil {
+		return h.loginError(echoCtx, err)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// confirmEmailChange godoc
+// @Summary      Confirm email change
+// @Description  Подтвердить новую почту токеном из письма, токен одноразовый. Новая почта сразу считается подтверждённой
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.EmailChangeTokenData  true  "Confirmation token"
+// @Success      204
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/email/change/confirm [post]
+func (h *Auth) confirmEmailChange(echoCtx echo.Context) error {
+	var data dto.EmailChangeTokenData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	if err := h.authService.ConfirmEmailChange(echoCtx.Request().Context(), data.Token); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// revertEmailChange godoc
+// @Summary      Revert email change
+// @Description  Вернуть прежнюю почту токеном из письма на старый адрес, до или после подтверждения новой.
+// @Description  Все сессии пользователя завершаются, ссылки сброса пароля перестают действовать
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.EmailChangeTokenData  true  "Revert token"
+// @Success      204
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/email/change/revert [post]
+func (h *Auth) revertEmailChange(echoCtx echo.Context) error {
+	var data dto.EmailChangeTokenData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	if err := h.authService.RevertEmailChange(echoCtx.Request().Context(), data.Token); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
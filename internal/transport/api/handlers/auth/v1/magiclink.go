@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+)
+
+// sendMagicLink godoc
+// @Summary      Send login link
+// @Description  Отправить на почту одноразовую ссылку для входа без пароля. Ответ не зависит от того, зарегистрирован ли адрес.
+// @Description  Возвращённый секрет устройства нужно сохранить: ссылка принимается только вместе с ним
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.EmailData  true  "Email"
+// @Success      200  {object}  dto.MagicLinkSent
+// @Failure      400  {object}  dto.ApiError
+// @Failure      429  {object}  dto.ApiError
+// @Header       429  {integer}  Retry-After  "Seconds until more links can be sent to the address"
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/magic-link [post]
+func (h *Auth) sendMagicLink(echoCtx echo.Context) error {
+	var data dto.EmailData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	device, err := h.authService.SendMagicLink(echoCtx.Request().Context(), data.Email)
+	if err != nil {
+		return h.loginError(echoCtx, err)
+	}
+
+	return echoCtx.JSON(http.StatusOK, dto.MagicLinkSent{
+		Device: device,
+	})
+}
+
+// loginMagicLink godoc
+// @Summary      Login with link
+// @Description  Войти по ссылке из письма с устройства, на котором она запрошена. Ссылка одноразовая и подтверждает почту.
+// @Description  При включённой 2FA возвращается 202 с challenge для /api/auth/v1/login/totp
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.MagicLinkData  true  "Link token and device secret"
+// @Param        mode query string false "cookie - keep the tokens in cookies" Enums(cookie)
+// @Success      200  {object}  dto.Token
+// @Success      202  {object}  dto.Challenge
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/magic-link/login [post]
+func (h *Auth) loginMagicLink(echoCtx echo.Context) error {
+	var data dto.MagicLinkData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	result, err := h.authService.LoginMagicLink(echoCtx.Request().Context(), data.Token, data.Device)
+	if err != nil {
+		return h.loginError(echoCtx, err)
+	}
+
+	return h.loginResult(echoCtx, result)
+}
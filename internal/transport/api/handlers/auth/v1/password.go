@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/middlewares"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// forgotPassword godoc
+// @Summary      Forgot password
+// @Description  Отправить ссылку для сброса пароля. Ответ не зависит от того, зарегистрирован ли адрес
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.EmailData  true  "Email"
+// @Success      204
+// @Failure      400  {object}  dto.ApiError
+// @Failure      429  {object}  dto.ApiError
+// @Header       429  {integer}  Retry-After  "Seconds until more emails can be sent to the address"
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/password/forgot [post]
+func (h *Auth) forgotPassword(echoCtx echo.Context) error {
+	var data dto.EmailData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	if err := h.authService.ForgotPassword(echoCtx.Request().Context(), data.Email); err != nil {
+		return h.loginError(echoCtx, err)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// resetPassword godoc
+// @Summary      Reset password
+// @Description  Установить новый пароль по токену из письма, все сессии пользователя завершаются
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.ResetPasswordData  true  "Reset token and new password"
+// @Success      204
+// @Failure      400  {object}  dto.PasswordPolicyError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/password/reset [post]
+func (h *Auth) resetPassword(echoCtx echo.Context) error {
+	var data dto.ResetPasswordData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	if err := h.authService.ResetPassword(echoCtx.Request().Context(), data.Token, data.Password); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// changePassword godoc
+// @Summary      Change password
+// @Description  Сменить пароль, указав текущий. Неверный текущий пароль учитывается в блокировке аккаунта.
+// @Description  С revoke_other_sessions завершаются все сессии, кроме текущей, и отзываются API ключи, токены имперсонации действуют до истечения.
+// @Description  О смене пароля приходит письмо
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body dto.ChangePasswordData  true  "Current and new password"
+// @Success      204
+// @Failure      400  {object}  dto.PasswordPolicyError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      429  {object}  dto.ApiError
+// @Header       429  {integer}  Retry-After  "Seconds until the lockout ends"
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/password/change [post]
+func (h *Auth) changePassword(echoCtx echo.Context) error {
+	var data dto.ChangePasswordData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	principal, err := middlewares.Principal(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	ctx := echoCtx.Request().Context()
+	if err = h.authService.ChangePassword(ctx, principal.UserID, principal.SessionID, model.PasswordChange{
+		CurrentPassword:     data.CurrentPassword,
+		NewPassword:         data.NewPassword,
+		RevokeOtherSessions: data.RevokeOtherSessions,
+	}); err != nil {
+		return h.loginError(echoCtx, err)
+	}
+
+	// the password is already changed, a lost notification must not fail the request
+	if err = h.authService.NotifyPasswordChanged(ctx, principal.UserID); err != nil {
+		h.logger.Warnw("password change notification not sent", zap.String("user_id", principal.UserID), zap.Error(err))
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/middlewares"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+// loginTOTP godoc
+// @Summary      Login with TOTP
+// @Description  Второй шаг входа: обменять challenge и код из приложения или код восстановления на пару токенов
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.TOTPLoginData  true  "Challenge and code"
+// @Param        mode query string false "cookie - keep the tokens in cookies" Enums(cookie)
+// @Success      200  {object}  dto.Token
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      429  {object}  dto.ApiError
+// @Header       429  {integer}  Retry-After  "Seconds until the lockout ends"
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/login/totp [post]
+func (h *Auth) loginTOTP(echoCtx echo.Context) error {
+	var data dto.TOTPLoginData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	ctx := echoCtx.Request().Context()
+
+	tokens, err := h.authService.LoginTOTP(ctx, data.ChallengeToken, data.Code)
+	if err != nil {
+		return h.loginError(echoCtx, err)
+	}
+
+	return h.cookies.Respond(echoCtx, tokens)
+}
+
+// enrollTOTP godoc
+// @Summary      Enroll TOTP
+// @Description  Сгенерировать TOTP секрет, 2FA включится после подтверждения кодом
+// @Tags         2fa
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  dto.TOTPEnrollment
+// @Failure      401  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/totp/enroll [post]
+func (h *Auth) enrollTOTP(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	enrollment, err := h.authService.EnrollTOTP(echoCtx.Request().Context(), userID)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusOK, dto.TOTPEnrollment{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(enrollment.QRCode),
+	})
+}
+
+// confirmTOTP godoc
+// @Summary      Confirm TOTP
+// @Description  Включить 2FA первым кодом из приложения, в ответе одноразовые коды восстановления
+// @Tags         2fa
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body dto.TOTPCode  true  "TOTP code"
+// @Success      200  {object}  dto.RecoveryCodes
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/totp/confirm [post]
+func (h *Auth) confirmTOTP(echoCtx echo.Context) error {
+	var data dto.TOTPCode
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	codes, err := h.authService.ConfirmTOTP(echoCtx.Request().Context(), userID, data.Code)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusOK, dto.RecoveryCodes{RecoveryCodes: codes})
+}
+
+// disableTOTP godoc
+// @Summary      Disable TOTP
+// @Description  Выключить 2FA, требуется код из приложения или код восстановления
+// @Tags         2fa
+// @Accept       json
+// @Security     Bearer
+// @Param        body body dto.TOTPCode  true  "TOTP code or recovery code"
+// @Success      204
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/totp/disable [post]
+func (h *Auth) disableTOTP(echoCtx echo.Context) error {
+	var data dto.TOTPCode
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if err = h.authService.DisableTOTP(echoCtx.Request().Context(), userID, data.Code); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// regenerateRecoveryCodes godoc
+// @Summary      Regenerate recovery codes
+// @Description  Заменить коды восстановления новыми, требуется код из приложения или код восстановления
+// @Tags         2fa
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body dto.TOTPCode  true  "TOTP code or recovery code"
+// @Success      200  {object}  dto.RecoveryCodes
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/totp/recovery-codes [post]
+func (h *Auth) regenerateRecoveryCodes(echoCtx echo.Context) error {
+	var data dto.TOTPCode
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	codes, err := h.authService.RegenerateRecoveryCodes(echoCtx.Request().Context(), userID, data.Code)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusOK, dto.RecoveryCodes{RecoveryCodes: codes})
+}
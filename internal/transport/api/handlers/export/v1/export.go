@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/export"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/middlewares"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+type Export struct {
+	exportService service.ExportService
+	logger        *infra.Logger
+}
+
+// NewExport - создать новый экземпляр обработчика
+func NewExport(exportService *export.Service, logger *infra.Logger, router *echo.Echo, authWare *middlewares.Auth) *Export {
+	result := &Export{
+		exportService: exportService,
+		logger:        logger,
+	}
+
+	// the list hands out download links, an administrator acting as the user must not get the archive
+	exports := router.Group("/api/user/v1/me/export", authWare.Required, authWare.RejectImpersonation)
+	exports.GET("", result.list)
+	exports.POST("", result.request)
+
+	// the signed token replaces the session, so the link from the email works in any browser
+	router.GET("/api/user/v1/export/download", result.download)
+	return result
+}
+
+// request godoc
+// @Summary      Request personal data export
+// @Description  Поставить в очередь выгрузку всех персональных данных: профиль, сессии, журналы, привязанные аккаунты.
+// @Description  Архив собирается в фоне, когда он готов, на почту приходит ссылка на скачивание
+// @Tags         users
+// @Produce      json
+// @Security     Bearer
+// @Success      202  {object}  dto.DataExport
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/me/export [post]
+func (h *Export) request(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	dataExport, err := h.exportService.Request(echoCtx.Request().Context(), userID)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusAccepted, toDataExport(dataExport))
+}
+
+// list godoc
+// @Summary      List personal data exports
+// @Description  Последние выгрузки текущего пользователя, у готовых есть токен для скачивания архива
+// @Tags         users
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {array}   dto.DataExport
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/me/export [get]
+func (h *Export) list(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	exports, err := h.exportService.List(echoCtx.Request().Context(), userID)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	result := make([]dto.DataExport, 0, len(exports))
+	for _, dataExport := range exports {
+		result = append(result, toDataExport(dataExport))
+	}
+	return echoCtx.JSON(http.StatusOK, result)
+}
+
+// download godoc
+// @Summary      Download personal data export
+// @Description  ZIP архив с JSON файлом на каждый раздел персональных данных. Ссылка подписана и действует,
+// @Description  пока архив не удалён, выход на всех устройствах и смена пароля её отзывают
+// @Tags         users
+// @Produce      application/zip
+// @Param        token  query     string  true  "Download token from the email or the export list"
+// @Success      200  {file}    file
+// @Failure      401  {object}  dto.ApiError
+// @Failure      404  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/export/download [get]
+func (h *Export) download(echoCtx echo.Context) error {
+	archive, err := h.exportService.Download(echoCtx.Request().Context(), echoCtx.QueryParam("token"))
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	echoCtx.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename="+strconv.Quote(archive.Name))
+	echoCtx.Response().Header().Set("Cache-Control", "no-store")
+	return echoCtx.Blob(http.StatusOK, "application/zip", archive.Content)
+}
+
+func toDataExport(dataExport model.DataExport) dto.DataExport {
+	return dto.DataExport{
+		ID:            dataExport.ID,
+		Status:        dataExport.Status,
+		Error:         dataExport.Error,
+		CreatedAt:     dataExport.CreatedAt,
+		CompletedAt:   dataExport.CompletedAt,
+		ExpiresAt:     dataExport.ExpiresAt,
+		DownloadToken: dataExport.DownloadToken,
+	}
+}
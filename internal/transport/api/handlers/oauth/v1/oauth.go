@@ -0,0 +1,310 @@
+package v1
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/oauth"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/middlewares"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+type OAuth struct {
+	oauthService service.OAuthService
+	logger       *infra.Logger
+}
+
+// NewOAuth - создать новый экземпляр обработчика
+func NewOAuth(oauthService *oauth.Service, logger *infra.Logger, router *echo.Echo, authWare *middlewares.Auth) *OAuth {
+	result := &OAuth{
+		oauthService: oauthService,
+		logger:       logger,
+	}
+
+	router.POST("/api/oauth/v1/token", result.token)
+	// the frontend authorization page logs the user in, shows the consent screen and calls this endpoint
+	router.POST("/api/oauth/v1/authorize", result.authorize, authWare.Required, authWare.RejectImpersonation)
+
+	consents := router.Group("/api/oauth/v1/consents", authWare.Required)
+	consents.GET("", result.consents)
+	consents.DELETE("/:client_id", result.revokeConsent, authWare.RejectImpersonation)
+
+	clients := router.Group("/api/oauth/v1/clients", authWare.RequireScope(), authWare.RequirePermission(model.PermissionClientsManage))
+	clients.GET("", result.clients)
+	clients.POST("", result.createClient)
+	clients.DELETE("/:id", result.deleteClient)
+	return result
+}
+
+// authorize godoc
+// @Summary      OAuth authorization request
+// @Description  Запрос авторизации OAuth 2.0 (authorization code + PKCE S256) от имени вошедшего пользователя.
+// @Description  Стороннему клиенту без согласия возвращается consent_required: после подтверждения запрос повторяется с consent.
+// @Description  Иначе в redirect_to адрес клиента с code и state, на который нужно перенаправить браузер
+// @Tags         oauth
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body      dto.OAuthAuthorizeRequest  true  "Authorization request parameters"
+// @Success      200  {object}  dto.OAuthAuthorization
+// @Failure      400  {object}  dto.OAuthError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/oauth/v1/authorize [post]
+func (h *OAuth) authorize(echoCtx echo.Context) error {
+	var data dto.OAuthAuthorizeRequest
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	authorization, err := h.oauthService.Authorize(echoCtx.Request().Context(), userID, model.OAuthAuthorizeRequest{
+		ResponseType:        data.ResponseType,
+		ClientID:            data.ClientID,
+		RedirectURI:         data.RedirectURI,
+		Scope:               data.Scope,
+		State:               data.State,
+		CodeChallenge:       data.CodeChallenge,
+		CodeChallengeMethod: data.CodeChallengeMethod,
+		Consent:             data.Consent,
+	})
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusOK, dto.OAuthAuthorization{
+		RedirectTo:      authorization.RedirectTo,
+		ConsentRequired: authorization.ConsentRequired,
+		ClientName:      authorization.ClientName,
+		Scopes:          authorization.Scopes,
+	})
+}
+
+// token godoc
+// @Summary      OAuth token endpoint
+// @Description  Token endpoint OAuth 2.0: authorization_code с code_verifier, client_credentials и refresh_token.
+// @Description  Конфиденциальный клиент аутентифицируется через HTTP Basic или client_secret в форме.
+// @Description  Выдаются те же JWT, что и при входе, токены с scope ограничены ими как API ключи
+// @Tags         oauth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        body formData  dto.OAuthTokenRequest  true  "Token request parameters"
+// @Success      200  {object}  dto.OAuthToken
+// @Failure      400  {object}  dto.OAuthError
+// @Failure      401  {object}  dto.OAuthError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/oauth/v1/token [post]
+func (h *OAuth) token(echoCtx echo.Context) error {
+	// token responses must never be cached (RFC 6749, section 5.1)
+	echoCtx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	echoCtx.Response().Header().Set("Pragma", "no-cache")
+
+	var data dto.OAuthTokenRequest
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	if clientID, clientSecret, ok := echoCtx.Request().BasicAuth(); ok {
+		// client_secret_basic credentials are form-encoded before base64 (RFC 6749, section 2.3.1)
+		decodedID, idErr := url.QueryUnescape(clientID)
+		decodedSecret, secretErr := url.QueryUnescape(clientSecret)
+		if idErr != nil || secretErr != nil || (data.ClientID != "" && data.ClientID != decodedID) {
+			return utils.Convert(&utils.OAuthError{Code: utils.OAuthInvalidClient, Description: "malformed client credentials"}, h.logger)
+		}
+		data.ClientID, data.ClientSecret = decodedID, decodedSecret
+	}
+
+	token, err := h.oauthService.Token(echoCtx.Request().Context(), model.OAuthTokenRequest{
+		GrantType:    data.GrantType,
+		ClientID:     data.ClientID,
+		ClientSecret: data.ClientSecret,
+		Code:         data.Code,
+		RedirectURI:  data.RedirectURI,
+		CodeVerifier: data.CodeVerifier,
+		RefreshToken: data.RefreshToken,
+		Scope:        data.Scope,
+	})
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusOK, dto.OAuthToken{
+		AccessToken:  token.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(token.ExpiresIn.Seconds()),
+		RefreshToken: token.RefreshToken,
+		Scope:        strings.Join(token.Scopes, " "),
+	})
+}
+
+// consents godoc
+// @Summary      List OAuth consents
+// @Description  Сторонние приложения, которым текущий пользователь разрешил доступ к аккаунту
+// @Tags         oauth
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {array}   dto.OAuthConsent
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/oauth/v1/consents [get]
+func (h *OAuth) consents(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	consents, err := h.oauthService.Consents(echoCtx.Request().Context(), userID)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	result := make([]dto.OAuthConsent, 0, len(consents))
+	for _, consent := range consents {
+		result = append(result, dto.OAuthConsent{
+			ClientID:   consent.ClientID,
+			ClientName: consent.ClientName,
+			Scopes:     consent.Scopes,
+			CreatedAt:  consent.CreatedAt,
+			UpdatedAt:  consent.UpdatedAt,
+		})
+	}
+	return echoCtx.JSON(http.StatusOK, result)
+}
+
+// revokeConsent godoc
+// @Summary      Revoke OAuth consent
+// @Description  Отозвать доступ приложения: согласие и refresh токены удаляются, при следующем входе согласие спросят заново
+// @Tags         oauth
+// @Security     Bearer
+// @Param        client_id  path      string  true  "Client ID"
+// @Success      204
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      404  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/oauth/v1/consents/{client_id} [delete]
+func (h *OAuth) revokeConsent(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if err = h.oauthService.RevokeConsent(echoCtx.Request().Context(), userID, echoCtx.Param("client_id")); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// createClient godoc
+// @Summary      Register OAuth client
+// @Description  Зарегистрировать клиента OAuth, требуется право clients:manage. Scope клиента не шире прав вызывающего, first-party клиента регистрирует только сессия, не API ключ и не токен OAuth. Секрет конфиденциального клиента показывается один раз
+// @Tags         oauth
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body      dto.OAuthClientData  true  "Client settings"
+// @Success      201  {object}  dto.CreatedOAuthClient
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/oauth/v1/clients [post]
+func (h *OAuth) createClient(echoCtx echo.Context) error {
+	var data dto.OAuthClientData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	principal, err := middlewares.Principal(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	client, err := h.oauthService.CreateClient(echoCtx.Request().Context(), principal, model.OAuthClient{
+		Name:         data.Name,
+		RedirectURIs: data.RedirectURIs,
+		GrantTypes:   data.GrantTypes,
+		Scopes:       data.Scopes,
+		Confidential: data.Confidential,
+		FirstParty:   data.FirstParty,
+	})
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusCreated, dto.CreatedOAuthClient{
+		OAuthClient:  toOAuthClient(client),
+		ClientSecret: client.Secret,
+	})
+}
+
+// clients godoc
+// @Summary      List OAuth clients
+// @Description  Зарегистрированные клиенты OAuth, требуется право clients:manage. Секреты не возвращаются
+// @Tags         oauth
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {array}   dto.OAuthClient
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/oauth/v1/clients [get]
+func (h *OAuth) clients(echoCtx echo.Context) error {
+	clients, err := h.oauthService.Clients(echoCtx.Request().Context())
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	result := make([]dto.OAuthClient, 0, len(clients))
+	for _, client := range clients {
+		result = append(result, toOAuthClient(client))
+	}
+	return echoCtx.JSON(http.StatusOK, result)
+}
+
+// deleteClient godoc
+// @Summary      Delete OAuth client
+// @Description  Удалить клиента OAuth вместе с согласиями и refresh токенами, требуется право clients:manage
+// @Tags         oauth
+// @Security     Bearer
+// @Param        id   path      string  true  "Client ID"
+// @Success      204
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      404  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/oauth/v1/clients/{id} [delete]
+func (h *OAuth) deleteClient(echoCtx echo.Context) error {
+	if err := h.oauthService.DeleteClient(echoCtx.Request().Context(), echoCtx.Param("id")); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+func toOAuthClient(client model.OAuthClient) dto.OAuthClient {
+	return dto.OAuthClient{
+		ID:           client.ID,
+		Name:         client.Name,
+		RedirectURIs: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Scopes:       client.Scopes,
+		Confidential: client.Confidential,
+		FirstParty:   client.FirstParty,
+		CreatedAt:    client.CreatedAt,
+	}
+}
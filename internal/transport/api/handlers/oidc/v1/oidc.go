@@ -0,0 +1,239 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/oidc"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/middlewares"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+type OIDC struct {
+	oidcService service.OIDCService
+	cookies     *middlewares.Cookies
+	logger      *infra.Logger
+}
+
+// NewOIDC - создать новый экземпляр обработчика
+func NewOIDC(oidcService *oidc.Service, cookies *middlewares.Cookies, logger *infra.Logger, router *echo.Echo, authWare *middlewares.Auth) *OIDC {
+	result := &OIDC{
+		oidcService: oidcService,
+		cookies:     cookies,
+		logger:      logger,
+	}
+
+	router.GET("/api/auth/v1/oidc/providers", result.providers)
+	router.POST("/api/auth/v1/oidc/:provider/begin", result.beginLogin)
+	router.POST("/api/auth/v1/oidc/callback", result.finishLogin)
+
+	identities := router.Group("/api/auth/v1/identities", authWare.Required, authWare.RejectImpersonation)
+	identities.GET("", result.list)
+	identities.POST("/:provider/begin", result.beginLink)
+	identities.POST("/callback", result.finishLink)
+	identities.DELETE("/:provider", result.unlink)
+	return result
+}
+
+// providers godoc
+// @Summary      List identity providers
+// @Description  Имена провайдеров, через которых можно войти
+// @Tags         oidc
+// @Produce      json
+// @Success      200  {array}   string
+// @Router       /api/auth/v1/oidc/providers [get]
+func (h *OIDC) providers(echoCtx echo.Context) error {
+	return echoCtx.JSON(http.StatusOK, h.oidcService.Providers())
+}
+
+// beginLogin godoc
+// @Summary      Begin provider login
+// @Description  Начать вход через OpenID Connect провайдера (authorization code + PKCE).
+// @Description  Клиент сохраняет state и переходит по authorization_url, провайдер вернёт пользователя на OIDC_REDIRECT_URL
+// @Tags         oidc
+// @Produce      json
+// @Param        provider  path      string  true  "Provider name"
+// @Success      200  {object}  dto.OIDCAuthorization
+// @Failure      404  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/oidc/{provider}/begin [post]
+func (h *OIDC) beginLogin(echoCtx echo.Context) error {
+	authorization, err := h.oidcService.BeginLogin(echoCtx.Request().Context(), echoCtx.Param("provider"))
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusOK, toAuthorization(authorization))
+}
+
+// finishLogin godoc
+// @Summary      Finish provider login
+// @Description  Обменять code от провайдера на пару токенов. Новый аккаунт провайдера с подтверждённой почтой регистрирует пользователя.
+// @Description  Если почта уже занята, возвращается 409: нужно войти паролем и привязать провайдера. При включённой 2FA возвращается 202 с challenge
+// @Tags         oidc
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.OIDCCallback  true  "Redirect parameters"
+// @Param        mode query string false "cookie - keep the tokens in cookies" Enums(cookie)
+// @Success      200  {object}  dto.Token
+// @Success      202  {object}  dto.Challenge
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/oidc/callback [post]
+func (h *OIDC) finishLogin(echoCtx echo.Context) error {
+	var data dto.OIDCCallback
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	result, err := h.oidcService.FinishLogin(echoCtx.Request().Context(), data.State, data.Code)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if result.ChallengeToken != "" {
+		return echoCtx.JSON(http.StatusAccepted, dto.Challenge{
+			ChallengeToken: result.ChallengeToken,
+		})
+	}
+
+	return h.cookies.Respond(echoCtx, result.Tokens)
+}
+
+// list godoc
+// @Summary      List linked identities
+// @Description  Аккаунты провайдеров, привязанные к текущему пользователю
+// @Tags         oidc
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {array}   dto.Identity
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/identities [get]
+func (h *OIDC) list(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	identities, err := h.oidcService.List(echoCtx.Request().Context(), userID)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	result := make([]dto.Identity, 0, len(identities))
+	for _, identity := range identities {
+		result = append(result, toIdentity(identity))
+	}
+	return echoCtx.JSON(http.StatusOK, result)
+}
+
+// beginLink godoc
+// @Summary      Begin identity linking
+// @Description  Начать привязку аккаунта провайдера к текущему пользователю, дальше как при входе
+// @Tags         oidc
+// @Produce      json
+// @Security     Bearer
+// @Param        provider  path      string  true  "Provider name"
+// @Success      200  {object}  dto.OIDCAuthorization
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      404  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/identities/{provider}/begin [post]
+func (h *OIDC) beginLink(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	authorization, err := h.oidcService.BeginLink(echoCtx.Request().Context(), userID, echoCtx.Param("provider"))
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusOK, toAuthorization(authorization))
+}
+
+// finishLink godoc
+// @Summary      Finish identity linking
+// @Description  Привязать аккаунт провайдера по code. Аккаунт, уже привязанный к кому-то, даёт 409
+// @Tags         oidc
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body dto.OIDCCallback  true  "Redirect parameters"
+// @Success      201  {object}  dto.Identity
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/identities/callback [post]
+func (h *OIDC) finishLink(echoCtx echo.Context) error {
+	var data dto.OIDCCallback
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	identity, err := h.oidcService.FinishLink(echoCtx.Request().Context(), userID, data.State, data.Code)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusCreated, toIdentity(identity))
+}
+
+// unlink godoc
+// @Summary      Unlink identity
+// @Description  Отвязать аккаунт провайдера. Последний способ входа пользователя без пароля отвязать нельзя
+// @Tags         oidc
+// @Security     Bearer
+// @Param        provider  path      string  true  "Provider name"
+// @Success      204
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      404  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/identities/{provider} [delete]
+func (h *OIDC) unlink(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if err = h.oidcService.Unlink(echoCtx.Request().Context(), userID, echoCtx.Param("provider")); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+func toAuthorization(authorization model.OIDCAuthorization) dto.OIDCAuthorization {
+	return dto.OIDCAuthorization{
+		AuthorizationURL: authorization.URL,
+		State:            authorization.State,
+	}
+}
+
+func toIdentity(identity model.Identity) dto.Identity {
+	return dto.Identity{
+		Provider:    identity.Provider,
+		Email:       identity.Email,
+		CreatedAt:   identity.CreatedAt,
+		LastLoginAt: identity.LastLoginAt,
+	}
+}
@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/passkey"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/middlewares"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+type Passkey struct {
+	passkeyService service.PasskeyService
+	cookies        *middlewares.Cookies
+	logger         *infra.Logger
+}
+
+// NewPasskey - создать новый экземпляр обработчика
+func NewPasskey(passkeyService *passkey.Service, cookies *middlewares.Cookies, logger *infra.Logger, router *echo.Echo, authWare *middlewares.Auth) *Passkey {
+	result := &Passkey{
+		passkeyService: passkeyService,
+		cookies:        cookies,
+		logger:         logger,
+	}
+
+	router.POST("/api/auth/v1/passkeys/login/begin", result.beginLogin)
+	router.POST("/api/auth/v1/passkeys/login/finish", result.finishLogin)
+
+	passkeys := router.Group("/api/auth/v1/passkeys", authWare.Required, authWare.RejectImpersonation)
+	passkeys.GET("", result.list)
+	passkeys.POST("/register/begin", result.beginRegistration)
+	passkeys.POST("/register/finish", result.finishRegistration)
+	passkeys.DELETE("/:id", result.delete)
+	return result
+}
+
+// beginRegistration godoc
+// @Summary      Begin passkey registration
+// @Description  Начать регистрацию passkey, options передаются в navigator.credentials.create
+// @Tags         passkeys
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  dto.PasskeyCeremony
+// @Failure      401  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/passkeys/register/begin [post]
+func (h *Passkey) beginRegistration(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	ceremony, err := h.passkeyService.BeginRegistration(echoCtx.Request().Context(), userID)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusOK, toCeremony(ceremony))
+}
+
+// finishRegistration godoc
+// @Summary      Finish passkey registration
+// @Description  Сохранить passkey по ответу аутентификатора
+// @Tags         passkeys
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body dto.PasskeyRegistration  true  "Authenticator response"
+// @Success      201  {object}  dto.Passkey
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/passkeys/register/finish [post]
+func (h *Passkey) finishRegistration(echoCtx echo.Context) error {
+	var data dto.PasskeyRegistration
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	created, err := h.passkeyService.FinishRegistration(echoCtx.Request().Context(), userID, data.SessionID, data.Name, data.Credential)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusCreated, toPasskey(created))
+}
+
+// beginLogin godoc
+// @Summary      Begin passkey login
+// @Description  Начать вход по passkey, options передаются в navigator.credentials.get
+// @Tags         passkeys
+// @Produce      json
+// @Success      200  {object}  dto.PasskeyCeremony
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/passkeys/login/begin [post]
+func (h *Passkey) beginLogin(echoCtx echo.Context) error {
+	ceremony, err := h.passkeyService.BeginLogin(echoCtx.Request().Context())
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusOK, toCeremony(ceremony))
+}
+
+// finishLogin godoc
+// @Summary      Finish passkey login
+// @Description  Проверить подпись passkey и выдать пару токенов
+// @Tags         passkeys
+// @Accept       json
+// @Produce      json
+// @Param        body body dto.PasskeyLogin  true  "Authenticator response"
+// @Param        mode query string false "cookie - keep the tokens in cookies" Enums(cookie)
+// @Success      200  {object}  dto.Token
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/passkeys/login/finish [post]
+func (h *Passkey) finishLogin(echoCtx echo.Context) error {
+	var data dto.PasskeyLogin
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	tokens, err := h.passkeyService.FinishLogin(echoCtx.Request().Context(), data.SessionID, data.Credential)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return h.cookies.Respond(echoCtx, tokens)
+}
+
+// list godoc
+// @Summary      List passkeys
+// @Description  Зарегистрированные passkey текущего пользователя
+// @Tags         passkeys
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {array}   dto.Passkey
+// @Failure      401  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/passkeys [get]
+func (h *Passkey) list(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	passkeys, err := h.passkeyService.List(echoCtx.Request().Context(), userID)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	result := make([]dto.Passkey, 0, len(passkeys))
+	for _, item := range passkeys {
+		result = append(result, toPasskey(item))
+	}
+	return echoCtx.JSON(http.StatusOK, result)
+}
+
+// delete godoc
+// @Summary      Delete passkey
+// @Description  Удалить passkey текущего пользователя
+// @Tags         passkeys
+// @Security     Bearer
+// @Param        id   path      string  true  "Passkey ID"
+// @Success      204
+// @Failure      401  {object}  dto.ApiError
+// @Failure      404  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/auth/v1/passkeys/{id} [delete]
+func (h *Passkey) delete(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if err = h.passkeyService.Delete(echoCtx.Request().Context(), userID, echoCtx.Param("id")); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+func toCeremony(ceremony model.PasskeyCeremony) dto.PasskeyCeremony {
+	return dto.PasskeyCeremony{
+		SessionID: ceremony.SessionID,
+		Options:   ceremony.Options,
+	}
+}
+
+func toPasskey(item model.Passkey) dto.Passkey {
+	return dto.Passkey{
+		ID:         item.ID,
+		Name:       item.Name,
+		CreatedAt:  item.CreatedAt,
+		LastUsedAt: item.LastUsedAt,
+	}
+}
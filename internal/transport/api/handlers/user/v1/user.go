@@ -4,39 +4,79 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/access"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/auth"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/impersonation"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/user"
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/middlewares"
 	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
 )
 
 type User struct {
-	userService service.UserService
-	logger      *infra.Logger
+	userService   service.UserService
+	authService   service.AuthService
+	accessService service.AccessService
+
+	impersonationService service.ImpersonationService
+
+	cookies *middlewares.Cookies
+	logger  *infra.Logger
 }
 
 // NewUser - создать новый экземпляр обработчика
-func NewUser(userService *user.Service, logger *infra.Logger, router *echo.Echo) *User {
+func NewUser(userService *user.Service, authService *auth.Service, accessService *access.Service, impersonationService *impersonation.Service, cookies *middlewares.Cookies, logger *infra.Logger, router *echo.Echo, authWare *middlewares.Auth) *User {
 	result := &User{
-		userService: userService,
-		logger:      logger,
+		userService:   userService,
+		authService:   authService,
+		accessService: accessService,
+
+		impersonationService: impersonationService,
+
+		cookies: cookies,
+		logger:  logger,
 	}
 
 	router.POST("/api/register", result.register)
+
+	me := router.Group("/api/user/v1/me", authWare.Required)
+	me.GET("", result.me)
+	me.PATCH("", result.updateMe)
+	me.DELETE("", result.deleteMe, authWare.RejectImpersonation)
+
+	sessions := router.Group("/api/user/v1/me/sessions", authWare.Required)
+	sessions.GET("", result.sessions)
+	sessions.DELETE("/:id", result.revokeSession, authWare.RejectImpersonation)
+
+	// admin routes accept API keys, the permission must be in the key scopes as well
+	router.DELETE("/api/user/v1/:id/lockout", result.unlock, authWare.RequireScope(), authWare.RequirePermission(model.PermissionUsersWrite))
+
+	// impersonation is interactive only, the admin must be logged in as themselves
+	router.POST("/api/user/v1/:id/impersonate", result.impersonate, authWare.Required, authWare.RejectImpersonation, authWare.RequirePermission(model.PermissionUsersImpersonate))
+	router.GET("/api/user/v1/:id/impersonations", result.impersonations, authWare.RequireScope(), authWare.RequirePermission(model.PermissionUsersRead))
+
+	roles := router.Group("/api/user/v1/:id/roles", authWare.RequireScope())
+	roles.GET("", result.getRoles, authWare.RequirePermission(model.PermissionUsersRead))
+	roles.POST("", result.assignRole, authWare.RequirePermission(model.PermissionRolesManage))
+	roles.DELETE("/:role", result.removeRole, authWare.RequirePermission(model.PermissionRolesManage))
 	return result
 }
 
 // register godoc
 // @Summary      Register
-// @Description  Регистрация
+// @Description  Регистрация, пароль проверяется парольной политикой, на почту отправляется ссылка для её подтверждения
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        body body dto.AuthData  true  "Auth data"
 // @Success      200  {object}  dto.Token
-// @Failure      400  {object}  dto.ApiError
+// @Failure      400  {object}  dto.PasswordPolicyError
 // @Failure      401  {object}  dto.ApiError
 // @Failure      500  {object}  dto.ApiError
 // @Router       /api/user/v1/register [post]
@@ -54,8 +94,363 @@ func (h *User) register(echoCtx echo.Context) error {
 		return utils.Convert(err, h.logger)
 	}
 
+	// the account already exists, the user can ask for the email again
+	if err = h.authService.SendVerificationEmail(ctx, token); err != nil {
+		h.logger.Warnw("verification email not sent", zap.String("user_id", token), zap.Error(err))
+	}
+
 	tokenData := dto.Token{
 		Token: token,
 	}
 	return echoCtx.JSON(http.StatusOK, tokenData)
 }
+
+// getRoles godoc
+// @Summary      User roles
+// @Description  Роли пользователя, требуется право users:read
+// @Tags         roles
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  dto.Roles
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/{id}/roles [get]
+func (h *User) getRoles(echoCtx echo.Context) error {
+	ctx := echoCtx.Request().Context()
+
+	roles, err := h.accessService.GetUserRoles(ctx, echoCtx.Param("id"))
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if roles == nil {
+		roles = []string{}
+	}
+	return echoCtx.JSON(http.StatusOK, dto.Roles{Roles: roles})
+}
+
+// assignRole godoc
+// @Summary      Assign role
+// @Description  Выдать роль пользователю, требуется право roles:manage
+// @Tags         roles
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      string        true  "User ID"
+// @Param        body body      dto.RoleData  true  "Role"
+// @Success      204
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      404  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/{id}/roles [post]
+func (h *User) assignRole(echoCtx echo.Context) error {
+	var data dto.RoleData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	ctx := echoCtx.Request().Context()
+
+	if err := h.accessService.AssignRole(ctx, echoCtx.Param("id"), data.Role); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// removeRole godoc
+// @Summary      Remove role
+// @Description  Снять роль с пользователя, требуется право roles:manage
+// @Tags         roles
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      string  true  "User ID"
+// @Param        role path      string  true  "Role name"
+// @Success      204
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/{id}/roles/{role} [delete]
+func (h *User) removeRole(echoCtx echo.Context) error {
+	ctx := echoCtx.Request().Context()
+
+	if err := h.accessService.RemoveRole(ctx, echoCtx.Param("id"), echoCtx.Param("role")); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// unlock godoc
+// @Summary      Unlock account
+// @Description  Снять блокировку входа после неудачных попыток, требуется право users:write
+// @Tags         users
+// @Security     Bearer
+// @Param        id   path      string  true  "User ID"
+// @Success      204
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      404  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/{id}/lockout [delete]
+func (h *User) unlock(echoCtx echo.Context) error {
+	if err := h.authService.UnlockAccount(echoCtx.Request().Context(), echoCtx.Param("id")); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// me godoc
+// @Summary      Current user
+// @Description  Профиль текущего пользователя
+// @Tags         users
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {object}  dto.Profile
+// @Failure      401  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/me [get]
+func (h *User) me(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	user, err := h.userService.GetByID(echoCtx.Request().Context(), userID)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+	return echoCtx.JSON(http.StatusOK, toProfile(user))
+}
+
+// updateMe godoc
+// @Summary      Update current user
+// @Description  Изменить профиль текущего пользователя: отображаемое имя (до 64 символов), локаль BCP 47 и часовой пояс IANA.
+// @Description  Поля, которых нет в запросе, не меняются
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body      dto.ProfileData  true  "Profile fields"
+// @Success      200  {object}  dto.Profile
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/me [patch]
+func (h *User) updateMe(echoCtx echo.Context) error {
+	var data dto.ProfileData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	user, err := h.userService.UpdateProfile(echoCtx.Request().Context(), userID, model.ProfileUpdate{
+		DisplayName: data.DisplayName,
+		Locale:      data.Locale,
+		Timezone:    data.Timezone,
+	})
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+	return echoCtx.JSON(http.StatusOK, toProfile(user))
+}
+
+// deleteMe godoc
+// @Summary      Delete current user
+// @Description  Удалить аккаунт текущего пользователя. Вход и все токены сразу перестают работать, на почту приходит ссылка
+// @Description  для восстановления. Данные удаляются окончательно по истечении ACCOUNT_DELETION_GRACE. Пароль обязателен, если он есть у аккаунта
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        body body      dto.DeleteAccountData  true  "Current password"
+// @Success      204
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      409  {object}  dto.ApiError
+// @Failure      429  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/me [delete]
+func (h *User) deleteMe(echoCtx echo.Context) error {
+	var data dto.DeleteAccountData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if err = h.authService.DeleteAccount(echoCtx.Request().Context(), userID, data.Password); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if middlewares.CookieMode(echoCtx) {
+		h.cookies.Clear(echoCtx)
+	}
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// sessions godoc
+// @Summary      List sessions
+// @Description  Активные сессии текущего пользователя: устройство, IP, время входа и последней активности.
+// @Description  Сессия текущего токена отмечена current
+// @Tags         users
+// @Produce      json
+// @Security     Bearer
+// @Success      200  {array}   dto.Session
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/me/sessions [get]
+func (h *User) sessions(echoCtx echo.Context) error {
+	principal, err := middlewares.Principal(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	sessions, err := h.authService.Sessions(echoCtx.Request().Context(), principal.UserID, principal.SessionID)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	result := make([]dto.Session, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, dto.Session{
+			ID:           session.ID,
+			ClientID:     session.ClientID,
+			UserAgent:    session.UserAgent,
+			IP:           session.IP,
+			CreatedAt:    session.CreatedAt,
+			LastActiveAt: session.LastActiveAt,
+			Current:      session.Current,
+		})
+	}
+	return echoCtx.JSON(http.StatusOK, result)
+}
+
+// revokeSession godoc
+// @Summary      Revoke session
+// @Description  Завершить сессию текущего пользователя: её refresh токен отзывается, access токены перестают приниматься сразу
+// @Tags         users
+// @Security     Bearer
+// @Param        id   path      string  true  "Session ID"
+// @Success      204
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      404  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/me/sessions/{id} [delete]
+func (h *User) revokeSession(echoCtx echo.Context) error {
+	userID, err := middlewares.UserID(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	if err = h.authService.RevokeSession(echoCtx.Request().Context(), userID, echoCtx.Param("id")); err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.NoContent(http.StatusNoContent)
+}
+
+// impersonate godoc
+// @Summary      Impersonate user
+// @Description  Выдать администратору токен пользователя с claim act (RFC 8693), требуется право users:impersonate.
+// @Description  Токен действует IMPERSONATION_TTL, не продлевается, каждый запрос с ним записывается в журнал.
+// @Description  Нельзя имперсонировать себя и других администраторов с правом users:impersonate
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      string                 true  "User ID"
+// @Param        body body      dto.ImpersonationData  true  "Reason"
+// @Success      200  {object}  dto.Impersonation
+// @Failure      400  {object}  dto.ApiError
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      404  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/{id}/impersonate [post]
+func (h *User) impersonate(echoCtx echo.Context) error {
+	var data dto.ImpersonationData
+	if err := echoCtx.Bind(&data); err != nil {
+		return err
+	}
+
+	principal, err := middlewares.Principal(echoCtx)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	ctx := echoCtx.Request().Context()
+	h.logger.Info("impersonate: " + principal.UserID + " as " + echoCtx.Param("id"))
+
+	impersonation, err := h.impersonationService.Start(ctx, principal, echoCtx.Param("id"), data.Reason)
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	return echoCtx.JSON(http.StatusOK, dto.Impersonation{
+		Token:     impersonation.AccessToken,
+		ExpiresAt: impersonation.ExpiresAt,
+	})
+}
+
+// impersonations godoc
+// @Summary      Impersonation journal
+// @Description  Журнал имперсонации пользователя: выдачи токенов и запросы с ними, где пользователь был целью или администратором.
+// @Description  Требуется право users:read
+// @Tags         users
+// @Produce      json
+// @Security     Bearer
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {array}   dto.ImpersonationEvent
+// @Failure      401  {object}  dto.ApiError
+// @Failure      403  {object}  dto.ApiError
+// @Failure      500  {object}  dto.ApiError
+// @Router       /api/user/v1/{id}/impersonations [get]
+func (h *User) impersonations(echoCtx echo.Context) error {
+	events, err := h.impersonationService.Events(echoCtx.Request().Context(), echoCtx.Param("id"))
+	if err != nil {
+		return utils.Convert(err, h.logger)
+	}
+
+	result := make([]dto.ImpersonationEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, dto.ImpersonationEvent{
+			ID:        event.ID,
+			ActorID:   event.ActorID,
+			UserID:    event.UserID,
+			Action:    event.Action,
+			Status:    event.Status,
+			Reason:    event.Reason,
+			IP:        event.IP,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+	return echoCtx.JSON(http.StatusOK, result)
+}
+
+func toProfile(user queries.User) dto.Profile {
+	return dto.Profile{
+		ID:            user.ID,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerifiedAt.Valid,
+		DisplayName:   user.DisplayName,
+		Locale:        user.Locale,
+		Timezone:      user.Timezone,
+	}
+}
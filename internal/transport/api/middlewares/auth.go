@@ -1 +1,262 @@
 package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/access"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/apikey"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/auth"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/impersonation"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/service/user"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const (
+	userIDKey    = "auth.user_id"
+	userKey      = "auth.user"
+	principalKey = "auth.principal"
+)
+
+type Auth struct {
+	authService          service.AuthService
+	userService          service.UserService
+	accessService        service.AccessService
+	apiKeyService        service.APIKeyService
+	impersonationService service.ImpersonationService
+	logger               *infra.Logger
+}
+
+// NewAuth - создать middleware авторизации
+func NewAuth(authService *auth.Service, userService *user.Service, accessService *access.Service, apiKeyService *apikey.Service, impersonationService *impersonation.Service, logger *infra.Logger) *Auth {
+	return &Auth{
+		authService:          authService,
+		userService:          userService,
+		accessService:        accessService,
+		apiKeyService:        apiKeyService,
+		impersonationService: impersonationService,
+		logger:               logger,
+	}
+}
+
+// Required - пропустить запрос только с валидным токеном сессии из заголовка Bearer или cookie
+// и сохранить ID пользователя в контексте.
+// API ключи и токены с scope сюда не допускаются, маршруты для скриптов и сторонних клиентов
+// объявляются через RequireScope.
+func (m *Auth) Required(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authHeader, err := Credentials(c)
+		if err != nil {
+			return utils.Convert(err, m.logger)
+		}
+		if authHeader == "" {
+			return echo.ErrUnauthorized
+		}
+
+		return m.authenticate(c, authHeader, sessionOnly(next))
+	}
+}
+
+// Optional - пропустить анонимный запрос, но если токен передан, он должен быть валидным токеном сессии
+func (m *Auth) Optional(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authHeader, err := Credentials(c)
+		if err != nil {
+			return utils.Convert(err, m.logger)
+		}
+		if authHeader == "" {
+			return next(c)
+		}
+
+		return m.authenticate(c, authHeader, sessionOnly(next))
+	}
+}
+
+// RequireScope - как Required, но пропускает и API ключи и токены клиентов OAuth, у которых есть все перечисленные scope.
+// Без аргументов пропускает любой ключ, тогда scope проверяет RequirePermission.
+func (m *Auth) RequireScope(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authHeader, err := Credentials(c)
+			if err != nil {
+				return utils.Convert(err, m.logger)
+			}
+			if authHeader == "" {
+				return echo.ErrUnauthorized
+			}
+
+			return m.authenticate(c, authHeader, func(c echo.Context) error {
+				principal, err := Principal(c)
+				if err != nil {
+					return utils.Convert(err, m.logger)
+				}
+
+				if !principal.Allows(scopes...) {
+					return echo.ErrForbidden
+				}
+
+				return next(c)
+			})
+		}
+	}
+}
+
+// LoadUser - загрузить текущего пользователя из БД, ставится после Required или Optional
+func (m *Auth) LoadUser(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := UserID(c)
+		if err != nil {
+			// anonymous request passed by Optional
+			return next(c)
+		}
+
+		currentUser, err := m.userService.GetByID(c.Request().Context(), userID)
+		if err != nil {
+			// token outlived its user
+			if errors.Is(err, pgx.ErrNoRows) {
+				return echo.ErrUnauthorized
+			}
+			return utils.Convert(err, m.logger)
+		}
+
+		c.Set(userKey, currentUser)
+		return next(c)
+	}
+}
+
+// RequirePermission - пропустить запрос, только если роли из токена дают все перечисленные права,
+// ставится после Required
+func (m *Auth) RequirePermission(permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, err := Principal(c)
+			if err != nil {
+				return utils.Convert(err, m.logger)
+			}
+
+			// an API key needs the permission both in its scopes and in the owner roles
+			if !principal.Allows(permissions...) {
+				return echo.ErrForbidden
+			}
+
+			// a client acting on its own behalf has no roles, its scopes were granted on registration
+			if principal.UserID == "" {
+				return next(c)
+			}
+
+			if err = m.accessService.Authorize(c.Request().Context(), principal.Roles, permissions...); err != nil {
+				return utils.Convert(err, m.logger)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func (m *Auth) authenticate(c echo.Context, authHeader string, next echo.HandlerFunc) error {
+	var (
+		principal model.Principal
+		err       error
+	)
+
+	if token := strings.TrimPrefix(authHeader, "Bearer "); apikey.IsAPIKey(token) {
+		principal, err = m.apiKeyService.Authenticate(c.Request().Context(), token)
+	} else {
+		principal, err = m.authService.Authenticate(c.Request().Context(), authHeader)
+	}
+	if err != nil {
+		return utils.Convert(err, m.logger)
+	}
+
+	if principal.UserID != "" {
+		c.Set(userIDKey, principal.UserID)
+	}
+	c.Set(principalKey, principal)
+
+	if principal.ActorID != "" {
+		return m.auditImpersonation(c, principal, next)
+	}
+	return next(c)
+}
+
+// auditImpersonation - выполнить запрос и записать его в журнал имперсонации вместе со статусом ответа,
+// в том числе запросы, отклонённые следующими middleware
+func (m *Auth) auditImpersonation(c echo.Context, principal model.Principal, next echo.HandlerFunc) error {
+	err := next(c)
+
+	status := c.Response().Status
+	if err != nil {
+		status = http.StatusInternalServerError
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			status = httpErr.Code
+		}
+	}
+
+	req := c.Request()
+	if recordErr := m.impersonationService.Record(req.Context(), model.ImpersonationEvent{
+		ActorID: principal.ActorID,
+		UserID:  principal.UserID,
+		Action:  req.Method + " " + req.URL.Path,
+		Status:  status,
+		IP:      c.RealIP(),
+	}); recordErr != nil {
+		m.logger.Errorw("impersonation event not recorded", zap.String("actor_id", principal.ActorID), zap.String("user_id", principal.UserID), zap.Error(recordErr))
+	}
+
+	return err
+}
+
+// RejectImpersonation - отклонить запрос с токеном имперсонации, ставится после Required на маршруты,
+// меняющие способы входа, сессии и доступ сторонних приложений
+func (m *Auth) RejectImpersonation(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if principal, err := Principal(c); err == nil && principal.ActorID != "" {
+			return utils.Convert(utils.ErrImpersonated, m.logger)
+		}
+		return next(c)
+	}
+}
+
+// sessionOnly - отклонить запрос, аутентифицированный API ключом или токеном с scope
+func sessionOnly(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if principal, err := Principal(c); err == nil && principal.Restricted() {
+			return echo.ErrForbidden
+		}
+		return next(c)
+	}
+}
+
+// UserID - ID текущего пользователя, сохранённый Required или Optional
+func UserID(c echo.Context) (string, error) {
+	return fromContext[string](c, userIDKey)
+}
+
+// Principal - ID и роли текущего пользователя из токена или API ключа, сохранённые Required, Optional или RequireScope
+func Principal(c echo.Context) (model.Principal, error) {
+	return fromContext[model.Principal](c, principalKey)
+}
+
+// User - текущий пользователь, загруженный LoadUser
+func User(c echo.Context) (queries.User, error) {
+	return fromContext[queries.User](c, userKey)
+}
+
+func fromContext[T any](c echo.Context, key string) (T, error) {
+	value, ok := c.Get(key).(T)
+	if !ok {
+		var empty T
+		return empty, utils.ErrContextUserNotFound
+	}
+	return value, nil
+}
@@ -0,0 +1,423 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra/queries"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	serviceMocks "github.com/CringeDrivenDevelopment/webTemplate/internal/service/mocks"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+func newTestAuth(t *testing.T) (*Auth, *serviceMocks.MockAuthService, *serviceMocks.MockUserService) {
+	t.Helper()
+	authService := serviceMocks.NewMockAuthService(t)
+	userService := serviceMocks.NewMockUserService(t)
+	logger := &infra.Logger{Zap: zap.NewNop(), SugaredLogger: zap.NewNop().Sugar()}
+
+	return &Auth{authService: authService, userService: userService, logger: logger}, authService, userService
+}
+
+func newTestAccess(t *testing.T) (*Auth, *serviceMocks.MockAuthService, *serviceMocks.MockAccessService) {
+	t.Helper()
+	authWare, authService, _ := newTestAuth(t)
+	accessService := serviceMocks.NewMockAccessService(t)
+	authWare.accessService = accessService
+
+	return authWare, authService, accessService
+}
+
+func serve(t *testing.T, authHeader string, handler echo.HandlerFunc) error {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	if authHeader != "" {
+		req.Header.Set(echo.HeaderAuthorization, authHeader)
+	}
+
+	return handler(echo.New().NewContext(req, httptest.NewRecorder()))
+}
+
+func TestRequired(t *testing.T) {
+	tests := []struct {
+		name         string
+		authHeader   string
+		mockSetup    func(*serviceMocks.MockAuthService)
+		expectedCode int
+	}{
+		{
+			name:       "valid token",
+			authHeader: "Bearer valid",
+			mockSetup: func(authService *serviceMocks.MockAuthService) {
+				authService.On("Authenticate", mock.Anything, "Bearer valid").Return(model.Principal{UserID: "user-123"}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "missing header",
+			authHeader:   "",
+			mockSetup:    func(_ *serviceMocks.MockAuthService) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid token",
+			authHeader: "Bearer invalid",
+			mockSetup: func(authService *serviceMocks.MockAuthService) {
+				authService.On("Authenticate", mock.Anything, "Bearer invalid").Return(model.Principal{}, utils.ErrInvalidToken).Once()
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authWare, authService, _ := newTestAuth(t)
+			tt.mockSetup(authService)
+
+			err := serve(t, tt.authHeader, authWare.Required(func(c echo.Context) error {
+				userID, err := UserID(c)
+				require.NoError(t, err)
+				assert.Equal(t, "user-123", userID)
+				return c.NoContent(http.StatusOK)
+			}))
+
+			if tt.expectedCode == http.StatusOK {
+				require.NoError(t, err)
+			} else {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tt.expectedCode, httpErr.Code)
+			}
+		})
+	}
+}
+
+func TestOptional(t *testing.T) {
+	t.Run("anonymous request", func(t *testing.T) {
+		authWare, _, _ := newTestAuth(t)
+
+		err := serve(t, "", authWare.Optional(func(c echo.Context) error {
+			_, err := UserID(c)
+			assert.ErrorIs(t, err, utils.ErrContextUserNotFound)
+			return c.NoContent(http.StatusOK)
+		}))
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid token is not ignored", func(t *testing.T) {
+		authWare, authService, _ := newTestAuth(t)
+		authService.On("Authenticate", mock.Anything, "Bearer invalid").Return(model.Principal{}, utils.ErrInvalidToken).Once()
+
+		err := serve(t, "Bearer invalid", authWare.Optional(func(c echo.Context) error {
+			t.Fatal("handler must not be called")
+			return nil
+		}))
+		assert.Equal(t, echo.ErrUnauthorized, err)
+	})
+}
+
+func TestLoadUser(t *testing.T) {
+	currentUser := queries.User{ID: "user-123", Email: "test@example.com"}
+
+	t.Run("user loaded", func(t *testing.T) {
+		authWare, authService, userService := newTestAuth(t)
+		authService.On("Authenticate", mock.Anything, "Bearer valid").Return(model.Principal{UserID: "user-123"}, nil).Once()
+		userService.On("GetByID", mock.Anything, "user-123").Return(currentUser, nil).Once()
+
+		err := serve(t, "Bearer valid", authWare.Required(authWare.LoadUser(func(c echo.Context) error {
+			loaded, err := User(c)
+			require.NoError(t, err)
+			assert.Equal(t, currentUser, loaded)
+			return c.NoContent(http.StatusOK)
+		})))
+		require.NoError(t, err)
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		authWare, authService, userService := newTestAuth(t)
+		authService.On("Authenticate", mock.Anything, "Bearer valid").Return(model.Principal{UserID: "user-123"}, nil).Once()
+		userService.On("GetByID", mock.Anything, "user-123").Return(queries.User{}, pgx.ErrNoRows).Once()
+
+		err := serve(t, "Bearer valid", authWare.Required(authWare.LoadUser(func(c echo.Context) error {
+			t.Fatal("handler must not be called")
+			return nil
+		})))
+		assert.Equal(t, echo.ErrUnauthorized, err)
+	})
+
+	t.Run("anonymous request", func(t *testing.T) {
+		authWare, _, _ := newTestAuth(t)
+
+		err := serve(t, "", authWare.Optional(authWare.LoadUser(func(c echo.Context) error {
+			_, err := User(c)
+			assert.ErrorIs(t, err, utils.ErrContextUserNotFound)
+			return c.NoContent(http.StatusOK)
+		})))
+		require.NoError(t, err)
+	})
+}
+
+func TestRequirePermission(t *testing.T) {
+	principal := model.Principal{UserID: "user-123", Roles: []string{model.RoleAdmin}}
+
+	tests := []struct {
+		name         string
+		mockSetup    func(*serviceMocks.MockAccessService)
+		expectedCode int
+	}{
+		{
+			name: "permission granted",
+			mockSetup: func(accessService *serviceMocks.MockAccessService) {
+				accessService.On("Authorize", mock.Anything, principal.Roles, model.PermissionRolesManage).Return(nil).Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "permission denied",
+			mockSetup: func(accessService *serviceMocks.MockAccessService) {
+				accessService.On("Authorize", mock.Anything, principal.Roles, model.PermissionRolesManage).Return(utils.ErrForbidden).Once()
+			},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authWare, authService, accessService := newTestAccess(t)
+			authService.On("Authenticate", mock.Anything, "Bearer valid").Return(principal, nil).Once()
+			tt.mockSetup(accessService)
+
+			handler := authWare.Required(authWare.RequirePermission(model.PermissionRolesManage)(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}))
+			err := serve(t, "Bearer valid", handler)
+
+			if tt.expectedCode == http.StatusOK {
+				require.NoError(t, err)
+			} else {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tt.expectedCode, httpErr.Code)
+			}
+		})
+	}
+
+	t.Run("without Required", func(t *testing.T) {
+		authWare, _, _ := newTestAccess(t)
+
+		err := serve(t, "", authWare.RequirePermission(model.PermissionRolesManage)(func(c echo.Context) error {
+			t.Fatal("handler must not be called")
+			return nil
+		}))
+		assert.Equal(t, echo.ErrUnauthorized, err)
+	})
+}
+
+func TestAPIKeys(t *testing.T) {
+	const key = "wtk_Y2KQ6JWXN7D3BFRQ5AZ4TLMH3E"
+	keyPrincipal := model.Principal{
+		UserID:   "user-123",
+		Roles:    []string{model.RoleAdmin},
+		APIKeyID: "key-1",
+		Scopes:   []string{model.PermissionUsersRead},
+	}
+
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+
+	tests := []struct {
+		name         string
+		handler      func(*Auth) echo.HandlerFunc
+		mockSetup    func(*serviceMocks.MockAccessService)
+		expectedCode int
+	}{
+		{
+			name: "session only route",
+			handler: func(authWare *Auth) echo.HandlerFunc {
+				return authWare.Required(ok)
+			},
+			mockSetup:    func(_ *serviceMocks.MockAccessService) {},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "scope granted",
+			handler: func(authWare *Auth) echo.HandlerFunc {
+				return authWare.RequireScope(model.PermissionUsersRead)(ok)
+			},
+			mockSetup:    func(_ *serviceMocks.MockAccessService) {},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "scope missing",
+			handler: func(authWare *Auth) echo.HandlerFunc {
+				return authWare.RequireScope(model.PermissionUsersWrite)(ok)
+			},
+			mockSetup:    func(_ *serviceMocks.MockAccessService) {},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "permission in scopes and roles",
+			handler: func(authWare *Auth) echo.HandlerFunc {
+				return authWare.RequireScope()(authWare.RequirePermission(model.PermissionUsersRead)(ok))
+			},
+			mockSetup: func(accessService *serviceMocks.MockAccessService) {
+				accessService.On("Authorize", mock.Anything, keyPrincipal.Roles, model.PermissionUsersRead).Return(nil).Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "permission of the owner but not in scopes",
+			handler: func(authWare *Auth) echo.HandlerFunc {
+				return authWare.RequireScope()(authWare.RequirePermission(model.PermissionRolesManage)(ok))
+			},
+			mockSetup:    func(_ *serviceMocks.MockAccessService) {},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authWare, _, accessService := newTestAccess(t)
+			apiKeyService := serviceMocks.NewMockAPIKeyService(t)
+			authWare.apiKeyService = apiKeyService
+			apiKeyService.On("Authenticate", mock.Anything, key).Return(keyPrincipal, nil).Once()
+			tt.mockSetup(accessService)
+
+			err := serve(t, "Bearer "+key, tt.handler(authWare))
+
+			if tt.expectedCode == http.StatusOK {
+				require.NoError(t, err)
+			} else {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tt.expectedCode, httpErr.Code)
+			}
+		})
+	}
+
+	t.Run("session token passes scoped route", func(t *testing.T) {
+		authWare, authService, _ := newTestAuth(t)
+		authService.On("Authenticate", mock.Anything, "Bearer valid").Return(model.Principal{UserID: "user-123"}, nil).Once()
+
+		require.NoError(t, serve(t, "Bearer valid", authWare.RequireScope(model.PermissionUsersWrite)(ok)))
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		authWare, _, _ := newTestAuth(t)
+		apiKeyService := serviceMocks.NewMockAPIKeyService(t)
+		authWare.apiKeyService = apiKeyService
+		apiKeyService.On("Authenticate", mock.Anything, key).Return(model.Principal{}, utils.ErrInvalidToken).Once()
+
+		assert.Equal(t, echo.ErrUnauthorized, serve(t, "Bearer "+key, authWare.RequireScope()(ok)))
+	})
+}
+
+func TestHelpersWithoutMiddleware(t *testing.T) {
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", http.NoBody), httptest.NewRecorder())
+
+	_, err := UserID(c)
+	require.ErrorIs(t, err, utils.ErrContextUserNotFound)
+	_, err = User(c)
+	require.ErrorIs(t, err, utils.ErrContextUserNotFound)
+	_, err = Principal(c)
+	require.ErrorIs(t, err, utils.ErrContextUserNotFound)
+}
+
+func TestOAuthTokens(t *testing.T) {
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+
+	t.Run("first-party token without scopes is a session", func(t *testing.T) {
+		authWare, authService, _ := newTestAuth(t)
+		authService.On("Authenticate", mock.Anything, "Bearer spa").
+			Return(model.Principal{UserID: "user-123", ClientID: "spa"}, nil).Once()
+
+		require.NoError(t, serve(t, "Bearer spa", authWare.Required(ok)))
+	})
+
+	t.Run("third-party token is limited to scoped routes", func(t *testing.T) {
+		authWare, authService, _ := newTestAuth(t)
+		authService.On("Authenticate", mock.Anything, "Bearer partner").
+			Return(model.Principal{UserID: "user-123", ClientID: "partner", Scopes: []string{model.PermissionUsersRead}}, nil).Once()
+
+		assert.Equal(t, echo.ErrForbidden, serve(t, "Bearer partner", authWare.Required(ok)))
+	})
+
+	t.Run("client credentials token is checked by scopes only", func(t *testing.T) {
+		// no Authorize call is expected, the client has no roles
+		authWare, authService, _ := newTestAccess(t)
+		authService.On("Authenticate", mock.Anything, "Bearer machine").
+			Return(model.Principal{ClientID: "partner", Scopes: []string{model.PermissionUsersRead}}, nil).Twice()
+
+		err := serve(t, "Bearer machine", authWare.RequireScope()(authWare.RequirePermission(model.PermissionUsersRead)(func(c echo.Context) error {
+			_, err := UserID(c)
+			assert.ErrorIs(t, err, utils.ErrContextUserNotFound)
+			return c.NoContent(http.StatusOK)
+		})))
+		require.NoError(t, err)
+
+		err = serve(t, "Bearer machine", authWare.RequireScope()(authWare.RequirePermission(model.PermissionUsersWrite)(ok)))
+		assert.Equal(t, echo.ErrForbidden, err)
+	})
+}
+
+func TestImpersonation(t *testing.T) {
+	impersonated := model.Principal{UserID: "user-123", ActorID: "admin-456", Roles: []string{model.RoleUser}}
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+
+	newTestImpersonation := func(t *testing.T) (*Auth, *serviceMocks.MockImpersonationService) {
+		t.Helper()
+		authWare, authService, _ := newTestAuth(t)
+		impersonationService := serviceMocks.NewMockImpersonationService(t)
+		authWare.impersonationService = impersonationService
+		authService.On("Authenticate", mock.Anything, "Bearer impersonated").Return(impersonated, nil).Once()
+
+		return authWare, impersonationService
+	}
+
+	t.Run("requests are recorded with status", func(t *testing.T) {
+		authWare, impersonationService := newTestImpersonation(t)
+		impersonationService.On("Record", mock.Anything, mock.MatchedBy(func(event model.ImpersonationEvent) bool {
+			return event.ActorID == "admin-456" && event.UserID == "user-123" && event.Action == "GET /" && event.Status == http.StatusNoContent
+		})).Return(nil).Once()
+
+		require.NoError(t, serve(t, "Bearer impersonated", authWare.Required(ok)))
+	})
+
+	t.Run("rejected requests are recorded", func(t *testing.T) {
+		authWare, impersonationService := newTestImpersonation(t)
+		impersonationService.On("Record", mock.Anything, mock.MatchedBy(func(event model.ImpersonationEvent) bool {
+			return event.Status == http.StatusForbidden
+		})).Return(nil).Once()
+
+		err := serve(t, "Bearer impersonated", authWare.Required(authWare.RejectImpersonation(ok)))
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+
+	t.Run("journal failure does not fail the request", func(t *testing.T) {
+		authWare, impersonationService := newTestImpersonation(t)
+		impersonationService.On("Record", mock.Anything, mock.Anything).Return(pgx.ErrTxClosed).Once()
+
+		require.NoError(t, serve(t, "Bearer impersonated", authWare.Required(ok)))
+	})
+
+	t.Run("own token passes", func(t *testing.T) {
+		authWare, authService, _ := newTestAuth(t)
+		authService.On("Authenticate", mock.Anything, "Bearer valid").Return(model.Principal{UserID: "user-123"}, nil).Once()
+
+		require.NoError(t, serve(t, "Bearer valid", authWare.Required(authWare.RejectImpersonation(ok))))
+	})
+}
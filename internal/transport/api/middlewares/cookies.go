@@ -0,0 +1,156 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+	// CSRFCookie - readable by the frontend, its value is repeated in CSRFHeader
+	CSRFCookie = "csrf_token"
+	CSRFHeader = "X-CSRF-Token"
+
+	// refreshCookiePath - the refresh token is sent only to refresh and logout
+	refreshCookiePath = "/api/auth/v1"
+)
+
+// Cookies - cookie сессии браузера: access и refresh токены в HttpOnly cookie и CSRF токен для double submit
+type Cookies struct {
+	domain         string
+	secure         bool
+	sameSite       http.SameSite
+	refreshExpires time.Duration
+}
+
+// NewCookies - создать cookie сессии с атрибутами из конфига
+func NewCookies(cfg *infra.Config) *Cookies {
+	sameSite := http.SameSiteStrictMode
+	switch cfg.CookieSameSite {
+	case "lax":
+		sameSite = http.SameSiteLaxMode
+	case "none":
+		sameSite = http.SameSiteNoneMode
+	}
+
+	return &Cookies{
+		domain:         cfg.CookieDomain,
+		secure:         cfg.CookieSecure,
+		sameSite:       sameSite,
+		refreshExpires: cfg.RefreshTokenTTL,
+	}
+}
+
+// CookieMode - клиент просит токены в cookie, а не в теле ответа: ?mode=cookie
+func CookieMode(c echo.Context) bool {
+	return c.QueryParam("mode") == "cookie"
+}
+
+// Respond - ответить парой токенов в теле, а в режиме cookie записать их в HttpOnly cookie
+// и вернуть новый CSRF токен: фронтенд на другом домене не может прочитать его cookie
+func (k *Cookies) Respond(c echo.Context, tokens model.TokenPair) error {
+	if !CookieMode(c) {
+		return c.JSON(http.StatusOK, dto.Token{
+			Token:        tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+		})
+	}
+
+	csrfToken := rand.Text()
+	c.SetCookie(k.cookie(AccessTokenCookie, tokens.AccessToken, "/api", tokens.ExpiresIn, true))
+	c.SetCookie(k.cookie(RefreshTokenCookie, tokens.RefreshToken, refreshCookiePath, k.refreshExpires, true))
+	c.SetCookie(k.cookie(CSRFCookie, csrfToken, "/", k.refreshExpires, false))
+
+	return c.JSON(http.StatusOK, dto.CookieSession{CSRFToken: csrfToken})
+}
+
+// Clear - удалить cookie сессии после выхода
+func (k *Cookies) Clear(c echo.Context) {
+	c.SetCookie(k.cookie(AccessTokenCookie, "", "/api", -1, true))
+	c.SetCookie(k.cookie(RefreshTokenCookie, "", refreshCookiePath, -1, true))
+	c.SetCookie(k.cookie(CSRFCookie, "", "/", -1, false))
+}
+
+func (k *Cookies) cookie(name, value, path string, maxAge time.Duration, httpOnly bool) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   k.domain,
+		MaxAge:   int(maxAge.Seconds()),
+		Secure:   k.secure,
+		HttpOnly: httpOnly,
+		SameSite: k.sameSite,
+	}
+	// MaxAge below zero deletes the cookie, zero would keep it until the browser is closed
+	if maxAge < 0 {
+		cookie.MaxAge = -1
+	}
+
+	return cookie
+}
+
+// Credentials - заголовок Authorization, а без него access токен из cookie. Cookie браузер отправляет сам,
+// поэтому изменяющий запрос с ней принимается, только если он повторил CSRF токен в заголовке.
+// Пустая строка - запрос анонимный.
+func Credentials(c echo.Context) (string, error) {
+	if authHeader := c.Request().Header.Get(echo.HeaderAuthorization); authHeader != "" {
+		return authHeader, nil
+	}
+
+	cookie, err := c.Cookie(AccessTokenCookie)
+	if err != nil || cookie.Value == "" {
+		return "", nil
+	}
+
+	if err = checkCSRF(c); err != nil {
+		return "", err
+	}
+
+	return "Bearer " + cookie.Value, nil
+}
+
+// RefreshToken - refresh токен из cookie, проверяется так же, как access токен в Credentials
+func RefreshToken(c echo.Context) (string, error) {
+	cookie, err := c.Cookie(RefreshTokenCookie)
+	if err != nil || cookie.Value == "" {
+		return "", nil
+	}
+
+	if err = checkCSRF(c); err != nil {
+		return "", err
+	}
+
+	return cookie.Value, nil
+}
+
+// checkCSRF - double submit: на изменяющем запросе заголовок должен совпасть с CSRF cookie,
+// которую сторонний сайт не может ни прочитать, ни подставить в заголовок
+func checkCSRF(c echo.Context) error {
+	switch c.Request().Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+
+	cookie, err := c.Cookie(CSRFCookie)
+	if err != nil || cookie.Value == "" {
+		return utils.ErrInvalidCSRF
+	}
+
+	header := c.Request().Header.Get(CSRFHeader)
+	if subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) != 1 {
+		return utils.ErrInvalidCSRF
+	}
+
+	return nil
+}
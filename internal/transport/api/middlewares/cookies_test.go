@@ -0,0 +1,171 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
+	"github.com/CringeDrivenDevelopment/webTemplate/pkg/utils"
+)
+
+var testTokens = model.TokenPair{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: time.Hour}
+
+func newCookieContext(method, target string, cookies ...*http.Cookie) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, target, http.NoBody)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+
+	return echo.New().NewContext(req, rec), rec
+}
+
+func responseCookies(rec *httptest.ResponseRecorder) map[string]*http.Cookie {
+	result := map[string]*http.Cookie{}
+	for _, cookie := range rec.Result().Cookies() {
+		result[cookie.Name] = cookie
+	}
+	return result
+}
+
+func TestCookiesRespond(t *testing.T) {
+	cookies := NewCookies(&infra.Config{CookieSecure: true, CookieSameSite: "lax", RefreshTokenTTL: 24 * time.Hour})
+
+	t.Run("tokens in body by default", func(t *testing.T) {
+		c, rec := newCookieContext(http.MethodPost, "/api/login")
+
+		require.NoError(t, cookies.Respond(c, testTokens))
+		assert.Empty(t, rec.Result().Cookies())
+		assert.Contains(t, rec.Body.String(), `"refresh_token":"refresh"`)
+	})
+
+	t.Run("cookie mode", func(t *testing.T) {
+		c, rec := newCookieContext(http.MethodPost, "/api/login?mode=cookie")
+
+		require.NoError(t, cookies.Respond(c, testTokens))
+		assert.NotContains(t, rec.Body.String(), "access")
+
+		set := responseCookies(rec)
+		require.Len(t, set, 3)
+
+		access := set[AccessTokenCookie]
+		assert.Equal(t, "access", access.Value)
+		assert.True(t, access.HttpOnly)
+		assert.True(t, access.Secure)
+		assert.Equal(t, http.SameSiteLaxMode, access.SameSite)
+		assert.Equal(t, 3600, access.MaxAge)
+
+		refresh := set[RefreshTokenCookie]
+		assert.Equal(t, "refresh", refresh.Value)
+		assert.Equal(t, "/api/auth/v1", refresh.Path)
+		assert.True(t, refresh.HttpOnly)
+
+		// the frontend reads the csrf token, so it is returned and not HttpOnly
+		csrf := set[CSRFCookie]
+		assert.False(t, csrf.HttpOnly)
+		assert.Contains(t, rec.Body.String(), `"csrf_token":"`+csrf.Value+`"`)
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		c, rec := newCookieContext(http.MethodPost, "/api/auth/v1/logout?mode=cookie")
+
+		cookies.Clear(c)
+		for _, cookie := range responseCookies(rec) {
+			assert.Empty(t, cookie.Value)
+			assert.Negative(t, cookie.MaxAge)
+		}
+	})
+}
+
+func TestCredentials(t *testing.T) {
+	access := &http.Cookie{Name: AccessTokenCookie, Value: "cookie-token"}
+	csrf := &http.Cookie{Name: CSRFCookie, Value: "csrf-secret"}
+
+	tests := []struct {
+		name       string
+		method     string
+		header     string
+		csrfHeader string
+		cookies    []*http.Cookie
+		expected   string
+		err        error
+	}{
+		{name: "anonymous", method: http.MethodPost},
+		{name: "header wins over cookie", method: http.MethodPost, header: "Bearer header-token", cookies: []*http.Cookie{access}, expected: "Bearer header-token"},
+		{name: "cookie on safe request", method: http.MethodGet, cookies: []*http.Cookie{access}, expected: "Bearer cookie-token"},
+		{name: "cookie with csrf token", method: http.MethodDelete, csrfHeader: "csrf-secret", cookies: []*http.Cookie{access, csrf}, expected: "Bearer cookie-token"},
+		{name: "cookie without csrf header", method: http.MethodPost, cookies: []*http.Cookie{access, csrf}, err: utils.ErrInvalidCSRF},
+		{name: "csrf header mismatch", method: http.MethodPatch, csrfHeader: "guess", cookies: []*http.Cookie{access, csrf}, err: utils.ErrInvalidCSRF},
+		{name: "csrf cookie missing", method: http.MethodPost, csrfHeader: "csrf-secret", cookies: []*http.Cookie{access}, err: utils.ErrInvalidCSRF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newCookieContext(tt.method, "/", tt.cookies...)
+			if tt.header != "" {
+				c.Request().Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+			if tt.csrfHeader != "" {
+				c.Request().Header.Set(CSRFHeader, tt.csrfHeader)
+			}
+
+			authHeader, err := Credentials(c)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, authHeader)
+		})
+	}
+}
+
+func TestRequiredWithCookie(t *testing.T) {
+	authWare, authService, _ := newTestAuth(t)
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+
+	c, _ := newCookieContext(http.MethodPost, "/", &http.Cookie{Name: AccessTokenCookie, Value: "cookie-token"})
+
+	// a cross-site form post carries the cookie but cannot repeat the csrf token
+	err := authWare.Required(ok)(c)
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusForbidden, httpErr.Code)
+
+	authService.On("Authenticate", mock.Anything, "Bearer cookie-token").Return(model.Principal{UserID: "user-123"}, nil).Once()
+
+	c, _ = newCookieContext(http.MethodPost, "/",
+		&http.Cookie{Name: AccessTokenCookie, Value: "cookie-token"},
+		&http.Cookie{Name: CSRFCookie, Value: "csrf-secret"},
+	)
+	c.Request().Header.Set(CSRFHeader, "csrf-secret")
+	require.NoError(t, authWare.Required(ok)(c))
+
+	userID, err := UserID(c)
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", userID)
+}
+
+func TestRefreshToken(t *testing.T) {
+	c, _ := newCookieContext(http.MethodPost, "/api/auth/v1/refresh?mode=cookie",
+		&http.Cookie{Name: RefreshTokenCookie, Value: "refresh"},
+		&http.Cookie{Name: CSRFCookie, Value: "csrf-secret"},
+	)
+
+	_, err := RefreshToken(c)
+	assert.ErrorIs(t, err, utils.ErrInvalidCSRF)
+
+	c.Request().Header.Set(CSRFHeader, "csrf-secret")
+	refreshToken, err := RefreshToken(c)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh", refreshToken)
+	assert.True(t, CookieMode(c))
+}
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/model"
 )
 
 func NewLogger(log *infra.Logger) echo.MiddlewareFunc {
@@ -15,12 +16,19 @@ func NewLogger(log *infra.Logger) echo.MiddlewareFunc {
 		return func(c echo.Context) error {
 			start := time.Now()
 
+			req := c.Request()
+			// services record the same client data in the sessions they create
+			c.SetRequest(req.WithContext(model.WithClientInfo(req.Context(), model.ClientInfo{
+				IP:        c.RealIP(),
+				UserAgent: req.UserAgent(),
+			})))
+
 			err := next(c)
 			if err != nil {
 				c.Error(err)
 			}
 
-			req := c.Request()
+			req = c.Request()
 			res := c.Response()
 
 			fields := map[string]interface{}{
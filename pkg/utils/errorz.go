@@ -2,12 +2,17 @@ package utils
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 
 	"github.com/CringeDrivenDevelopment/webTemplate/internal/infra"
+	"github.com/CringeDrivenDevelopment/webTemplate/internal/transport/api/dto"
 )
 
 var (
@@ -16,8 +21,91 @@ var (
 	ErrInvalidUser         = errors.New("invalid user")
 	ErrContextUserNotFound = errors.New("user not found in context")
 	ErrEmailAlreadySignup  = errors.New("email already signup")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+	ErrForbidden           = errors.New("forbidden")
+	ErrUnknownRole         = errors.New("unknown role")
+	ErrLastAdmin           = errors.New("last admin role cannot be removed")
+	ErrInvalidOTP          = errors.New("invalid one-time code")
+	ErrTwoFactorEnabled    = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorDisabled   = errors.New("two-factor authentication is not enabled")
+	ErrInvalidPasskey      = errors.New("invalid passkey")
+	ErrEmailNotVerified    = errors.New("email is not verified")
+	ErrTooManyAttempts     = errors.New("too many failed attempts")
+	ErrWeakPassword        = errors.New("password does not meet the policy")
+	ErrInvalidEmail        = errors.New("invalid email")
+	ErrUnknownScope        = errors.New("unknown scope")
+	ErrInvalidExpiry       = errors.New("invalid expiry")
+	ErrNameRequired        = errors.New("name is required")
+	ErrUnknownProvider     = errors.New("unknown identity provider")
+	ErrIdentityLinked      = errors.New("identity is already linked")
+	ErrIdentityNotLinked   = errors.New("account with this email exists, log in and link the provider first")
+	ErrLastLoginMethod     = errors.New("last login method cannot be removed")
+	ErrInvalidClientConfig = errors.New("invalid client configuration")
+	ErrImpersonated        = errors.New("not allowed while impersonating")
+	ErrCannotImpersonate   = errors.New("user cannot be impersonated")
+	ErrReasonRequired      = errors.New("reason is required")
+	ErrInvalidCSRF         = errors.New("missing or invalid csrf token")
+	ErrInvalidProfile      = errors.New("invalid profile")
+	ErrExportInProgress    = errors.New("data export is already in progress")
 )
 
+// LockoutError - вход временно заблокирован, повторить можно через RetryAfter
+type LockoutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LockoutError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// PolicyViolation - нарушенное правило парольной политики
+type PolicyViolation struct {
+	Rule    string
+	Message string
+}
+
+// PasswordPolicyError - пароль отклонён, перечислены все нарушенные правила
+type PasswordPolicyError struct {
+	Violations []PolicyViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	rules := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		rules = append(rules, violation.Rule)
+	}
+	return fmt.Sprintf("%s: %s", ErrWeakPassword, strings.Join(rules, ", "))
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrWeakPassword
+}
+
+// Коды ошибок OAuth 2.0 из RFC 6749
+const (
+	OAuthInvalidRequest          = "invalid_request"
+	OAuthInvalidClient           = "invalid_client"
+	OAuthInvalidGrant            = "invalid_grant"
+	OAuthUnauthorizedClient      = "unauthorized_client"
+	OAuthUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthUnsupportedResponseType = "unsupported_response_type"
+	OAuthInvalidScope            = "invalid_scope"
+)
+
+// OAuthError - ошибка OAuth 2.0, отдаётся клиенту в формате RFC 6749 вместо ApiError
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
 func Convert(functionError error, logger *infra.Logger) error {
 	if errors.Is(functionError, pgx.ErrNoRows) {
 		return echo.ErrNotFound
@@ -34,7 +122,113 @@ func Convert(functionError error, logger *infra.Logger) error {
 	if errors.Is(functionError, ErrInvalidUser) {
 		return echo.ErrUnauthorized
 	}
+	if errors.Is(functionError, ErrRefreshTokenReused) {
+		return echo.ErrUnauthorized
+	}
+	if errors.Is(functionError, ErrContextUserNotFound) {
+		return echo.ErrUnauthorized
+	}
+	if errors.Is(functionError, ErrForbidden) {
+		return echo.ErrForbidden
+	}
+	if errors.Is(functionError, ErrUnknownRole) {
+		return echo.ErrBadRequest
+	}
+	if errors.Is(functionError, ErrLastAdmin) {
+		return echo.ErrConflict
+	}
+	if errors.Is(functionError, ErrInvalidOTP) {
+		return echo.ErrUnauthorized
+	}
+	if errors.Is(functionError, ErrTwoFactorEnabled) {
+		return echo.ErrConflict
+	}
+	if errors.Is(functionError, ErrTwoFactorDisabled) {
+		return echo.ErrConflict
+	}
+	if errors.Is(functionError, ErrInvalidPasskey) {
+		return echo.ErrUnauthorized
+	}
+	if errors.Is(functionError, ErrEmailNotVerified) {
+		return echo.ErrForbidden
+	}
+	if errors.Is(functionError, ErrTooManyAttempts) {
+		return echo.ErrTooManyRequests
+	}
+	var policyErr *PasswordPolicyError
+	if errors.As(functionError, &policyErr) {
+		return echo.NewHTTPError(http.StatusBadRequest, policyResponse(policyErr))
+	}
+	var oauthErr *OAuthError
+	if errors.As(functionError, &oauthErr) {
+		status := http.StatusBadRequest
+		if oauthErr.Code == OAuthInvalidClient {
+			status = http.StatusUnauthorized
+		}
+		return echo.NewHTTPError(status, dto.OAuthError{Error: oauthErr.Code, ErrorDescription: oauthErr.Description})
+	}
+	if errors.Is(functionError, ErrWeakPassword) {
+		return echo.ErrBadRequest
+	}
+	if errors.Is(functionError, ErrInvalidEmail) {
+		return echo.ErrBadRequest
+	}
+	if errors.Is(functionError, ErrUnknownScope) {
+		return echo.ErrBadRequest
+	}
+	if errors.Is(functionError, ErrInvalidExpiry) {
+		return echo.ErrBadRequest
+	}
+	if errors.Is(functionError, ErrNameRequired) {
+		return echo.ErrBadRequest
+	}
+	if errors.Is(functionError, ErrUnknownProvider) {
+		return echo.ErrNotFound
+	}
+	if errors.Is(functionError, ErrIdentityLinked) {
+		return echo.ErrConflict
+	}
+	if errors.Is(functionError, ErrIdentityNotLinked) {
+		return echo.NewHTTPError(http.StatusConflict, ErrIdentityNotLinked.Error())
+	}
+	if errors.Is(functionError, ErrLastLoginMethod) {
+		return echo.ErrConflict
+	}
+	if errors.Is(functionError, ErrInvalidProfile) {
+		return echo.NewHTTPError(http.StatusBadRequest, functionError.Error())
+	}
+	if errors.Is(functionError, ErrInvalidClientConfig) {
+		return echo.NewHTTPError(http.StatusBadRequest, functionError.Error())
+	}
+	if errors.Is(functionError, ErrImpersonated) {
+		return echo.NewHTTPError(http.StatusForbidden, ErrImpersonated.Error())
+	}
+	if errors.Is(functionError, ErrCannotImpersonate) {
+		return echo.NewHTTPError(http.StatusForbidden, ErrCannotImpersonate.Error())
+	}
+	if errors.Is(functionError, ErrInvalidCSRF) {
+		return echo.NewHTTPError(http.StatusForbidden, ErrInvalidCSRF.Error())
+	}
+	if errors.Is(functionError, ErrReasonRequired) {
+		return echo.ErrBadRequest
+	}
+	if errors.Is(functionError, ErrExportInProgress) {
+		return echo.NewHTTPError(http.StatusConflict, ErrExportInProgress.Error())
+	}
 	logger.Error("500 error stacktrace", zap.Error(functionError))
 
 	return echo.ErrInternalServerError
 }
+
+func policyResponse(policyErr *PasswordPolicyError) dto.PasswordPolicyError {
+	violations := make([]dto.PolicyViolation, 0, len(policyErr.Violations))
+	for _, violation := range policyErr.Violations {
+		violations = append(violations, dto.PolicyViolation{Rule: violation.Rule, Message: violation.Message})
+	}
+
+	return dto.PasswordPolicyError{
+		Code:       http.StatusBadRequest,
+		Message:    ErrWeakPassword.Error(),
+		Violations: violations,
+	}
+}
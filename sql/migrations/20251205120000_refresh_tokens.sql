@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS refresh_tokens(
+                                             id TEXT NOT NULL PRIMARY KEY,
+                                             user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                             family_id TEXT NOT NULL,
+                                             token_hash TEXT NOT NULL UNIQUE,
+                                             expires_at TIMESTAMPTZ NOT NULL,
+                                             created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                             revoked_at TIMESTAMPTZ,
+                                             replaced_by TEXT
+);
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_id_idx ON refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS refresh_tokens_user_id_idx ON refresh_tokens(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS refresh_tokens;
+-- +goose StatementEnd
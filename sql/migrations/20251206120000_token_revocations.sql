@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS revoked_tokens(
+                                             jti TEXT NOT NULL PRIMARY KEY,
+                                             user_id TEXT NOT NULL,
+                                             expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS revoked_tokens_expires_at_idx ON revoked_tokens(expires_at);
+CREATE TABLE IF NOT EXISTS user_token_revocations(
+                                                     user_id TEXT NOT NULL PRIMARY KEY,
+                                                     revoked_at TIMESTAMPTZ NOT NULL,
+                                                     expires_at TIMESTAMPTZ NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS user_token_revocations;
+DROP TABLE IF EXISTS revoked_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS roles(
+                                    name TEXT NOT NULL PRIMARY KEY,
+                                    description TEXT NOT NULL DEFAULT ''
+);
+CREATE TABLE IF NOT EXISTS permissions(
+                                          name TEXT NOT NULL PRIMARY KEY,
+                                          description TEXT NOT NULL DEFAULT ''
+);
+CREATE TABLE IF NOT EXISTS role_permissions(
+                                               role TEXT NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+                                               permission TEXT NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+                                               PRIMARY KEY (role, permission)
+);
+CREATE TABLE IF NOT EXISTS user_roles(
+                                         user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                         role TEXT NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+                                         created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                         PRIMARY KEY (user_id, role)
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Full access to user and role management'),
+    ('user', 'Default role of every registered user')
+ON CONFLICT DO NOTHING;
+INSERT INTO permissions (name, description) VALUES
+    ('users:read', 'View any user account'),
+    ('users:write', 'Modify any user account'),
+    ('roles:manage', 'Grant and revoke roles')
+ON CONFLICT DO NOTHING;
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'users:read'),
+    ('admin', 'users:write'),
+    ('admin', 'roles:manage')
+ON CONFLICT DO NOTHING;
+INSERT INTO user_roles (user_id, role) SELECT id, 'user' FROM users ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS user_totp(
+                                        user_id TEXT NOT NULL PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                                        secret TEXT NOT NULL,
+                                        last_used_step BIGINT NOT NULL DEFAULT 0,
+                                        created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                        confirmed_at TIMESTAMPTZ
+);
+CREATE TABLE IF NOT EXISTS recovery_codes(
+                                             id TEXT NOT NULL PRIMARY KEY,
+                                             user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                             code_hash TEXT NOT NULL,
+                                             used_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS recovery_codes_user_id_idx ON recovery_codes(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS user_totp;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS webauthn_credentials(
+                                                   id TEXT NOT NULL PRIMARY KEY,
+                                                   user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                                   name TEXT NOT NULL DEFAULT '',
+                                                   credential_id BYTEA NOT NULL UNIQUE,
+                                                   public_key BYTEA NOT NULL,
+                                                   attestation_type TEXT NOT NULL DEFAULT '',
+                                                   aaguid BYTEA,
+                                                   transports TEXT[] NOT NULL DEFAULT '{}',
+                                                   sign_count BIGINT NOT NULL DEFAULT 0,
+                                                   backup_eligible BOOLEAN NOT NULL DEFAULT false,
+                                                   backup_state BOOLEAN NOT NULL DEFAULT false,
+                                                   created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                   last_used_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS webauthn_credentials_user_id_idx ON webauthn_credentials(user_id);
+CREATE TABLE IF NOT EXISTS webauthn_sessions(
+                                                id TEXT NOT NULL PRIMARY KEY,
+                                                user_id TEXT REFERENCES users(id) ON DELETE CASCADE,
+                                                data BYTEA NOT NULL,
+                                                expires_at TIMESTAMPTZ NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS webauthn_sessions;
+DROP TABLE IF EXISTS webauthn_credentials;
+-- +goose StatementEnd
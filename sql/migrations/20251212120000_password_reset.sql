@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS password_reset_tokens(
+                                                    id TEXT NOT NULL PRIMARY KEY,
+                                                    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                                    token_hash TEXT NOT NULL UNIQUE,
+                                                    expires_at TIMESTAMPTZ NOT NULL,
+                                                    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                    used_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS password_reset_tokens_user_id_idx ON password_reset_tokens(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS password_reset_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS login_attempts(
+                                             subject TEXT NOT NULL PRIMARY KEY,
+                                             failures INTEGER NOT NULL DEFAULT 0,
+                                             last_failure_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                             locked_until TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS login_attempts_last_failure_at_idx ON login_attempts(last_failure_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS login_attempts;
+-- +goose StatementEnd
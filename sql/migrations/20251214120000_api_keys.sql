@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS api_keys(
+                                       id TEXT NOT NULL PRIMARY KEY,
+                                       user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                       name TEXT NOT NULL,
+                                       prefix TEXT NOT NULL,
+                                       key_hash TEXT NOT NULL UNIQUE,
+                                       scopes TEXT[] NOT NULL DEFAULT '{}',
+                                       expires_at TIMESTAMPTZ NOT NULL,
+                                       created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                       last_used_at TIMESTAMPTZ,
+                                       revoked_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS api_keys_user_id_idx ON api_keys(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS api_keys;
+-- +goose StatementEnd
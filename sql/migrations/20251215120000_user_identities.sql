@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS user_identities(
+                                              provider TEXT NOT NULL,
+                                              subject TEXT NOT NULL,
+                                              user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                              email TEXT NOT NULL DEFAULT '',
+                                              created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                              last_login_at TIMESTAMPTZ,
+                                              PRIMARY KEY (provider, subject),
+                                              UNIQUE (user_id, provider)
+);
+
+CREATE TABLE IF NOT EXISTS oidc_states(
+                                          id TEXT NOT NULL PRIMARY KEY,
+                                          provider TEXT NOT NULL,
+                                          user_id TEXT REFERENCES users(id) ON DELETE CASCADE,
+                                          nonce TEXT NOT NULL,
+                                          code_verifier TEXT NOT NULL,
+                                          expires_at TIMESTAMPTZ NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS oidc_states;
+DROP TABLE IF EXISTS user_identities;
+-- +goose StatementEnd
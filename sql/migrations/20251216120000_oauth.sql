@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS oauth_clients(
+                                            id TEXT NOT NULL PRIMARY KEY,
+                                            name TEXT NOT NULL,
+                                            secret_hash TEXT,
+                                            redirect_uris TEXT[] NOT NULL DEFAULT '{}',
+                                            grant_types TEXT[] NOT NULL DEFAULT '{}',
+                                            scopes TEXT[] NOT NULL DEFAULT '{}',
+                                            first_party BOOLEAN NOT NULL DEFAULT false,
+                                            created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS oauth_authorization_codes(
+                                                        id TEXT NOT NULL PRIMARY KEY,
+                                                        client_id TEXT NOT NULL REFERENCES oauth_clients(id) ON DELETE CASCADE,
+                                                        user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                                        redirect_uri TEXT NOT NULL,
+                                                        scopes TEXT[],
+                                                        code_challenge TEXT NOT NULL,
+                                                        expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS oauth_consents(
+                                             user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                             client_id TEXT NOT NULL REFERENCES oauth_clients(id) ON DELETE CASCADE,
+                                             scopes TEXT[] NOT NULL DEFAULT '{}',
+                                             created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                             updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                             PRIMARY KEY (user_id, client_id)
+);
+
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS client_id TEXT REFERENCES oauth_clients(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS scopes TEXT[];
+
+INSERT INTO permissions (name, description) VALUES
+    ('clients:manage', 'Register and remove OAuth clients')
+ON CONFLICT DO NOTHING;
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'clients:manage')
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DELETE FROM permissions WHERE name = 'clients:manage';
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS scopes,
+    DROP COLUMN IF EXISTS client_id;
+DROP TABLE IF EXISTS oauth_consents;
+DROP TABLE IF EXISTS oauth_authorization_codes;
+DROP TABLE IF EXISTS oauth_clients;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS sessions(
+                                       id TEXT NOT NULL PRIMARY KEY,
+                                       user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                       client_id TEXT REFERENCES oauth_clients(id) ON DELETE CASCADE,
+                                       user_agent TEXT NOT NULL DEFAULT '',
+                                       ip TEXT NOT NULL DEFAULT '',
+                                       created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                       last_active_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                       revoked_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions(user_id);
+
+-- logins made before this migration become sessions without device info
+INSERT INTO sessions (id, user_id, client_id, created_at, last_active_at)
+SELECT family_id, user_id, max(client_id), min(created_at), max(created_at)
+FROM refresh_tokens
+GROUP BY family_id, user_id
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS sessions;
+-- +goose StatementEnd
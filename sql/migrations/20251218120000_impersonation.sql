@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- no foreign keys: the audit trail outlives the users it mentions
+CREATE TABLE IF NOT EXISTS impersonation_events(
+                                                   id TEXT NOT NULL PRIMARY KEY,
+                                                   actor_id TEXT NOT NULL,
+                                                   user_id TEXT NOT NULL,
+                                                   action TEXT NOT NULL,
+                                                   status INTEGER,
+                                                   reason TEXT,
+                                                   ip TEXT NOT NULL DEFAULT '',
+                                                   created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS impersonation_events_user_id_idx ON impersonation_events(user_id);
+CREATE INDEX IF NOT EXISTS impersonation_events_actor_id_idx ON impersonation_events(actor_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:impersonate', 'Act as another user with a short-lived audited token')
+ON CONFLICT DO NOTHING;
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'users:impersonate')
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DELETE FROM permissions WHERE name = 'users:impersonate';
+DROP TABLE IF EXISTS impersonation_events;
+-- +goose StatementEnd
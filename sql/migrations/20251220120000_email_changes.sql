@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- one pending change per user, the new address stays reserved until it is confirmed or expires
+CREATE TABLE IF NOT EXISTS email_changes(
+                                            user_id TEXT NOT NULL PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                                            new_email TEXT NOT NULL,
+                                            expires_at TIMESTAMPTZ NOT NULL,
+                                            created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS email_changes_new_email_idx ON email_changes(LOWER(new_email));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS email_changes;
+-- +goose StatementEnd
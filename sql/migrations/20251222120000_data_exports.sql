@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- personal data archives, built in the background and kept until expires_at
+CREATE TABLE IF NOT EXISTS data_exports(
+                                           id TEXT NOT NULL PRIMARY KEY,
+                                           user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                           status TEXT NOT NULL DEFAULT 'pending',
+                                           archive BYTEA,
+                                           error TEXT NOT NULL DEFAULT '',
+                                           created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                           started_at TIMESTAMPTZ,
+                                           completed_at TIMESTAMPTZ,
+                                           expires_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS data_exports_user_id_idx ON data_exports(user_id);
+-- at most one unfinished export per user
+CREATE UNIQUE INDEX IF NOT EXISTS data_exports_active_idx ON data_exports(user_id) WHERE status IN ('pending', 'running');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS data_exports;
+-- +goose StatementEnd
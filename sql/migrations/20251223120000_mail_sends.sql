@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- emails of one kind sent to one address, subject is the kind and the email hash
+CREATE TABLE IF NOT EXISTS mail_sends(
+                                         subject TEXT NOT NULL PRIMARY KEY,
+                                         sent INTEGER NOT NULL DEFAULT 0,
+                                         window_started_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS mail_sends_window_started_at_idx ON mail_sends(window_started_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS mail_sends;
+-- +goose StatementEnd
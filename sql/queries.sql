@@ -1,6 +1,253 @@
 -- name: GetUserByID :one
-SELECT * FROM users WHERE id = $1 LIMIT 1;
+SELECT * FROM users WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
 -- name: GetUserByEmail :one
-SELECT * FROM users WHERE LOWER(email) = $1 LIMIT 1;
+SELECT * FROM users WHERE LOWER(email) = $1 AND deleted_at IS NULL LIMIT 1;
 -- name: CreateUser :exec
 INSERT INTO users (id, email, password_hash) VALUES ($1, $2, $3);
+-- name: VerifyUserEmail :execrows
+UPDATE users SET email_verified_at = now() WHERE id = $1 AND email = $2 AND email_verified_at IS NULL AND deleted_at IS NULL;
+-- name: UpdateUserPassword :exec
+UPDATE users SET password_hash = $2 WHERE id = $1 AND deleted_at IS NULL;
+-- name: UpgradeUserPasswordHash :execrows
+UPDATE users SET password_hash = sqlc.arg(new_hash) WHERE id = sqlc.arg(id) AND password_hash = sqlc.arg(old_hash) AND deleted_at IS NULL;
+-- name: UpdateUserProfile :one
+UPDATE users SET display_name = COALESCE(sqlc.narg(display_name)::text, display_name),
+    locale = COALESCE(sqlc.narg(locale)::text, locale),
+    timezone = COALESCE(sqlc.narg(timezone)::text, timezone)
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL RETURNING *;
+-- name: UpdateUserEmail :exec
+UPDATE users SET email = $2, email_verified_at = now() WHERE id = $1 AND deleted_at IS NULL;
+-- name: IsEmailTaken :one
+SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(email) = sqlc.arg(email))
+    OR EXISTS(SELECT 1 FROM email_changes WHERE LOWER(new_email) = sqlc.arg(email) AND user_id <> sqlc.arg(user_id) AND expires_at > now()) AS taken;
+-- name: LockEmail :exec
+SELECT pg_advisory_xact_lock(hashtext('email:' || sqlc.arg(email)::text));
+-- name: UpsertEmailChange :exec
+INSERT INTO email_changes (user_id, new_email, expires_at) VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE SET new_email = EXCLUDED.new_email, expires_at = EXCLUDED.expires_at, created_at = now();
+-- name: ConsumeEmailChange :one
+DELETE FROM email_changes WHERE user_id = $1 AND LOWER(new_email) = sqlc.arg(new_email) AND expires_at > now() RETURNING *;
+-- name: DeleteEmailChange :exec
+DELETE FROM email_changes WHERE user_id = $1;
+-- name: DeleteExpiredEmailChanges :execrows
+DELETE FROM email_changes WHERE expires_at < now();
+-- name: SoftDeleteUser :execrows
+UPDATE users SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL;
+-- name: RestoreUser :execrows
+UPDATE users SET deleted_at = NULL WHERE id = $1 AND deleted_at > sqlc.arg(deleted_after)::timestamptz;
+-- name: PurgeDeletedUsers :many
+DELETE FROM users WHERE deleted_at < sqlc.arg(deleted_before)::timestamptz RETURNING id;
+-- name: DeleteUsersRevokedTokens :exec
+DELETE FROM revoked_tokens WHERE user_id = ANY(sqlc.arg(user_ids)::text[]);
+-- name: DeleteUsersTokenRevocations :exec
+DELETE FROM user_token_revocations WHERE user_id = ANY(sqlc.arg(user_ids)::text[]);
+
+-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, client_id, scopes) VALUES ($1, $2, $3, $4, $5, $6, $7);
+-- name: GetRefreshTokenByHash :one
+SELECT * FROM refresh_tokens WHERE token_hash = $1 LIMIT 1;
+-- name: RevokeRefreshToken :execrows
+UPDATE refresh_tokens SET revoked_at = now(), replaced_by = $2 WHERE id = $1 AND revoked_at IS NULL;
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens SET revoked_at = now() WHERE family_id = $1 AND revoked_at IS NULL;
+-- name: RevokeUserRefreshTokens :exec
+UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL;
+-- name: RevokeClientRefreshTokens :exec
+UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND client_id = $2 AND revoked_at IS NULL;
+-- name: RevokeOtherUserRefreshTokens :exec
+UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND family_id <> sqlc.arg(keep_family_id) AND revoked_at IS NULL;
+-- name: DeleteExpiredRefreshTokens :execrows
+DELETE FROM refresh_tokens WHERE expires_at < now();
+-- name: CreateSession :exec
+INSERT INTO sessions (id, user_id, client_id, user_agent, ip) VALUES ($1, $2, $3, $4, $5);
+-- name: GetUserSessions :many
+SELECT * FROM sessions
+WHERE user_id = $1 AND revoked_at IS NULL
+  AND EXISTS(SELECT 1 FROM refresh_tokens WHERE refresh_tokens.family_id = sessions.id AND refresh_tokens.revoked_at IS NULL AND refresh_tokens.expires_at > now())
+ORDER BY last_active_at DESC;
+-- name: RevokeSession :execrows
+UPDATE sessions SET revoked_at = now() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
+-- name: RevokeOtherUserSessions :exec
+UPDATE sessions SET revoked_at = now() WHERE user_id = $1 AND id <> sqlc.arg(keep_id) AND revoked_at IS NULL;
+-- name: RevokeUserSessions :exec
+UPDATE sessions SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL;
+-- name: TouchSession :exec
+UPDATE sessions SET last_active_at = now() WHERE id = $1 AND last_active_at < sqlc.arg(active_before)::timestamptz;
+-- name: DeleteStaleSessions :execrows
+DELETE FROM sessions WHERE NOT EXISTS(SELECT 1 FROM refresh_tokens WHERE refresh_tokens.family_id = sessions.id);
+-- name: RevokeAccessToken :execrows
+INSERT INTO revoked_tokens (jti, user_id, expires_at) VALUES ($1, $2, $3) ON CONFLICT (jti) DO NOTHING;
+-- name: RevokeUserAccessTokens :exec
+INSERT INTO user_token_revocations (user_id, revoked_at, expires_at) VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE SET revoked_at = EXCLUDED.revoked_at, expires_at = EXCLUDED.expires_at;
+-- name: IsAccessTokenRevoked :one
+SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1)
+    OR EXISTS(SELECT 1 FROM user_token_revocations
+              WHERE user_id = $2 AND date_trunc('second', revoked_at) >= sqlc.arg(issued_at)::timestamptz
+                AND NOT EXISTS(SELECT 1 FROM sessions WHERE id = sqlc.arg(session_id)::text AND created_at > user_token_revocations.revoked_at))
+    OR EXISTS(SELECT 1 FROM sessions WHERE id = sqlc.arg(session_id)::text AND revoked_at IS NOT NULL) AS revoked;
+-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_tokens WHERE expires_at < now();
+-- name: DeleteExpiredUserTokenRevocations :execrows
+DELETE FROM user_token_revocations WHERE expires_at < now();
+-- name: GetUserRoles :many
+SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role;
+-- name: GetRolesPermissions :many
+SELECT DISTINCT permission FROM role_permissions WHERE role = ANY(sqlc.arg(roles)::text[]) ORDER BY permission;
+-- name: AssignUserRole :exec
+INSERT INTO user_roles (user_id, role) VALUES ($1, $2) ON CONFLICT DO NOTHING;
+-- name: RemoveUserRole :exec
+DELETE FROM user_roles WHERE user_id = $1 AND role = $2;
+-- name: CountUsersWithRole :one
+SELECT COUNT(*) FROM user_roles WHERE role = $1 AND user_id IN (SELECT id FROM users WHERE deleted_at IS NULL);
+-- name: LockAdminRole :exec
+SELECT pg_advisory_xact_lock(hashtext('user_roles:admin'));
+-- name: GetUserTOTP :one
+SELECT * FROM user_totp WHERE user_id = $1;
+-- name: UpsertUserTOTP :execrows
+INSERT INTO user_totp (user_id, secret) VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = 0, created_at = now()
+WHERE user_totp.confirmed_at IS NULL;
+-- name: ConfirmUserTOTP :execrows
+UPDATE user_totp SET confirmed_at = now(), last_used_step = $2 WHERE user_id = $1 AND confirmed_at IS NULL;
+-- name: UseUserTOTPStep :execrows
+UPDATE user_totp SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2;
+-- name: DeleteUserTOTP :exec
+DELETE FROM user_totp WHERE user_id = $1;
+-- name: CreateRecoveryCode :exec
+INSERT INTO recovery_codes (id, user_id, code_hash) VALUES ($1, $2, $3);
+-- name: UseRecoveryCode :execrows
+UPDATE recovery_codes SET used_at = now() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM recovery_codes WHERE user_id = $1;
+-- name: CreateWebAuthnCredential :exec
+INSERT INTO webauthn_credentials (id, user_id, name, credential_id, public_key, attestation_type, aaguid, transports, sign_count, backup_eligible, backup_state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+-- name: GetUserWebAuthnCredentials :many
+SELECT * FROM webauthn_credentials WHERE user_id = $1 ORDER BY created_at;
+-- name: GetWebAuthnCredentialByCredentialID :one
+SELECT * FROM webauthn_credentials WHERE credential_id = $1;
+-- name: UpdateWebAuthnCredentialUsage :exec
+UPDATE webauthn_credentials SET sign_count = $2, backup_state = $3, last_used_at = now() WHERE id = $1;
+-- name: DeleteWebAuthnCredential :execrows
+DELETE FROM webauthn_credentials WHERE id = $1 AND user_id = $2;
+-- name: CreateWebAuthnSession :exec
+INSERT INTO webauthn_sessions (id, user_id, data, expires_at) VALUES ($1, $2, $3, $4);
+-- name: ConsumeWebAuthnSession :one
+DELETE FROM webauthn_sessions WHERE id = $1 AND expires_at > now() RETURNING *;
+-- name: DeleteExpiredWebAuthnSessions :execrows
+DELETE FROM webauthn_sessions WHERE expires_at <= now();
+-- name: CreatePasswordResetToken :exec
+INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at) VALUES ($1, $2, $3, $4);
+-- name: GetPasswordResetTokenByHash :one
+SELECT * FROM password_reset_tokens WHERE token_hash = $1 LIMIT 1;
+-- name: UsePasswordResetToken :execrows
+UPDATE password_reset_tokens SET used_at = now() WHERE id = $1 AND used_at IS NULL AND expires_at > now();
+-- name: InvalidateUserPasswordResetTokens :exec
+UPDATE password_reset_tokens SET used_at = now() WHERE user_id = $1 AND used_at IS NULL;
+-- name: DeleteExpiredPasswordResetTokens :execrows
+DELETE FROM password_reset_tokens WHERE expires_at < now();
+-- name: GetLockedLoginAttempts :many
+SELECT * FROM login_attempts WHERE subject = ANY(sqlc.arg(subjects)::text[]) AND locked_until > now();
+-- name: RegisterLoginFailure :one
+INSERT INTO login_attempts (subject, failures, last_failure_at) VALUES ($1, 1, now())
+ON CONFLICT (subject) DO UPDATE SET
+    failures = CASE WHEN login_attempts.last_failure_at < sqlc.arg(window_start)::timestamptz THEN 1 ELSE login_attempts.failures + 1 END,
+    last_failure_at = now()
+RETURNING *;
+-- name: LockLoginAttempts :exec
+UPDATE login_attempts SET locked_until = $2 WHERE subject = $1;
+-- name: DeleteLoginAttempts :exec
+DELETE FROM login_attempts WHERE subject = $1;
+-- name: DeleteStaleLoginAttempts :execrows
+DELETE FROM login_attempts WHERE last_failure_at < $1 AND (locked_until IS NULL OR locked_until < now());
+-- name: RegisterMailSend :one
+INSERT INTO mail_sends (subject, sent, window_started_at) VALUES ($1, 1, now())
+ON CONFLICT (subject) DO UPDATE SET
+    sent = CASE WHEN mail_sends.window_started_at < sqlc.arg(window_start)::timestamptz THEN 1 ELSE mail_sends.sent + 1 END,
+    window_started_at = CASE WHEN mail_sends.window_started_at < sqlc.arg(window_start)::timestamptz THEN now() ELSE mail_sends.window_started_at END
+RETURNING *;
+-- name: DeleteStaleMailSends :execrows
+DELETE FROM mail_sends WHERE window_started_at < $1;
+-- name: CreateApiKey :exec
+INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7);
+-- name: GetApiKeyByHash :one
+SELECT * FROM api_keys WHERE key_hash = $1 AND user_id IN (SELECT id FROM users WHERE deleted_at IS NULL) LIMIT 1;
+-- name: GetUserApiKeys :many
+SELECT * FROM api_keys WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at;
+-- name: RevokeApiKey :execrows
+UPDATE api_keys SET revoked_at = now() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
+-- name: RevokeUserApiKeys :exec
+UPDATE api_keys SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL;
+-- name: TouchApiKey :exec
+UPDATE api_keys SET last_used_at = now() WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < sqlc.arg(used_before)::timestamptz);
+-- name: DeleteExpiredApiKeys :execrows
+DELETE FROM api_keys WHERE expires_at < now() OR revoked_at IS NOT NULL;
+-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (provider, subject, user_id, email) VALUES ($1, $2, $3, $4);
+-- name: GetUserIdentity :one
+SELECT * FROM user_identities WHERE provider = $1 AND subject = $2 LIMIT 1;
+-- name: GetUserIdentities :many
+SELECT * FROM user_identities WHERE user_id = $1 ORDER BY created_at;
+-- name: TouchUserIdentity :exec
+UPDATE user_identities SET last_login_at = now(), email = $3 WHERE provider = $1 AND subject = $2;
+-- name: DeleteUserIdentity :execrows
+DELETE FROM user_identities WHERE user_id = $1 AND provider = $2;
+-- name: CreateOidcState :exec
+INSERT INTO oidc_states (id, provider, user_id, nonce, code_verifier, expires_at) VALUES ($1, $2, $3, $4, $5, $6);
+-- name: ConsumeOidcState :one
+DELETE FROM oidc_states WHERE id = $1 AND expires_at > now() RETURNING *;
+-- name: DeleteExpiredOidcStates :execrows
+DELETE FROM oidc_states WHERE expires_at <= now();
+-- name: CreateOauthClient :exec
+INSERT INTO oauth_clients (id, name, secret_hash, redirect_uris, grant_types, scopes, first_party) VALUES ($1, $2, $3, $4, $5, $6, $7);
+-- name: GetOauthClient :one
+SELECT * FROM oauth_clients WHERE id = $1 LIMIT 1;
+-- name: GetOauthClients :many
+SELECT * FROM oauth_clients ORDER BY created_at;
+-- name: DeleteOauthClient :execrows
+DELETE FROM oauth_clients WHERE id = $1;
+-- name: CreateOauthAuthorizationCode :exec
+INSERT INTO oauth_authorization_codes (id, client_id, user_id, redirect_uri, scopes, code_challenge, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7);
+-- name: ConsumeOauthAuthorizationCode :one
+DELETE FROM oauth_authorization_codes WHERE id = $1 AND expires_at > now() RETURNING *;
+-- name: DeleteExpiredOauthAuthorizationCodes :execrows
+DELETE FROM oauth_authorization_codes WHERE expires_at <= now();
+-- name: GetOauthConsent :one
+SELECT * FROM oauth_consents WHERE user_id = $1 AND client_id = $2 LIMIT 1;
+-- name: GetUserOauthConsents :many
+SELECT oauth_consents.client_id, oauth_clients.name AS client_name, oauth_consents.scopes, oauth_consents.created_at, oauth_consents.updated_at
+FROM oauth_consents JOIN oauth_clients ON oauth_clients.id = oauth_consents.client_id
+WHERE oauth_consents.user_id = $1 ORDER BY oauth_consents.created_at;
+-- name: SaveOauthConsent :exec
+INSERT INTO oauth_consents (user_id, client_id, scopes) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, client_id) DO UPDATE SET scopes = EXCLUDED.scopes, updated_at = now();
+-- name: DeleteOauthConsent :execrows
+DELETE FROM oauth_consents WHERE user_id = $1 AND client_id = $2;
+-- name: CreateImpersonationEvent :exec
+INSERT INTO impersonation_events (id, actor_id, user_id, action, status, reason, ip) VALUES ($1, $2, $3, $4, $5, $6, $7);
+-- name: GetImpersonationEvents :many
+SELECT * FROM impersonation_events WHERE user_id = $1 OR actor_id = $1 ORDER BY created_at DESC, id DESC;
+-- name: CreateDataExport :execrows
+INSERT INTO data_exports (id, user_id) VALUES ($1, $2)
+ON CONFLICT (user_id) WHERE status IN ('pending', 'running') DO NOTHING;
+-- name: GetUserDataExports :many
+SELECT id, user_id, status, error, created_at, started_at, completed_at, expires_at
+FROM data_exports WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2;
+-- name: ClaimDataExport :one
+UPDATE data_exports SET status = 'running', started_at = now()
+WHERE id = (
+    SELECT id FROM data_exports
+    WHERE status = 'pending' OR (status = 'running' AND started_at < sqlc.arg(stale_before)::timestamptz)
+    ORDER BY created_at LIMIT 1 FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id;
+-- name: CompleteDataExport :exec
+UPDATE data_exports SET status = 'ready', archive = $2, completed_at = now(), expires_at = $3 WHERE id = $1;
+-- name: FailDataExport :exec
+UPDATE data_exports SET status = 'failed', error = $2, completed_at = now(), expires_at = $3 WHERE id = $1;
+-- name: GetDataExportArchive :one
+SELECT archive FROM data_exports WHERE id = $1 AND user_id = $2 AND status = 'ready' AND expires_at > now();
+-- name: DeleteExpiredDataExports :execrows
+DELETE FROM data_exports WHERE expires_at <= now();
+-- name: DeleteUserDataExports :exec
+DELETE FROM data_exports WHERE user_id = $1;
@@ -1,5 +1,6 @@
 import logging
 from collections.abc import MutableMapping
+from datetime import datetime, timedelta, timezone
 from typing import Any
 from faker import Faker
 import pytest
@@ -23,3 +24,8 @@ def pytest_tavern_beta_after_every_response(expected: Any, response: Any) -> Non
 @pytest.fixture
 def generate_random_email() -> str:
     return fake.email()
+
+
+@pytest.fixture
+def api_key_expires_at() -> str:
+    return (datetime.now(timezone.utc) + timedelta(days=30)).strftime("%Y-%m-%dT%H:%M:%SZ")